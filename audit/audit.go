@@ -0,0 +1,114 @@
+/*
+Package audit contains the types used by the code generated for methods that
+define an Audit DSL to emit audit events. The generated endpoints emit one
+event per request once the endpoint completes, including requests that fail
+authentication or authorization, to a Sink implemented by the service. The
+JSONLinesSink type provides a sink that writes the events as JSON lines, e.g.
+to a local file for testing.
+*/
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+type (
+	// Event is an audit event emitted once the endpoint of a method that
+	// defines an Audit DSL completes.
+	Event struct {
+		// Time is the time the endpoint completed.
+		Time time.Time `json:"time"`
+		// Service is the name of the service.
+		Service string `json:"service"`
+		// Method is the name of the method.
+		Method string `json:"method"`
+		// RequestID is the ID of the request if any, see the RequestID
+		// middlewares.
+		RequestID string `json:"request_id,omitempty"`
+		// Actor is the value referenced by the Actor DSL if any, e.g.
+		// the value of the "sub" claim of the authenticated principal.
+		Actor any `json:"actor,omitempty"`
+		// Targets contains the values referenced by the Target DSLs
+		// indexed by reference, e.g. "payload.id".
+		Targets map[string]any `json:"targets,omitempty"`
+		// Outcome is the outcome of the request.
+		Outcome Outcome `json:"outcome"`
+		// Error is the name of the error returned by the endpoint if
+		// any, e.g. "not_found".
+		Error string `json:"error,omitempty"`
+	}
+
+	// Outcome is the outcome of an audited request.
+	Outcome string
+
+	// Sink receives the audit events. Services whose methods define an Audit
+	// DSL must implement Sink, e.g. by embedding a JSONLinesSink. Emit must
+	// be safe for concurrent use.
+	Sink interface {
+		// Emit records the audit event. The endpoint returns a fault
+		// error if Emit fails and the request succeeded so that events
+		// are never lost silently.
+		Emit(ctx context.Context, e *Event) error
+	}
+)
+
+const (
+	// OutcomeSuccess is the outcome of requests that succeeded.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeDenied is the outcome of requests that failed authentication
+	// or authorization.
+	OutcomeDenied Outcome = "denied"
+	// OutcomeFailure is the outcome of requests that failed for any other
+	// reason.
+	OutcomeFailure Outcome = "failure"
+)
+
+// NewEvent returns an event for the request with the given context that
+// completed with err. The service and method names and the request ID are
+// read from ctx. The generated code sets the event actor and targets.
+func NewEvent(ctx context.Context, err error) *Event {
+	e := &Event{Time: time.Now().UTC(), Outcome: OutcomeSuccess}
+	e.Service, _ = ctx.Value(goa.ServiceKey).(string)
+	e.Method, _ = ctx.Value(goa.MethodKey).(string)
+	e.RequestID, _ = ctx.Value(middleware.RequestIDKey).(string)
+	if err == nil {
+		return e
+	}
+	e.Outcome = OutcomeFailure
+	e.Error = "error"
+	var (
+		aerr  *security.AuthError
+		namer goa.GoaErrorNamer
+	)
+	if errors.As(err, &aerr) {
+		e.Error = goa.Unauthorized
+	} else if errors.As(err, &namer) {
+		e.Error = namer.GoaErrorName()
+	}
+	if e.Error == goa.Unauthorized || e.Error == goa.PermissionDenied {
+		e.Outcome = OutcomeDenied
+	}
+	return e
+}
+
+// Emit emits e to sink and returns err, the error returned by the endpoint.
+// Emit returns a fault error if err is nil and sink fails to record the
+// event.
+func Emit(ctx context.Context, sink Sink, e *Event, err error) error {
+	if serr := sink.Emit(ctx, e); serr != nil && err == nil {
+		return goa.Fault("failed to record audit event: %s", serr)
+	}
+	return err
+}
+
+// Claim returns the value of the given claim of the authenticated principal
+// stored in ctx with security.WithClaims, nil if there isn't any.
+func Claim(ctx context.Context, name string) any {
+	return security.ContextClaims(ctx)[name]
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+func TestNewEvent(t *testing.T) {
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "update")
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, "req-1")
+	cases := []struct {
+		name    string
+		err     error
+		outcome Outcome
+		errName string
+	}{
+		{"success", nil, OutcomeSuccess, ""},
+		{"service error", goa.PermanentError("not_found", "missing"), OutcomeFailure, "not_found"},
+		{"permission denied", goa.PermissionDeniedError(), OutcomeDenied, goa.PermissionDenied},
+		{"auth error", &security.AuthError{}, OutcomeDenied, goa.Unauthorized},
+		{"other error", errors.New("boom"), OutcomeFailure, "error"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEvent(ctx, c.err)
+			if e.Service != "svc" || e.Method != "update" || e.RequestID != "req-1" {
+				t.Errorf("got service %q, method %q and request ID %q", e.Service, e.Method, e.RequestID)
+			}
+			if e.Time.IsZero() {
+				t.Error("got zero time")
+			}
+			if e.Outcome != c.outcome {
+				t.Errorf("got outcome %q, want %q", e.Outcome, c.outcome)
+			}
+			if e.Error != c.errName {
+				t.Errorf("got error %q, want %q", e.Error, c.errName)
+			}
+		})
+	}
+}
+
+type errSink struct{ err error }
+
+func (s errSink) Emit(context.Context, *Event) error { return s.err }
+
+func TestEmit(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		endpErr = goa.PermanentError("not_found", "missing")
+		sinkErr = errors.New("disk full")
+	)
+	if err := Emit(ctx, errSink{}, &Event{}, nil); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := Emit(ctx, errSink{}, &Event{}, endpErr); err != endpErr {
+		t.Errorf("got error %v, want endpoint error", err)
+	}
+	if err := Emit(ctx, errSink{sinkErr}, &Event{}, endpErr); err != endpErr {
+		t.Errorf("got error %v, want endpoint error", err)
+	}
+	err := Emit(ctx, errSink{sinkErr}, &Event{}, nil)
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) || !serr.Fault {
+		t.Errorf("got error %v, want fault", err)
+	}
+}
+
+func TestClaim(t *testing.T) {
+	ctx := security.WithClaims(context.Background(), map[string]any{"sub": "joe"})
+	if got := Claim(ctx, "sub"); got != "joe" {
+		t.Errorf("got %v, want %q", got, "joe")
+	}
+	if got := Claim(context.Background(), "sub"); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+	events := []*Event{
+		{Service: "svc", Method: "update", Actor: "joe", Targets: map[string]any{"payload.id": "1"}, Outcome: OutcomeSuccess},
+		{Service: "svc", Method: "delete", Outcome: OutcomeDenied, Error: goa.PermissionDenied},
+	}
+	for _, e := range events {
+		if err := sink.Emit(context.Background(), e); err != nil {
+			t.Fatalf("got error %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("got error %v on close", err)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != len(events) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(events))
+	}
+	var got map[string]any
+	if err := json.Unmarshal(lines[1], &got); err != nil {
+		t.Fatalf("got invalid JSON line %q: %v", lines[1], err)
+	}
+	if got["outcome"] != "denied" || got["error"] != goa.PermissionDenied || got["method"] != "delete" {
+		t.Errorf("got %v", got)
+	}
+	if _, ok := got["actor"]; ok {
+		t.Errorf("got actor in %v, want omitted", got)
+	}
+}
+
+func TestOpenJSONLinesFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "audit.jsonl")
+	for i := 0; i < 2; i++ {
+		sink, err := OpenJSONLinesFile(name)
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+		if err := sink.Emit(context.Background(), &Event{Outcome: OutcomeSuccess}); err != nil {
+			t.Fatalf("got error %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("got error %v on close", err)
+		}
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(b, []byte("\n")); n != 2 {
+		t.Errorf("got %d lines, want 2 appended lines", n)
+	}
+}
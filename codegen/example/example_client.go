@@ -32,6 +32,7 @@ func exampleCLIMain(_ string, root *expr.RootExpr, svr *expr.ServerExpr) *codege
 	}
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "crypto/tls"},
 		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
@@ -45,6 +45,9 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "time"},
 		{Path: "goa.design/clue/debug"},
 		{Path: "goa.design/clue/log"},
+		{Path: "log/slog"},
+		codegen.GoaImport("middleware"),
+		codegen.GoaImport("security"),
 	}
 
 	// Iterate through services listed in the server expression.
@@ -73,6 +76,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
+	slog := service.ExampleSlog(root)
 
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
@@ -90,6 +94,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Source: readTemplate("server_logger"),
 			Data: map[string]any{
 				"APIPkg": apiPkg,
+				"Slog":   slog,
 			},
 		}, {
 			Name:   "server-main-services",
@@ -106,6 +111,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Source: readTemplate("server_endpoints"),
 			Data: map[string]any{
 				"Services": svcData,
+				"Slog":     slog,
 			},
 			FuncMap: map[string]any{
 				"mustInitServices": mustInitServices,
@@ -119,6 +125,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Data: map[string]any{
 				"Server":   svrdata,
 				"Services": svcData,
+				"Slog":     slog,
 			},
 			FuncMap: map[string]any{
 				"goify":   codegen.Goify,
@@ -129,6 +136,9 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{
 			Name:   "server-main-end",
 			Source: readTemplate("server_end"),
+			Data: map[string]any{
+				"Slog": slog,
+			},
 		},
 	}
 
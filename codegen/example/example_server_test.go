@@ -57,6 +57,8 @@ func TestExampleServerFiles(t *testing.T) {
 		{"service-for-only-http", testdata.ServiceForOnlyHTTPDSL},
 		{"sercice-for-only-grpc", testdata.ServiceForOnlyGRPCDSL},
 		{"service-for-http-and-part-of-grpc", testdata.ServiceForHTTPAndPartOfGRPCDSL},
+		{"slog-logger", testdata.SlogLoggerDSL},
+		{"secured", testdata.SecuredDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
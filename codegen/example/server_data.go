@@ -36,6 +36,14 @@ type (
 		Transports []*TransportData
 		// Dir is the directory name for the generated client and server examples.
 		Dir string
+		// MutualTLS is true if any of the server services methods is
+		// secured with mutual TLS in which case the example client
+		// accepts client certificate and key files.
+		MutualTLS bool
+		// CookieJar is true if any of the server services methods is
+		// secured with a cookie session in which case the example client
+		// keeps the cookies set by the server in a cookie jar.
+		CookieJar bool
 	}
 
 	// HostData contains the data about a single host in a server.
@@ -242,9 +250,32 @@ func buildServerData(svr *expr.ServerExpr) *Data {
 		Variables:   variables,
 		Transports:  transports,
 		Dir:         codegen.SnakeCase(codegen.Goify(svr.Name, true)),
+		MutualTLS:   usesScheme(svr, expr.MutualTLSKind),
+		CookieJar:   usesScheme(svr, expr.SessionKind),
 	}
 }
 
+// usesScheme returns true if any method of the services exposed by svr is
+// secured with a scheme of the given kind.
+func usesScheme(svr *expr.ServerExpr, kind expr.SchemeKind) bool {
+	for _, name := range svr.Services {
+		svc := expr.Root.Service(name)
+		if svc == nil {
+			continue
+		}
+		for _, m := range svc.Methods {
+			for _, req := range m.Requirements {
+				for _, s := range req.Schemes {
+					if s.Kind == kind {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // buildHostData builds the host data for the given host expression.
 func buildHostData(host *expr.HostExpr) *HostData {
 	var (
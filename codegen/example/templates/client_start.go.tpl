@@ -1,4 +1,9 @@
-
+{{- if .Server.MutualTLS }}
+// clientTLSConfig is the TLS configuration used to present the client
+// certificate to servers that require mutual TLS, nil if no certificate was
+// given.
+var clientTLSConfig *tls.Config
+{{ end }}
 func main() {
 	var (
 		hostF = flag.String("host", {{ printf "%q" .Server.DefaultHost.Name }}, "Server host (valid values: {{ (join .Server.AvailableHosts ", ") }})")
@@ -9,6 +14,10 @@ func main() {
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF = flag.Bool("v", false, "Print request and response details")
 		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+	{{- if .Server.MutualTLS }}
+		certF = flag.String("cert", "", "Path to the PEM encoded client certificate used for mutual TLS")
+		keyF = flag.String("key", "", "Path to the PEM encoded client private key used for mutual TLS")
+	{{- end }}
 	)
 	flag.Usage = usage
 	flag.Parse()
\ No newline at end of file
@@ -38,6 +38,16 @@ var (
 		timeout = *timeoutF
 		debug = *verboseF || *vF
 	}
+{{- if .Server.MutualTLS }}
+	if *certF != "" || *keyF != "" {
+		cert, err := tls.LoadX509KeyPair(*certF, *keyF)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid client certificate: %s\n", err)
+			os.Exit(1)
+		}
+		clientTLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+{{- end }}
 
 	var (
 		scheme string
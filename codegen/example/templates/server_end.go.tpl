@@ -1,11 +1,19 @@
 
 
     {{ comment "Wait for signal." }}
+{{- if .Slog }}
+	slog.InfoContext(ctx, "exiting", "reason", <-errc)
+{{- else }}
 	log.Printf(ctx, "exiting (%v)", <-errc)
+{{- end }}
 
 	{{ comment "Send cancellation signal to the goroutines." }}
 	cancel()
 
 	wg.Wait()
+{{- if .Slog }}
+	slog.InfoContext(ctx, "exited")
+{{- else }}
 	log.Printf(ctx, "exited")
+{{- end }}
 }
\ No newline at end of file
@@ -1,3 +1,10 @@
+
+
+	{{ comment "Initialize the metrics collector if metrics are enabled." }}
+	var metrics *middleware.Metrics
+	if *metricsF {
+		metrics = middleware.NewMetrics()
+	}
 {{- if mustInitServices .Services }}
 
 	{{ comment "Wrap the services in endpoints that can be invoked from other services potentially running in different processes." }}
@@ -12,9 +19,24 @@
 	{{- range .Services }}
 		{{- if .Methods }}
 			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+		{{- if not $.Slog }}
 			{{ .VarName }}Endpoints.Use(debug.LogPayloads())
 			{{ .VarName }}Endpoints.Use(log.Endpoint)
 		{{- end }}
+			if metrics != nil {
+				{{ .VarName }}Endpoints.Use(metrics.Endpoint)
+			}
+		{{- if .Schemes }}
+			{{ .VarName }}Endpoints.Use(security.HandleAuthErrors(func(ctx context.Context, err *security.AuthError) {
+				{{ comment "Log why each security scheme failed, the failures are not sent to the client." }}
+			{{- if $.Slog }}
+				slog.InfoContext(ctx, "authentication failed", "auth", err)
+			{{- else }}
+				log.Print(ctx, log.KV{K: "msg", V: "authentication failed"}, log.KV{K: "error", V: err.Details()})
+			{{- end }}
+			}))
+		{{- end }}
+		{{- end }}
 	{{- end }}
 	}
 {{- end }}
\ No newline at end of file
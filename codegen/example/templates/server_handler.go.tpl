@@ -20,14 +20,24 @@
 						}
 					}
 					if !{{ .VarName }}Seen {
+					{{- if $.Slog }}
+						slog.ErrorContext(ctx, "invalid value for URL '{{ .Name }}' variable", "value", *{{ .VarName }}F, "valid", {{ printf "%q" (join .Values ",") }})
+						os.Exit(1)
+					{{- else }}
 						log.Fatal(ctx, fmt.Errorf("invalid value for URL '{{ .Name }}' variable: %q (valid values: {{ join .Values "," }})\n", *{{ .VarName }}F))
+					{{- end }}
 					}
 				{{- end }}
 				addr = strings.Replace(addr, "{{ printf "{%s}" .Name }}", *{{ .VarName }}F, -1)
 			{{- end }}
 			u, err := url.Parse(addr)
 			if err != nil {
+			{{- if $.Slog }}
+				slog.ErrorContext(ctx, "invalid URL", "url", addr, "error", err)
+				os.Exit(1)
+			{{- else }}
 				log.Fatalf(ctx, err, "invalid URL %#v\n", addr)
+			{{- end }}
 			}
 			if *secureF {
 				u.Scheme = "{{ $u.Transport.Type }}s"
@@ -38,17 +48,27 @@
 			if *{{ $u.Transport.Type }}PortF != "" {
 				h, _, err := net.SplitHostPort(u.Host)
 				if err != nil {
+				{{- if $.Slog }}
+					slog.ErrorContext(ctx, "invalid URL", "url", u.Host, "error", err)
+					os.Exit(1)
+				{{- else }}
 					log.Fatalf(ctx, err, "invalid URL %#v\n", u.Host)
+				{{- end }}
 				}
 				u.Host = net.JoinHostPort(h, *{{ $u.Transport.Type }}PortF)
 			} else if u.Port() == "" {
 				u.Host = net.JoinHostPort(u.Host, "{{ $u.Port }}")
 			}
-			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $t := $.Server.Transports }}{{ if eq $t.Type $u.Transport.Type }}{{ range $s := $t.Services }}{{ range $.Services }}{{ if eq $s .Name }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}&wg, errc, *dbgF)
+			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $t := $.Server.Transports }}{{ if eq $t.Type $u.Transport.Type }}{{ range $s := $t.Services }}{{ range $.Services }}{{ if eq $s .Name }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}metrics, &wg, errc, *dbgF)
 		}
 	{{- end }}
 	{{ end }}
 {{- end }}
 	default:
+	{{- if .Slog }}
+		slog.ErrorContext(ctx, "invalid host argument", "host", *hostF, "valid", {{ printf "%q" (join .Server.AvailableHosts "|") }})
+		os.Exit(1)
+	{{- else }}
 		log.Fatal(ctx, fmt.Errorf("invalid host argument: %q (valid hosts: {{ join .Server.AvailableHosts "|" }})", *hostF))
+	{{- end }}
 	}	
\ No newline at end of file
@@ -1,6 +1,16 @@
 
 
 {{ comment "Setup logger. Replace logger with your own log package of choice." }}
+{{- if .Slog }}
+	var level slog.LevelVar
+	if *dbgF {
+		level.Set(slog.LevelDebug)
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: &level})))
+	ctx := context.Background()
+	slog.DebugContext(ctx, "debug logs enabled")
+	slog.InfoContext(ctx, "starting", "http-port", *httpPortF)
+{{- else }}
 	format := log.FormatJSON
 	if log.IsTerminal() {
 		format = log.FormatTerminal
@@ -10,4 +20,5 @@
 		ctx = log.Context(ctx, log.WithDebug())
 		log.Debugf(ctx, "debug logs enabled")
 	}
-	log.Print(ctx, log.KV{K: "http-port", V: *httpPortF})
\ No newline at end of file
+	log.Print(ctx, log.KV{K: "http-port", V: *httpPortF})
+{{- end }}
\ No newline at end of file
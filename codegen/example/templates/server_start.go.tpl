@@ -12,5 +12,6 @@ func main() {
 	{{- end }}
 		secureF = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF  = flag.Bool("debug", false, "Log request and response bodies")
+		metricsF = flag.Bool("metrics", false, "Record request metrics{{ if .Server.HasTransport "http" }} and expose them under /metrics{{ end }}")
 	)
 	flag.Parse()
\ No newline at end of file
@@ -308,3 +308,47 @@ var ConflictWithAPINameAndServiceNamesIncludingMultipartDSL = func() {
 		})
 	})
 }
+
+var SlogLoggerDSL = func() {
+	API("SlogLogger", func() {
+		Meta("example:logger", "slog")
+		Server("SlogLogger", func() {
+			Services("Service")
+			Host("dev", func() {
+				URI("http://example-{version}:8090")
+				URI("grpc://example-{version}:8080")
+				Variable("version", String, "Version", func() {
+					Enum("v1", "v2")
+				})
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+			GRPC(func() {})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var SecuredDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("Service", func() {
+		Method("Method", func() {
+			Security(APIKeyAuth)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Header("key:Authorization")
+			})
+		})
+	})
+}
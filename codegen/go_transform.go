@@ -3,6 +3,7 @@ package codegen
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"text/template"
 
@@ -269,9 +270,9 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				// source attribute is a primitive pointer or not a primitive
 				code += fmt.Sprintf("if %s == nil {\n\t", srcVar)
 				if ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && expr.IsPrimitive(tgtc.Type) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtc.Type), tdef, tgtVar)
+					code += fmt.Sprintf("var tmp %s = %s\n\t%s = &tmp\n", GoNativeTypeName(tgtc.Type), GoDefaultLiteral(tgtc, tdef), tgtVar)
 				} else {
-					code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
+					code += fmt.Sprintf("%s = %s\n", tgtVar, GoDefaultLiteral(tgtc, tdef))
 				}
 				code += "}\n"
 			case expr.IsPrimitive(srcc.Type) && srcMatt.HasDefaultValue(n) && ta.SourceCtx.UseDefault:
@@ -293,7 +294,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				} else {
 					code += fmt.Sprintf("if %s == zero ", tgtVar)
 				}
-				code += fmt.Sprintf("{\n\t%s = %#v\n}\n", tgtVar, tdef)
+				code += fmt.Sprintf("{\n\t%s = %s\n}\n", tgtVar, GoDefaultLiteral(tgtc, tdef))
 				code += "}\n"
 			}
 		}
@@ -306,6 +307,21 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 	return buffer.String(), nil
 }
 
+// GoDefaultLiteral returns the Go literal for the default value v of the
+// attribute att. Values of named byte slice types (e.g. json.RawMessage) are
+// rendered using the type set with the "struct:field:type" meta of att if
+// any, the type name printed by %#v depends on the Go version when the type
+// is an alias.
+func GoDefaultLiteral(att *expr.AttributeExpr, v any) string {
+	if typeName, _ := GetMetaType(att); typeName != "" {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return typeName + strings.TrimPrefix(fmt.Sprintf("%#v", rv.Bytes()), "[]byte")
+		}
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
 // typeStringIsNilable takes a go type as a string and checks for a '[]' or
 // 'map[' prefix to see if it's a nilable primitive type.
 func typeStringIsNilable(typeName string) bool {
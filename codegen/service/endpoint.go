@@ -30,6 +30,11 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// Authorizer is true if any of the endpoints evaluates an
+		// authorization policy.
+		Authorizer bool
+		// Auditor is true if any of the endpoints emits audit events.
+		Auditor bool
 	}
 
 	// EndpointMethodData describes a single endpoint method.
@@ -72,8 +77,15 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			{Path: "fmt"},
 			codegen.GoaImport(""),
 			codegen.GoaImport("security"),
+			codegen.GoaImport("audit"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 		}
+		for _, s := range svc.Schemes {
+			if s.Type == "Signature" && s.MaxClockSkew > 0 {
+				imports = append(imports, &codegen.ImportSpec{Path: "time"})
+				break
+			}
+		}
 		imports = append(imports, svc.UserTypeImports...)
 		header := codegen.Header(service.Name+" endpoints", svc.PkgName, imports)
 		def := &codegen.SectionTemplate{
@@ -120,7 +132,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				Name:    "endpoint-method",
 				Source:  readTemplate("service_endpoint_method"),
 				Data:    m,
-				FuncMap: map[string]any{"payloadVar": payloadVar},
+				FuncMap: map[string]any{"payloadVar": payloadVar, "auditRef": auditRef},
 			})
 		}
 	}
@@ -152,9 +164,17 @@ func endpointData(service *expr.ServiceExpr) *EndpointsData {
 		ClientInitArgs: strings.Join(names, ", "),
 		Methods:        methods,
 		Schemes:        svc.Schemes,
+		Authorizer:     svc.Authorizer,
+		Auditor:        svc.Auditor,
 	}
 }
 
+// auditRef returns the data needed to render the value of the given audit
+// reference.
+func auditRef(ref *AuditRefData, payload string) map[string]any {
+	return map[string]any{"Ref": ref, "Payload": payload}
+}
+
 func payloadVar(e *EndpointMethodData) string {
 	if e.ServerStream != nil || e.SkipRequestBodyEncodeDecode {
 		return "ep.Payload"
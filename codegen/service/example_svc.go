@@ -30,9 +30,20 @@ type (
 		// StreamInterface is the stream interface in the service package used
 		// by the endpoint implementation.
 		StreamInterface string
+		// Slog indicates that the endpoint implementation logs using
+		// log/slog.
+		Slog bool
 	}
 )
 
+// ExampleSlog returns true if the example code generated for the given design
+// logs using log/slog instead of the clue log package. Designs opt in by
+// setting the "example:logger" API meta to "slog".
+func ExampleSlog(root *expr.RootExpr) bool {
+	l, _ := root.API.Meta.Last("example:logger")
+	return l == "slog"
+}
+
 // ExampleServiceFiles returns a basic service implementation for every
 // service expression.
 func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
@@ -58,7 +69,7 @@ func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 }
 
 // exampleServiceFile returns a basic implementation of the given service.
-func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr, apipkg string) *codegen.File {
+func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExpr, apipkg string) *codegen.File {
 	data := Services.Get(svc.Name)
 	svcName := data.PathName
 	fpath := svcName + ".go"
@@ -68,11 +79,16 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 	specs := []*codegen.ImportSpec{
 		{Path: "io"},
 		{Path: "context"},
+		{Path: "crypto/x509"},
 		{Path: "fmt"},
 		{Path: "strings"},
 		{Path: path.Join(genpkg, svcName), Name: data.PkgName},
 		{Path: "goa.design/clue/log"},
+		{Path: "log/slog"},
 		{Path: "goa.design/goa/v3/security"},
+		{Path: "goa.design/goa/v3/security/jwt"},
+		{Path: "goa.design/goa/v3/audit"},
+		{Path: "os"},
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", apipkg, specs),
@@ -94,7 +110,7 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 		})
 	}
 	for _, m := range svc.Methods {
-		sections = append(sections, basicEndpointSection(m, data))
+		sections = append(sections, basicEndpointSection(m, data, ExampleSlog(root)))
 	}
 
 	return &codegen.File{
@@ -106,11 +122,12 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 
 // basicEndpointSection returns a section with a basic implementation for the
 // given method.
-func basicEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTemplate {
+func basicEndpointSection(m *expr.MethodExpr, svcData *Data, slog bool) *codegen.SectionTemplate {
 	md := svcData.Method(m.Name)
 	ed := &basicEndpointData{
 		MethodData:     md,
 		ServiceVarName: svcData.VarName,
+		Slog:           slog,
 	}
 	if m.Payload.Type != expr.Empty {
 		ed.PayloadFullRef = svcData.Scope.GoFullTypeRef(m.Payload, svcData.PkgName)
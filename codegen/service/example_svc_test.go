@@ -48,4 +48,75 @@ func TestExampleServiceFiles(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("jwt validation", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.JWTValidationDSL)
+		fs := ExampleServiceFiles("", expr.Root)
+		require.Len(t, fs, 1)
+		var sec *codegen.SectionTemplate
+		for _, s := range fs[0].SectionTemplates {
+			if s.Name == "security-authfuncs" {
+				sec = s
+			}
+		}
+		require.NotNil(t, sec)
+		code := codegen.SectionCode(t, sec)
+		assert.Equal(t, testdata.JWTValidationAuthFuncsCode, code)
+	})
+
+	t.Run("signature verification", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.SignatureVerificationDSL)
+		fs := ExampleServiceFiles("", expr.Root)
+		require.Len(t, fs, 1)
+		var sec *codegen.SectionTemplate
+		for _, s := range fs[0].SectionTemplates {
+			if s.Name == "security-authfuncs" {
+				sec = s
+			}
+		}
+		require.NotNil(t, sec)
+		code := codegen.SectionCode(t, sec)
+		assert.Equal(t, testdata.SignatureVerificationAuthFuncsCode, code)
+	})
+	t.Run("session csrf token", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.SessionCSRFTokenDSL)
+		fs := ExampleServiceFiles("", expr.Root)
+		require.Len(t, fs, 1)
+		var sec *codegen.SectionTemplate
+		for _, s := range fs[0].SectionTemplates {
+			if s.Name == "security-authfuncs" {
+				sec = s
+			}
+		}
+		require.NotNil(t, sec)
+		code := codegen.SectionCode(t, sec)
+		assert.Equal(t, testdata.SessionCSRFTokenAuthFuncsCode, code)
+	})
+	t.Run("slog logger", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.SlogLoggerDSL)
+		fs := ExampleServiceFiles("", expr.Root)
+		require.Len(t, fs, 1)
+		var sec *codegen.SectionTemplate
+		for _, s := range fs[0].SectionTemplates {
+			if s.Name == "basic-endpoint" {
+				sec = s
+			}
+		}
+		require.NotNil(t, sec)
+		code := codegen.SectionCode(t, sec)
+		assert.Equal(t, testdata.SlogLoggerEndpointCode, code)
+	})
+	t.Run("audit sink", func(t *testing.T) {
+		codegen.RunDSL(t, testdata.AuditDSL)
+		fs := ExampleServiceFiles("", expr.Root)
+		require.Len(t, fs, 1)
+		sections := make(map[string]*codegen.SectionTemplate)
+		for _, s := range fs[0].SectionTemplates {
+			sections[s.Name] = s
+		}
+		require.NotNil(t, sections["basic-service-struct"])
+		require.NotNil(t, sections["basic-service-init"])
+		assert.Equal(t, testdata.AuditServiceStructCode, codegen.SectionCode(t, sections["basic-service-struct"]))
+		assert.Equal(t, testdata.AuditServiceInitCode, codegen.SectionCode(t, sections["basic-service-init"]))
+	})
 }
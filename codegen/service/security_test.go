@@ -21,6 +21,8 @@ func TestSecureEndpointInit(t *testing.T) {
 		{"endpoints-with-requirements", testdata.EndpointsWithRequirementsDSL, testdata.EndpointInitWithRequirementsCode},
 		{"endpoints-with-service-requirements", testdata.EndpointsWithServiceRequirementsDSL, testdata.EndpointInitWithServiceRequirementsCode},
 		{"endpoints-no-security", testdata.EndpointNoSecurityDSL, testdata.EndpointInitNoSecurityCode},
+		{"endpoints-with-policy", testdata.EndpointWithPolicyDSL, testdata.EndpointInitWithPolicyCode},
+		{"endpoints-with-audit", testdata.EndpointWithAuditDSL, testdata.EndpointInitWithAuditCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -46,6 +48,13 @@ func TestSecureEndpoint(t *testing.T) {
 		{"with-optional-required-scopes", testdata.EndpointWithOptionalRequiredScopesDSL, testdata.EndpointWithOptionalRequiredScopesCode},
 		{"with-api-key-override", testdata.EndpointWithAPIKeyOverrideDSL, testdata.EndpointWithAPIKeyOverrideCode},
 		{"with-oauth2", testdata.EndpointWithOAuth2DSL, testdata.EndpointWithOAuth2Code},
+		{"with-openid-connect", testdata.EndpointWithOpenIDConnectDSL, testdata.EndpointWithOpenIDConnectCode},
+		{"with-mutual-tls", testdata.EndpointWithMutualTLSDSL, testdata.EndpointWithMutualTLSCode},
+		{"with-signature", testdata.EndpointWithSignatureDSL, testdata.EndpointWithSignatureCode},
+		{"with-session", testdata.EndpointWithSessionDSL, testdata.EndpointWithSessionCode},
+		{"with-policy", testdata.EndpointWithPolicyDSL, testdata.EndpointWithPolicyCode},
+		{"with-audit", testdata.EndpointWithAuditDSL, testdata.EndpointWithAuditCode},
+		{"with-alternative-requirements", testdata.EndpointWithAlternativeRequirementsDSL, testdata.EndpointWithAlternativeRequirementsCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -100,6 +100,17 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		})
 	}
 
+	redactedPaths := make(map[string]struct{})
+	for _, t := range svc.redactedTypes {
+		path := pathWithDefault(t.Loc, svcPath)
+		redactedPaths[path] = struct{}{}
+		addTypeDefSection(path, "~"+t.VarName+".String", &codegen.SectionTemplate{
+			Name:   "service-redacted-type-methods",
+			Source: readTemplate("redacted_type"),
+			Data:   t,
+		})
+	}
+
 	for _, et := range errorTypes {
 		// Don't override the section created for the error type
 		// declaration, make sure the key does not clash with existing
@@ -168,6 +179,15 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		codegen.GoaImport("security"),
 		codegen.NewImport(svc.ViewsPkg, genpkg+"/"+svcName+"/views"),
 	}
+	if _, ok := redactedPaths[svcPath]; ok {
+		imports = append(imports, codegen.SimpleImport("log/slog"))
+	}
+	for _, s := range svc.Schemes {
+		if s.Type == "MutualTLS" {
+			imports = append(imports, codegen.SimpleImport("crypto/x509"))
+			break
+		}
+	}
 	imports = append(imports, svc.UserTypeImports...)
 	header := codegen.Header(service.Name+" service", svc.PkgName, imports)
 	def := &codegen.SectionTemplate{
@@ -228,7 +248,11 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		}
 		fullRelPath := filepath.Join(codegen.Gendir, p)
 		dir, _ := filepath.Split(fullRelPath)
-		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), nil)
+		var imports []*codegen.ImportSpec
+		if _, ok := redactedPaths[p]; ok {
+			imports = []*codegen.ImportSpec{codegen.SimpleImport("log/slog"), codegen.GoaImport("")}
+		}
+		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), imports)
 		sections := append([]*codegen.SectionTemplate{h}, secs...)
 		files = append(files, &codegen.File{Path: fullRelPath, SectionTemplates: sections})
 	}
@@ -6,9 +6,11 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/security/policy"
 )
 
 // Services holds the data computed from the design needed to generate the code
@@ -70,6 +72,14 @@ type (
 		Methods []*MethodData
 		// Schemes is the list of security schemes required by the service methods.
 		Schemes SchemesData
+		// Authorizer is true if any of the service methods defines an
+		// authorization policy in which case the service package defines
+		// the Authorizer interface.
+		Authorizer bool
+		// Auditor is true if any of the service methods defines an
+		// Audit DSL in which case the service must implement
+		// audit.Sink.
+		Auditor bool
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
@@ -97,6 +107,21 @@ type (
 		viewedResultTypes []*ViewedResultTypeData
 		// unionValueMethods lists the methods used to define union types.
 		unionValueMethods []*UnionValueMethodData
+		// redactedTypes lists the types that define sensitive fields.
+		redactedTypes []*RedactedTypeData
+	}
+
+	// RedactedTypeData describes a type that defines sensitive fields. The
+	// String, GoString and LogValue methods generated for the type redact
+	// the values of these fields.
+	RedactedTypeData struct {
+		// VarName is the Go type name.
+		VarName string
+		// Fields lists the names of the sensitive struct fields.
+		Fields []string
+		// Loc defines the file and Go package of the methods if
+		// overridden in corresponding type via Meta.
+		Loc *codegen.Location
 	}
 
 	// UnionValueMethodData describes a method used on a union value type.
@@ -188,6 +213,11 @@ type (
 		// Schemes contains the security schemes types used by the
 		// method.
 		Schemes SchemesData
+		// Policy is the authorization policy of the method if any.
+		Policy *PolicyData
+		// Audit describes the audit events emitted by the method
+		// endpoint if any.
+		Audit *AuditData
 		// ViewedResult contains the data required to generate the code handling
 		// views if any.
 		ViewedResult *ViewedResultTypeData
@@ -280,10 +310,59 @@ type (
 		Type expr.UserType
 	}
 
+	// PolicyData describes the authorization policy of a method.
+	PolicyData struct {
+		// Expr is the policy expression, it combines all the policies
+		// defined on the method.
+		Expr string
+		// Attributes lists the payload attributes referenced by the
+		// policy.
+		Attributes []*PolicyAttributeData
+	}
+
+	// PolicyAttributeData describes a payload attribute referenced by an
+	// authorization policy.
+	PolicyAttributeData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the name of the corresponding payload struct
+		// field.
+		FieldName string
+	}
+
+	// AuditData describes the audit events emitted by a method endpoint.
+	AuditData struct {
+		// Actor is the reference to the value that identifies the
+		// actor if any.
+		Actor *AuditRefData
+		// Targets lists the references to the values that identify the
+		// targets.
+		Targets []*AuditRefData
+	}
+
+	// AuditRefData describes a value referenced by the Actor or Target
+	// DSLs.
+	AuditRefData struct {
+		// Ref is the reference as written in the design, e.g.
+		// "payload.id".
+		Ref string
+		// Claim is the name of the referenced claim, empty if the
+		// reference is to a payload attribute.
+		Claim string
+		// FieldName is the name of the payload struct field holding the
+		// referenced attribute value, empty if the reference is to a
+		// claim.
+		FieldName string
+		// Sensitive is true if the referenced attribute is sensitive in
+		// which case its value is redacted.
+		Sensitive bool
+	}
+
 	// SchemeData describes a single security scheme.
 	SchemeData struct {
-		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT"
-		// or "OAuth2".
+		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT",
+		// "OAuth2", "OpenIDConnect", "MutualTLS", "Signature" or
+		// "Session".
 		Type string
 		// SchemeName is the name of the scheme.
 		SchemeName string
@@ -329,6 +408,30 @@ type (
 		Flows []*expr.FlowExpr
 		// In indicates the request element that holds the credential.
 		In string
+		// JWKS is the path to the JSON Web Key Set file used to verify
+		// JWT tokens if any.
+		JWKS string
+		// Issuer is the expected JWT issuer if any.
+		Issuer string
+		// Audience lists the accepted JWT audiences if any.
+		Audience []string
+		// DiscoveryURL is the OpenID Connect discovery URL if any.
+		DiscoveryURL string
+		// SignedComponents lists the request components that must be
+		// covered by the request signature if any.
+		SignedComponents []string
+		// MaxClockSkew is the maximum request signature clock skew in
+		// seconds if any.
+		MaxClockSkew int64
+		// CSRF is the CSRF protection mode of cookie session schemes if
+		// any.
+		CSRF string
+		// CSRFHeader is the name of the header that holds the CSRF
+		// token if any.
+		CSRFHeader string
+		// CSRFCookie is the name of the cookie that holds the CSRF token
+		// in double-submit mode if any.
+		CSRFCookie string
 	}
 
 	// ViewedResultTypeData contains the data used to generate a viewed result type
@@ -516,6 +619,23 @@ func (r RequirementsData) Scheme(name string) *SchemeData {
 	return nil
 }
 
+// Schemes returns the schemes listed by the requirements in evaluation order.
+// Schemes listed by multiple requirements appear once.
+func (r RequirementsData) Schemes() []*SchemeData {
+	var schemes []*SchemeData
+	seen := make(map[string]struct{})
+	for _, req := range r {
+		for _, s := range req.Schemes {
+			if _, ok := seen[s.SchemeName]; ok {
+				continue
+			}
+			seen[s.SchemeName] = struct{}{}
+			schemes = append(schemes, s)
+		}
+	}
+	return schemes
+}
+
 // Dup creates a copy of the scheme data.
 func (s *SchemeData) Dup() *SchemeData {
 	return &SchemeData{
@@ -537,6 +657,15 @@ func (s *SchemeData) Dup() *SchemeData {
 		Scopes:           s.Scopes,
 		Flows:            s.Flows,
 		In:               s.In,
+		JWKS:             s.JWKS,
+		Issuer:           s.Issuer,
+		Audience:         s.Audience,
+		DiscoveryURL:     s.DiscoveryURL,
+		SignedComponents: s.SignedComponents,
+		MaxClockSkew:     s.MaxClockSkew,
+		CSRF:             s.CSRF,
+		CSRFHeader:       s.CSRFHeader,
+		CSRFCookie:       s.CSRFCookie,
 	}
 }
 
@@ -671,8 +800,10 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 	}
 
 	var (
-		methods []*MethodData
-		schemes SchemesData
+		methods    []*MethodData
+		schemes    SchemesData
+		authorizer bool
+		auditor    bool
 	)
 	{
 		methods = make([]*MethodData, len(service.Methods))
@@ -682,6 +813,12 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 			for _, s := range m.Schemes {
 				schemes = schemes.Append(s)
 			}
+			if m.Policy != nil {
+				authorizer = true
+			}
+			if m.Audit != nil {
+				auditor = true
+			}
 			rt, ok := e.Result.Type.(*expr.ResultTypeExpr)
 			if !ok {
 				continue
@@ -746,6 +883,24 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	var (
+		redactedTypes []*RedactedTypeData
+	)
+	{
+		seen := make(map[string]struct{})
+		for _, t := range types {
+			redactedTypes = append(redactedTypes, collectRedactedTypes(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, t := range errTypes {
+			redactedTypes = append(redactedTypes, collectRedactedTypes(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, m := range service.Methods {
+			redactedTypes = append(redactedTypes, collectRedactedTypes(m.Payload, scope, seen)...)
+			redactedTypes = append(redactedTypes, collectRedactedTypes(m.StreamingPayload, scope, seen)...)
+			redactedTypes = append(redactedTypes, collectRedactedTypes(m.Result, scope, seen)...)
+		}
+	}
+
 	var (
 		desc string
 	)
@@ -769,6 +924,8 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		ViewsPkg:           viewspkg,
 		Methods:            methods,
 		Schemes:            schemes,
+		Authorizer:         authorizer,
+		Auditor:            auditor,
 		Scope:              scope,
 		ViewScope:          viewScope,
 		errorTypes:         errTypes,
@@ -778,6 +935,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		viewedUnionMethods: viewedUnionMeths,
 		viewedResultTypes:  viewedRTs,
 		unionValueMethods:  unionMethods,
+		redactedTypes:      redactedTypes,
 	}
 	d[service.Name] = data
 
@@ -876,6 +1034,57 @@ func collectUnionMethods(att *expr.AttributeExpr, scope *codegen.NameScope, loc
 	return
 }
 
+// collectRedactedTypes traverses the given attribute recursively and returns
+// the user types that define sensitive fields.
+func collectRedactedTypes(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*RedactedTypeData) {
+	if att == nil || att.Type == expr.Empty {
+		return
+	}
+	collect := func(at *expr.AttributeExpr) []*RedactedTypeData {
+		return collectRedactedTypes(at, scope, seen)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return nil
+		}
+		seen[dt.ID()] = struct{}{}
+		if dt == expr.ErrorResult {
+			return nil
+		}
+		if obj := expr.AsObject(dt); obj != nil {
+			var fields []string
+			for _, nat := range *obj {
+				if nat.Attribute.IsSensitive() {
+					fields = append(fields, codegen.GoifyAtt(nat.Attribute, nat.Name, true))
+				}
+			}
+			if len(fields) > 0 {
+				data = append(data, &RedactedTypeData{
+					VarName: scope.GoTypeName(&expr.AttributeExpr{Type: dt}),
+					Fields:  fields,
+					Loc:     codegen.UserTypeLocation(dt),
+				})
+			}
+		}
+		data = append(data, collect(dt.Attribute())...)
+	case *expr.Object:
+		for _, nat := range *dt {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	case *expr.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	}
+	return
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *expr.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Meta["goa:error:temporary"]
@@ -995,6 +1204,8 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		ErrorLocs:                    errorLocs,
 		Requirements:                 reqs,
 		Schemes:                      schemes,
+		Policy:                       buildPolicyData(m),
+		Audit:                        buildAuditData(m),
 		StreamKind:                   m.Stream,
 		SkipRequestBodyEncodeDecode:  httpMet != nil && httpMet.SkipRequestBodyEncodeDecode,
 		SkipResponseBodyEncodeDecode: httpMet != nil && httpMet.SkipResponseBodyEncodeDecode,
@@ -1082,8 +1293,99 @@ func initStreamData(data *MethodData, m *expr.MethodExpr, vname, rname, resultRe
 	data.StreamingPayloadEx = spayloadEx
 }
 
+// buildPolicyData builds the authorization policy data of the given method,
+// nil if the method does not define any policy.
+func buildPolicyData(m *expr.MethodExpr) *PolicyData {
+	if len(m.Policies) == 0 {
+		return nil
+	}
+	pexpr := m.Policies[0]
+	if len(m.Policies) > 1 {
+		exprs := make([]string, len(m.Policies))
+		for i, p := range m.Policies {
+			exprs[i] = "(" + p + ")"
+		}
+		pexpr = strings.Join(exprs, " and ")
+	}
+	pol, err := policy.Parse(pexpr)
+	if err != nil {
+		panic(err) // bug, policies are validated by the expr package
+	}
+	attrs := pol.PayloadAttributes()
+	obj := expr.AsObject(m.Payload.Type)
+	data := &PolicyData{Expr: pexpr, Attributes: make([]*PolicyAttributeData, len(attrs))}
+	for i, att := range attrs {
+		data.Attributes[i] = &PolicyAttributeData{Name: att, FieldName: codegen.GoifyAtt(obj.Attribute(att), att, true)}
+	}
+	return data
+}
+
+// buildAuditData builds the audit data of the given method, nil if the method
+// does not define an Audit DSL.
+func buildAuditData(m *expr.MethodExpr) *AuditData {
+	if m.Audit == nil {
+		return nil
+	}
+	ref := func(r string) *AuditRefData {
+		if claim, ok := strings.CutPrefix(r, expr.AuditAuthPrefix); ok {
+			return &AuditRefData{Ref: r, Claim: claim}
+		}
+		name := strings.TrimPrefix(r, expr.AuditPayloadPrefix)
+		att := expr.AsObject(m.Payload.Type).Attribute(name)
+		return &AuditRefData{
+			Ref:       r,
+			FieldName: codegen.GoifyAtt(att, name, true),
+			Sensitive: att.IsSensitive(),
+		}
+	}
+	data := &AuditData{Targets: make([]*AuditRefData, len(m.Audit.Targets))}
+	if m.Audit.Actor != "" {
+		data.Actor = ref(m.Audit.Actor)
+	}
+	for i, t := range m.Audit.Targets {
+		data.Targets[i] = ref(t)
+	}
+	return data
+}
+
 // BuildSchemeData builds the scheme data for the given scheme and method expr.
 func BuildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
+	if s.Kind == expr.MutualTLSKind {
+		// Mutual TLS credentials come from the TLS handshake, not the
+		// payload.
+		var scopes []string
+		if len(s.Scopes) > 0 {
+			scopes = make([]string, len(s.Scopes))
+			for i, s := range s.Scopes {
+				scopes[i] = s.Name
+			}
+		}
+		return &SchemeData{
+			Type:       s.Kind.String(),
+			SchemeName: s.SchemeName,
+			Scopes:     scopes,
+		}
+	}
+	if s.Kind == expr.SignatureKind {
+		// Request signatures are computed by the transport from the
+		// request, not the payload.
+		var scopes []string
+		if len(s.Scopes) > 0 {
+			scopes = make([]string, len(s.Scopes))
+			for i, s := range s.Scopes {
+				scopes[i] = s.Name
+			}
+		}
+		return &SchemeData{
+			Type:             s.Kind.String(),
+			SchemeName:       s.SchemeName,
+			Name:             s.Name,
+			In:               s.In,
+			Scopes:           scopes,
+			SignedComponents: s.SignedComponents,
+			MaxClockSkew:     int64(s.MaxClockSkew / time.Second),
+		}
+	}
 	if !expr.IsObject(m.Payload.Type) {
 		return nil
 	}
@@ -1136,6 +1438,33 @@ func BuildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 			}
 		}
 	case expr.JWTKind:
+		if keyAtt := expr.TaggedAttribute(m.Payload, "security:token"); keyAtt != "" {
+			key := codegen.Goify(keyAtt, true)
+			var scopes []string
+			if len(s.Scopes) > 0 {
+				scopes = make([]string, len(s.Scopes))
+				for i, s := range s.Scopes {
+					scopes[i] = s.Name
+				}
+			}
+			jwks, _ := s.Meta.Last(expr.JWTJWKSMetaKey)
+			iss, _ := s.Meta.Last(expr.JWTIssuerMetaKey)
+			return &SchemeData{
+				Type:         s.Kind.String(),
+				Name:         s.Name,
+				SchemeName:   s.SchemeName,
+				CredField:    key,
+				CredPointer:  m.Payload.IsPrimitivePointer(keyAtt, true),
+				CredRequired: m.Payload.IsRequired(keyAtt),
+				KeyAttr:      keyAtt,
+				Scopes:       scopes,
+				In:           s.In,
+				JWKS:         jwks,
+				Issuer:       iss,
+				Audience:     s.Meta[expr.JWTAudienceMetaKey],
+			}
+		}
+	case expr.OpenIDConnectKind:
 		if keyAtt := expr.TaggedAttribute(m.Payload, "security:token"); keyAtt != "" {
 			key := codegen.Goify(keyAtt, true)
 			var scopes []string
@@ -1155,6 +1484,36 @@ func BuildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 				KeyAttr:      keyAtt,
 				Scopes:       scopes,
 				In:           s.In,
+				DiscoveryURL: s.DiscoveryURL,
+			}
+		}
+	case expr.SessionKind:
+		if keyAtt := expr.TaggedAttribute(m.Payload, "security:session"); keyAtt != "" {
+			key := codegen.Goify(keyAtt, true)
+			var scopes []string
+			if len(s.Scopes) > 0 {
+				scopes = make([]string, len(s.Scopes))
+				for i, s := range s.Scopes {
+					scopes[i] = s.Name
+				}
+			}
+			name := s.Name
+			if name == "" {
+				name = s.SessionCookie
+			}
+			return &SchemeData{
+				Type:         s.Kind.String(),
+				Name:         name,
+				SchemeName:   s.SchemeName,
+				CredField:    key,
+				CredPointer:  m.Payload.IsPrimitivePointer(keyAtt, true),
+				CredRequired: m.Payload.IsRequired(keyAtt),
+				KeyAttr:      keyAtt,
+				Scopes:       scopes,
+				In:           "cookie",
+				CSRF:         s.CSRF,
+				CSRFHeader:   s.CSRFHeader,
+				CSRFCookie:   s.CSRFCookie,
 			}
 		}
 	case expr.OAuth2Kind:
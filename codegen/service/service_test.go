@@ -25,6 +25,7 @@ func TestService(t *testing.T) {
 		{"service-single", testdata.SingleMethodDSL, testdata.SingleMethod},
 		{"service-multiple", testdata.MultipleMethodsDSL, testdata.MultipleMethods},
 		{"service-union", testdata.UnionMethodDSL, testdata.UnionMethod},
+		{"service-sensitive", testdata.SensitiveMethodDSL, testdata.SensitiveMethod},
 		{"service-multi-union", testdata.MultiUnionMethodDSL, testdata.MultiUnionMethod},
 		{"service-no-payload-no-result", testdata.EmptyMethodDSL, testdata.EmptyMethod},
 		{"service-payload-no-result", testdata.EmptyResultMethodDSL, testdata.EmptyResultMethod},
@@ -24,6 +24,10 @@ func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .Pay
 		{{- end }}
 	{{- end }}
 {{- end }}
+{{- if .Slog }}
+	slog.InfoContext(ctx, "{{ .ServiceVarName }}.{{ .Name }}")
+{{- else }}
 	log.Printf(ctx, "{{ .ServiceVarName }}.{{ .Name }}")
+{{- end }}
 	return
 }
@@ -0,0 +1,14 @@
+{{ printf "String returns a representation of %s with the sensitive fields redacted." .VarName | comment }}
+func (t *{{ .VarName }}) String() string {
+	return goa.RedactString(t{{ range .Fields }}, {{ printf "%q" . }}{{ end }})
+}
+
+{{ printf "GoString returns a Go representation of %s with the sensitive fields redacted." .VarName | comment }}
+func (t *{{ .VarName }}) GoString() string {
+	return goa.RedactGoString(t{{ range .Fields }}, {{ printf "%q" . }}{{ end }})
+}
+
+{{ printf "LogValue implements slog.LogValuer, it redacts the sensitive fields of %s." .VarName | comment }}
+func (t *{{ .VarName }}) LogValue() slog.Value {
+	return goa.RedactLogValue(t{{ range .Fields }}, {{ printf "%q" . }}{{ end }})
+}
@@ -1,9 +1,68 @@
 {{ range .Schemes }}
+{{- if and (eq .Type "JWT") .JWKS }}
+{{ printf "%sJWTValidator returns the validator used to verify the tokens of the %q security scheme." $.VarName .SchemeName | comment }}
+var {{ $.VarName }}JWTValidator = jwt.Lazy(
+	jwt.WithJWKSFile({{ printf "%q" .JWKS }}),
+{{- if .Issuer }}
+	jwt.WithIssuer({{ printf "%q" .Issuer }}),
+{{- end }}
+{{- if .Audience }}
+	jwt.WithAudience({{ range $i, $a := .Audience }}{{ if $i }}, {{ end }}{{ printf "%q" $a }}{{ end }}),
+{{- end }}
+)
+
 {{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme." .Type $.Name .SchemeName | comment }}
-func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+//
+// It verifies the token signature, expiry, issuer and audience and checks the
+// token scopes against the scopes required by the scheme. The verified claims
+// are available to the endpoint via jwt.ContextClaims.
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, token string, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
+	v, err := {{ $.VarName }}JWTValidator()
+	if err != nil {
+		return ctx, err
+	}
+	//
+	// The validator errors may be mapped to one of the generated error
+	// structs, e.g.:
+	//
+	//    if errors.Is(err, jwt.ErrTokenExpired) {
+	//        return ctx, myservice.MakeUnauthorizedError("token expired")
+	//    }
+	//
+	return v.Auth(ctx, token, scheme)
+}
+{{- else if eq .Type "Signature" }}
+{{ printf "%sSignatureNonces records the nonces of the requests signed for the %q security scheme so that they cannot be replayed." $.VarName .SchemeName | comment }}
+var {{ $.VarName }}SignatureNonces = security.NewMemoryNonceStore()
+
+// SignatureKey returns the secret key identified by keyID that is shared with
+// the client and used to verify the request signatures.
+func (s *{{ $.VarName }}srvc) SignatureKey(ctx context.Context, keyID string) ([]byte, error) {
+	//
+	// TBD: look up the key shared with the client.
+	//
+	return nil, fmt.Errorf("not implemented")
+}
+
+// SignatureNonces returns the store used to record the nonces of the verified
+// request signatures. The default in-memory store is only suitable for services
+// running a single instance.
+func (s *{{ $.VarName }}srvc) SignatureNonces() security.NonceStore {
+	return {{ $.VarName }}SignatureNonces
+}
+{{- else }}
+{{ printf "%sAuth implements the authorization logic for service %q for the %q security scheme." .Type $.Name .SchemeName | comment }}
+func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MutualTLS" }}chain []*x509.Certificate{{ else if eq .Type "Session" }}sessionID string{{ else }}token string{{ end }}, scheme *security.{{ .Type }}Scheme) (context.Context, error) {
 	//
 	// TBD: add authorization logic.
 	//
+{{- if eq .CSRF "synchronizer-token" }}
+	// The CSRF token associated with the session must be stored in the
+	// returned context so that it may be checked, e.g.:
+	//
+	//    ctx = security.WithCSRFToken(ctx, session.CSRFToken)
+	//
+{{- end }}
 	// In case of authorization failure this function should return
 	// one of the generated error structs, e.g.:
 	//
@@ -18,3 +77,4 @@ func (s *{{ $.VarName }}srvc) {{ .Type }}Auth(ctx context.Context, {{ if eq .Typ
 	return ctx, fmt.Errorf("not implemented")
 }
 {{- end }}
+{{- end }}
@@ -30,12 +30,33 @@ type Service interface {
 // Auther defines the authorization functions to be implemented by the service.
 type Auther interface {
 	{{- range .Schemes }}
+	{{- if eq .Type "Signature" }}
+	// SignatureKey returns the secret key identified by keyID used to verify
+	// the request signatures.
+	SignatureKey(ctx context.Context, keyID string) ([]byte, error)
+	// SignatureNonces returns the store used to record the nonces of the
+	// verified request signatures so that requests cannot be replayed.
+	SignatureNonces() security.NonceStore
+	{{- else }}
 	{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
-	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass string{{ else if eq .Type "APIKey" }}key string{{ else if eq .Type "MutualTLS" }}chain []*x509.Certificate{{ else if eq .Type "Session" }}sessionID string{{ else }}token string{{ end }}, schema *security.{{ .Type }}Scheme) (context.Context, error)
+	{{- end }}
 	{{- end }}
 }
 {{- end }}
 
+{{- if .Authorizer }}
+// Authorizer defines the authorization policy evaluation function that may be
+// implemented by the service to override the default evaluation performed by
+// security.Authorize.
+type Authorizer interface {
+	// Authorize returns a non-nil error if the request does not satisfy the
+	// authorization policy. payload holds the values of the payload attributes
+	// referenced by the policy indexed by attribute name.
+	Authorize(ctx context.Context, policy *security.Policy, payload map[string]any) error
+}
+{{- end }}
+
 // APIName is the name of the API as defined in the design.
 const APIName = {{ printf "%q" .APIName }}
 
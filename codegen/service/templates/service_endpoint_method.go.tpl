@@ -1,7 +1,17 @@
 
 
 {{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
-func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
+func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, {{ if eq .Type "Signature" }}signatureKeyFn security.SignatureKeyFunc, nonces security.NonceStore{{ else }}auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}{{ end }}{{ if .Policy }}, authorizeFn security.AuthorizeFunc{{ end }}{{ if .Audit }}, sink audit.Sink{{ end }}) goa.Endpoint {
+{{- if .Policy }}
+	policy := security.MustParsePolicy({{ printf "%q" .Policy.Expr }})
+{{- end }}
+{{- if .Requirements }}
+	schemes := []*security.SchemeRef{
+	{{- range .Requirements.Schemes }}
+		{Name: {{ printf "%q" .SchemeName }}, Type: {{ printf "%q" .Type }}},
+	{{- end }}
+	}
+{{- end }}
 	return func(ctx context.Context, req any) (any, error) {
 {{- if or .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
@@ -11,8 +21,14 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		p := req.({{ .PayloadRef }})
 {{- end }}
 {{- $payload := payloadVar . }}
+{{- if .Audit }}
+		res, err := func() (any, error) {
+{{- end }}
 {{- if .Requirements }}
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 	{{- range $ridx, $r := .Requirements }}
 		{{- if ne $ridx 0 }}
 		if err != nil {
@@ -70,6 +86,64 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
 
+			{{- else if eq .Type "OpenIDConnect" }}
+				sc := security.OpenIDConnectScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					DiscoveryURL: {{ printf "%q" .DiscoveryURL }},
+				}
+				{{- if $s.CredPointer }}
+				var token string
+				if {{ $payload }}.{{ $s.CredField }} != nil {
+					token = *{{ $payload }}.{{ $s.CredField }}
+				}
+				{{- end }}
+				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
+
+			{{- else if eq .Type "MutualTLS" }}
+				sc := security.MutualTLSScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+				}
+				ctx, err = auth{{ .Type }}Fn(ctx, security.ContextClientCertificates(ctx), &sc)
+
+			{{- else if eq .Type "Signature" }}
+				sc := security.SignatureScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					Components: []string{ {{- range .SignedComponents }}{{ printf "%q" . }}, {{ end }} },
+					{{- if .MaxClockSkew }}
+					MaxClockSkew: {{ .MaxClockSkew }} * time.Second,
+					{{- end }}
+				}
+				err = security.VerifySignature(ctx, security.ContextSignature(ctx), &sc, signatureKeyFn, nonces)
+
+			{{- else if eq .Type "Session" }}
+				sc := security.SessionScheme{
+					Name: {{ printf "%q" .SchemeName }},
+					Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+					RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+					Cookie: {{ printf "%q" .Name }},
+					{{- if .CSRF }}
+					CSRF: {{ printf "%q" .CSRF }},
+					{{- end }}
+				}
+				{{- if $s.CredPointer }}
+				var sessionID string
+				if {{ $payload }}.{{ $s.CredField }} != nil {
+					sessionID = *{{ $payload }}.{{ $s.CredField }}
+				}
+				{{- end }}
+				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}sessionID{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
+				{{- if .CSRF }}
+				if err == nil {
+					err = security.VerifyCSRF(ctx, &sc)
+				}
+				{{- end }}
+
 			{{- else if eq .Type "OAuth2" }}
 				sc := security.OAuth2Scheme{
 					Name: {{ printf "%q" .SchemeName }},
@@ -103,6 +177,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
 
 			{{- end }}
+				if err != nil {
+					failures = append(failures, &security.SchemeError{Requirement: {{ $ridx }}, Scheme: {{ printf "%q" .SchemeName }}, Type: {{ printf "%q" .Type }}, Err: err})
+				}
 			{{- if ne $sidx 0 }}
 				}
 			{{- end }}
@@ -111,6 +188,16 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		}
 		{{- end }}
 	{{- end }}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+{{- end }}
+{{- if .Policy }}
+		{{ if .Requirements }}err = {{ else }}err := {{ end }}authorizeFn(ctx, policy, {{ if .Policy.Attributes }}map[string]any{
+		{{- range .Policy.Attributes }}
+			{{ printf "%q" .Name }}: {{ $payload }}.{{ .FieldName }},
+		{{- end }}
+		}{{ else }}nil{{ end }})
 		if err != nil {
 			return nil, err
 		}
@@ -149,6 +236,28 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 	return &{{ .ResponseStruct }}{ {{ if .ResultRef }}Result: res, {{ end }}Body: body }, nil
 {{- else }}
 	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+{{- end }}
+{{- if .Audit }}
+		}()
+		ev := audit.NewEvent(ctx, err)
+	{{- with .Audit.Actor }}
+		ev.Actor = {{ template "audit_value" (auditRef . $payload) }}
+	{{- end }}
+	{{- if .Audit.Targets }}
+		ev.Targets = map[string]any{
+		{{- range .Audit.Targets }}
+			{{ printf "%q" .Ref }}: {{ template "audit_value" (auditRef . $payload) }},
+		{{- end }}
+		}
+	{{- end }}
+		return res, audit.Emit(ctx, sink, ev, err)
 {{- end }}
 	}
 }
+
+{{- define "audit_value" }}
+	{{- if .Ref.Claim }}audit.Claim(ctx, {{ printf "%q" .Ref.Claim }})
+	{{- else if .Ref.Sensitive }}goa.Redacted
+	{{- else }}{{ .Payload }}.{{ .Ref.FieldName }}
+	{{- end }}
+{{- end }}
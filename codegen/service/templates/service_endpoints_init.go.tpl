@@ -5,10 +5,21 @@ func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
+{{- end }}
+{{- if .Authorizer }}
+	// Use the service Authorizer implementation if any
+	authorize := security.Authorize
+	if z, ok := s.(Authorizer); ok {
+		authorize = z.Authorize
+	}
+{{- end }}
+{{- if .Auditor }}
+	// Casting service to audit.Sink interface
+	sink := s.(audit.Sink)
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
-		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, {{ if eq .Type "Signature" }}a.SignatureKey, a.SignatureNonces(){{ else }}a.{{ .Type }}Auth{{ end }}{{ end }}{{ if .Policy }}, authorize{{ end }}{{ if .Audit }}, sink{{ end }}),
 {{- end }}
 	}
 }
\ No newline at end of file
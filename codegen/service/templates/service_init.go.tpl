@@ -1,4 +1,4 @@
 {{ printf "New%s returns the %s service implementation." .StructName .Name | comment }}
 func New{{ .StructName }}() {{ .PkgName }}.Service {
-	return &{{ .VarName }}srvc{}
+	return &{{ .VarName }}srvc{ {{- if .Auditor }}JSONLinesSink: audit.NewJSONLinesSink(os.Stdout){{ end }}}
 }
@@ -1,2 +1,9 @@
 {{ printf "%s service example implementation.\nThe example methods log the requests and return zero values." .Name | comment }}
+{{- if .Auditor }}
+// The audit events are written to stdout.
+type {{ .VarName }}srvc struct {
+	*audit.JSONLinesSink
+}
+{{- else }}
 type {{ .VarName }}srvc struct {}
+{{- end }}
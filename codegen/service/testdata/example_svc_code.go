@@ -0,0 +1,100 @@
+package testdata
+
+var JWTValidationAuthFuncsCode = `// jWTValidationJWTValidator returns the validator used to verify the tokens of
+// the "jwt" security scheme.
+var jWTValidationJWTValidator = jwt.Lazy(
+	jwt.WithJWKSFile("keys/jwks.json"),
+	jwt.WithIssuer("https://auth.example.com"),
+	jwt.WithAudience("calc", "admin"),
+)
+
+// JWTAuth implements the authorization logic for service "JWTValidation" for
+// the "jwt" security scheme.
+//
+// It verifies the token signature, expiry, issuer and audience and checks the
+// token scopes against the scopes required by the scheme. The verified claims
+// are available to the endpoint via jwt.ContextClaims.
+func (s *jWTValidationsrvc) JWTAuth(ctx context.Context, token string, scheme *security.JWTScheme) (context.Context, error) {
+	v, err := jWTValidationJWTValidator()
+	if err != nil {
+		return ctx, err
+	}
+	//
+	// The validator errors may be mapped to one of the generated error
+	// structs, e.g.:
+	//
+	//    if errors.Is(err, jwt.ErrTokenExpired) {
+	//        return ctx, myservice.MakeUnauthorizedError("token expired")
+	//    }
+	//
+	return v.Auth(ctx, token, scheme)
+}
+`
+
+var SignatureVerificationAuthFuncsCode = `// signatureVerificationSignatureNonces records the nonces of the requests
+// signed for the "partner" security scheme so that they cannot be replayed.
+var signatureVerificationSignatureNonces = security.NewMemoryNonceStore()
+
+// SignatureKey returns the secret key identified by keyID that is shared with
+// the client and used to verify the request signatures.
+func (s *signatureVerificationsrvc) SignatureKey(ctx context.Context, keyID string) ([]byte, error) {
+	//
+	// TBD: look up the key shared with the client.
+	//
+	return nil, fmt.Errorf("not implemented")
+}
+
+// SignatureNonces returns the store used to record the nonces of the verified
+// request signatures. The default in-memory store is only suitable for services
+// running a single instance.
+func (s *signatureVerificationsrvc) SignatureNonces() security.NonceStore {
+	return signatureVerificationSignatureNonces
+}
+`
+
+var SessionCSRFTokenAuthFuncsCode = `// SessionAuth implements the authorization logic for service
+// "SessionCSRFToken" for the "session" security scheme.
+func (s *sessionCSRFTokensrvc) SessionAuth(ctx context.Context, sessionID string, scheme *security.SessionScheme) (context.Context, error) {
+	//
+	// TBD: add authorization logic.
+	//
+	// The CSRF token associated with the session must be stored in the
+	// returned context so that it may be checked, e.g.:
+	//
+	//    ctx = security.WithCSRFToken(ctx, session.CSRFToken)
+	//
+	// In case of authorization failure this function should return
+	// one of the generated error structs, e.g.:
+	//
+	//    return ctx, myservice.MakeUnauthorizedError("invalid token")
+	//
+	// Alternatively this function may return an instance of
+	// goa.ServiceError with a Name field value that matches one of
+	// the design error names, e.g:
+	//
+	//    return ctx, goa.PermanentError("unauthorized", "invalid token")
+	//
+	return ctx, fmt.Errorf("not implemented")
+}
+`
+
+var SlogLoggerEndpointCode = `// Method implements Method.
+func (s *slogLoggersrvc) Method(ctx context.Context) (err error) {
+	slog.InfoContext(ctx, "slogLogger.Method")
+	return
+}
+`
+
+var AuditServiceStructCode = `// Audited service example implementation.
+// The example methods log the requests and return zero values.
+// The audit events are written to stdout.
+type auditedsrvc struct {
+	*audit.JSONLinesSink
+}
+`
+
+var AuditServiceInitCode = `// NewAudited returns the Audited service implementation.
+func NewAudited() audited.Service {
+	return &auditedsrvc{JSONLinesSink: audit.NewJSONLinesSink(os.Stdout)}
+}
+`
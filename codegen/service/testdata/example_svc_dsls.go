@@ -21,3 +21,71 @@ var ConflictWithGoifiedAPINameAndServiceNamesDSL = func() {
 	var _ = Service("good-by-api", func() {})   // API name + 'api' suffix
 	var _ = Service("good-by-api-1", func() {}) // API name + 'api' suffix + sequential no.
 }
+
+var JWTValidationDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		JWKS("keys/jwks.json")
+		JWTIssuer("https://auth.example.com")
+		JWTAudience("calc", "admin")
+		Scope("api:read")
+	})
+	var _ = Service("JWTValidation", func() {
+		Method("Secured", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
+
+var SignatureVerificationDSL = func() {
+	var Signed = SignatureSecurity("partner")
+	var _ = Service("SignatureVerification", func() {
+		Method("Secured", func() {
+			Security(Signed)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SessionCSRFTokenDSL = func() {
+	var Session = SessionSecurity("session", func() {
+		CSRF(SynchronizerTokenCSRF)
+	})
+	var _ = Service("SessionCSRFToken", func() {
+		Method("Secured", func() {
+			Security(Session)
+			Payload(func() {
+				SessionID("session_id", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SlogLoggerDSL = func() {
+	var _ = API("SlogLogger", func() {
+		Meta("example:logger", "slog")
+	})
+	var _ = Service("SlogLogger", func() {
+		Method("Method", func() {})
+	})
+}
+
+var AuditDSL = func() {
+	var _ = Service("Audited", func() {
+		Method("Method", func() {
+			Audit(func() {
+				Target("payload.id")
+			})
+			Payload(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+}
@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/v3/dsl"
 )
 
@@ -28,6 +30,23 @@ var OAuth2AuthorizationCode = OAuth2Security("authCode", func() {
 	Scope("api:read", "Read access")
 })
 
+var OpenIDConnectAuth = OpenIDConnectSecurity("oidc", "https://auth.example.com/.well-known/openid-configuration", func() {
+	Scope("api:read", "Read-only access")
+	Scope("api:write", "Read and write access")
+})
+
+var MutualTLSAuth = MutualTLSSecurity("mtls")
+
+var SignatureAuth = SignatureSecurity("signed", func() {
+	SignedComponents("@method", "@path", "content-digest")
+	MaxClockSkew(time.Minute)
+})
+
+var SessionAuth = SessionSecurity("session", func() {
+	SessionCookie("sid")
+	CSRF(DoubleSubmitCSRF)
+})
+
 var EndpointWithoutRequirementDSL = func() {
 	Service("EndpointWithoutRequirement", func() {
 		Method("Unsecure", func() {
@@ -164,6 +183,141 @@ var EndpointWithOAuth2DSL = func() {
 	})
 }
 
+var EndpointWithOpenIDConnectDSL = func() {
+	Service("EndpointWithOpenIDConnect", func() {
+		Method("SecureWithOpenIDConnect", func() {
+			Security(OpenIDConnectAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var EndpointWithMutualTLSDSL = func() {
+	Service("EndpointWithMutualTLS", func() {
+		Method("SecureWithMutualTLS", func() {
+			Security(MutualTLSAuth)
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
+
+var EndpointWithSignatureDSL = func() {
+	Service("EndpointWithSignature", func() {
+		Method("SecureWithSignature", func() {
+			Security(SignatureAuth)
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				POST("/{id}")
+			})
+		})
+	})
+}
+
+var EndpointWithSessionDSL = func() {
+	Service("EndpointWithSession", func() {
+		Method("SecureWithSession", func() {
+			Security(SessionAuth)
+			Payload(func() {
+				SessionID("session_id", String)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				PUT("/")
+			})
+		})
+	})
+}
+
+var EndpointWithAlternativeRequirementsDSL = func() {
+	Service("EndpointWithAlternativeRequirements", func() {
+		Method("SecureWithAlternativeRequirements", func() {
+			Security(BasicAuth, JWTAuth)
+			Security(APIKeyAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Token("token", String)
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var EndpointWithPolicyDSL = func() {
+	Service("EndpointWithPolicy", func() {
+		Method("SecureWithPolicy", func() {
+			Security(JWTAuth)
+			Authorize("role:admin or (role:owner and payload.account_id == auth.account_id)")
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String, func() {
+					Meta("struct:field:name", "Account")
+				})
+			})
+			HTTP(func() {
+				PUT("/{account_id}")
+			})
+		})
+		Method("Unsecured", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("PolicyOnly", func() {
+			Authorize("not auth.suspended")
+			HTTP(func() {
+				DELETE("/")
+			})
+		})
+	})
+}
+
+var EndpointWithAuditDSL = func() {
+	Service("EndpointWithAudit", func() {
+		Method("Update", func() {
+			Security(JWTAuth)
+			Audit(func() {
+				Actor("auth.sub")
+				Target("payload.id")
+				Target("payload.secret")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+				Attribute("secret", String, func() {
+					Sensitive()
+				})
+			})
+			Result(String)
+			HTTP(func() {
+				PUT("/{id}")
+			})
+		})
+		Method("Unaudited", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var EndpointWithBasicAuthAndSkipRequestBodyEncodeDecodeDSL = func() {
 	Service("EndpointWithSkipRequestBodyEncodeDecode", func() {
 		Method("EndpointWithSkipRequestBodyEncodeDecode", func() {
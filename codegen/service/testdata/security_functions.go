@@ -46,9 +46,15 @@ var EndpointWithRequiredScopesCode = `// NewSecureWithRequiredScopesEndpoint ret
 // the method "SecureWithRequiredScopes" of service
 // "EndpointWithRequiredScopes".
 func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "jwt", Type: "JWT"},
+	}
 	return func(ctx context.Context, req any) (any, error) {
 		p := req.(*SecureWithRequiredScopesPayload)
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 		sc := security.JWTScheme{
 			Name:           "jwt",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -60,7 +66,10 @@ func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFu
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
 		if err != nil {
-			return nil, err
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "jwt", Type: "JWT", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
 		}
 		return nil, s.SecureWithRequiredScopes(ctx, p)
 	}
@@ -71,9 +80,15 @@ var EndpointWithOptionalRequiredScopesCode = `// NewSecureWithOptionalRequiredSc
 // that calls the method "SecureWithOptionalRequiredScopes" of service
 // "EndpointWithOptionalRequiredScopes".
 func NewSecureWithOptionalRequiredScopesEndpoint(s Service, authBasicFn security.AuthBasicFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "basic", Type: "Basic"},
+	}
 	return func(ctx context.Context, req any) (any, error) {
 		p := req.(*SecureWithOptionalRequiredScopesPayload)
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 		sc := security.BasicScheme{
 			Name:           "basic",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -89,7 +104,10 @@ func NewSecureWithOptionalRequiredScopesEndpoint(s Service, authBasicFn security
 		}
 		ctx, err = authBasicFn(ctx, user, pass, &sc)
 		if err != nil {
-			return nil, err
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "basic", Type: "Basic", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
 		}
 		return nil, s.SecureWithOptionalRequiredScopes(ctx, p)
 	}
@@ -100,9 +118,15 @@ var EndpointWithAPIKeyOverrideCode = `// NewSecureWithAPIKeyOverrideEndpoint ret
 // the method "SecureWithAPIKeyOverride" of service
 // "EndpointWithAPIKeyOverride".
 func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "api_key", Type: "APIKey"},
+	}
 	return func(ctx context.Context, req any) (any, error) {
 		p := req.(*SecureWithAPIKeyOverridePayload)
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 		sc := security.APIKeyScheme{
 			Name:           "api_key",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -114,7 +138,10 @@ func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAP
 		}
 		ctx, err = authAPIKeyFn(ctx, key, &sc)
 		if err != nil {
-			return nil, err
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "api_key", Type: "APIKey", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
 		}
 		return nil, s.SecureWithAPIKeyOverride(ctx, p)
 	}
@@ -124,9 +151,15 @@ func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAP
 var EndpointWithOAuth2Code = `// NewSecureWithOAuth2Endpoint returns an endpoint function that calls the
 // method "SecureWithOAuth2" of service "EndpointWithOAuth2".
 func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "authCode", Type: "OAuth2"},
+	}
 	return func(ctx context.Context, req any) (any, error) {
 		p := req.(*SecureWithOAuth2Payload)
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 		sc := security.OAuth2Scheme{
 			Name:           "authCode",
 			Scopes:         []string{"api:write", "api:read"},
@@ -146,7 +179,10 @@ func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func
 		}
 		ctx, err = authOAuth2Fn(ctx, token, &sc)
 		if err != nil {
-			return nil, err
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "authCode", Type: "OAuth2", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
 		}
 		return nil, s.SecureWithOAuth2(ctx, p)
 	}
@@ -157,9 +193,15 @@ var EndpointWithBasicAuthAndSkipRequestBodyEncodeDecodeCode = `// NewEndpointWit
 // function that calls the method "EndpointWithSkipRequestBodyEncodeDecode" of
 // service "EndpointWithSkipRequestBodyEncodeDecode".
 func NewEndpointWithSkipRequestBodyEncodeDecodeEndpoint(s Service, authBasicFn security.AuthBasicFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "basic", Type: "Basic"},
+	}
 	return func(ctx context.Context, req any) (any, error) {
 		ep := req.(*EndpointWithSkipRequestBodyEncodeDecodeRequestData)
-		var err error
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
 		sc := security.BasicScheme{
 			Name:           "basic",
 			Scopes:         []string{"api:read", "api:write", "api:admin"},
@@ -175,9 +217,328 @@ func NewEndpointWithSkipRequestBodyEncodeDecodeEndpoint(s Service, authBasicFn s
 		}
 		ctx, err = authBasicFn(ctx, user, pass, &sc)
 		if err != nil {
-			return nil, err
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "basic", Type: "Basic", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
 		}
 		return nil, s.EndpointWithSkipRequestBodyEncodeDecode(ctx, ep.Payload, ep.Body)
 	}
 }
 `
+
+var EndpointWithOpenIDConnectCode = `// NewSecureWithOpenIDConnectEndpoint returns an endpoint function that calls
+// the method "SecureWithOpenIDConnect" of service "EndpointWithOpenIDConnect".
+func NewSecureWithOpenIDConnectEndpoint(s Service, authOpenIDConnectFn security.AuthOpenIDConnectFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "oidc", Type: "OpenIDConnect"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithOpenIDConnectPayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.OpenIDConnectScheme{
+			Name:           "oidc",
+			Scopes:         []string{"api:read", "api:write"},
+			RequiredScopes: []string{"api:read"},
+			DiscoveryURL:   "https://auth.example.com/.well-known/openid-configuration",
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authOpenIDConnectFn(ctx, token, &sc)
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "oidc", Type: "OpenIDConnect", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		return nil, s.SecureWithOpenIDConnect(ctx, p)
+	}
+}
+`
+
+var EndpointWithMutualTLSCode = `// NewSecureWithMutualTLSEndpoint returns an endpoint function that calls the
+// method "SecureWithMutualTLS" of service "EndpointWithMutualTLS".
+func NewSecureWithMutualTLSEndpoint(s Service, authMutualTLSFn security.AuthMutualTLSFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "mtls", Type: "MutualTLS"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithMutualTLSPayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.MutualTLSScheme{
+			Name:           "mtls",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authMutualTLSFn(ctx, security.ContextClientCertificates(ctx), &sc)
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "mtls", Type: "MutualTLS", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		return nil, s.SecureWithMutualTLS(ctx, p)
+	}
+}
+`
+
+var EndpointWithSignatureCode = `// NewSecureWithSignatureEndpoint returns an endpoint function that calls the
+// method "SecureWithSignature" of service "EndpointWithSignature".
+func NewSecureWithSignatureEndpoint(s Service, signatureKeyFn security.SignatureKeyFunc, nonces security.NonceStore) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "signed", Type: "Signature"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithSignaturePayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.SignatureScheme{
+			Name:           "signed",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+			Components:     []string{"@method", "@path", "content-digest"},
+			MaxClockSkew:   60 * time.Second,
+		}
+		err = security.VerifySignature(ctx, security.ContextSignature(ctx), &sc, signatureKeyFn, nonces)
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "signed", Type: "Signature", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		return nil, s.SecureWithSignature(ctx, p)
+	}
+}
+`
+
+var EndpointInitWithPolicyCode = `// NewEndpoints wraps the methods of the "EndpointWithPolicy" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	// Use the service Authorizer implementation if any
+	authorize := security.Authorize
+	if z, ok := s.(Authorizer); ok {
+		authorize = z.Authorize
+	}
+	return &Endpoints{
+		SecureWithPolicy: NewSecureWithPolicyEndpoint(s, a.JWTAuth, authorize),
+		Unsecured:        NewUnsecuredEndpoint(s),
+		PolicyOnly:       NewPolicyOnlyEndpoint(s, authorize),
+	}
+}
+`
+
+var EndpointWithPolicyCode = `// NewSecureWithPolicyEndpoint returns an endpoint function that calls the
+// method "SecureWithPolicy" of service "EndpointWithPolicy".
+func NewSecureWithPolicyEndpoint(s Service, authJWTFn security.AuthJWTFunc, authorizeFn security.AuthorizeFunc) goa.Endpoint {
+	policy := security.MustParsePolicy("role:admin or (role:owner and payload.account_id == auth.account_id)")
+	schemes := []*security.SchemeRef{
+		{Name: "jwt", Type: "JWT"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithPolicyPayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "jwt", Type: "JWT", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		err = authorizeFn(ctx, policy, map[string]any{
+			"account_id": p.Account,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithPolicy(ctx, p)
+	}
+}
+`
+
+var EndpointWithSessionCode = `// NewSecureWithSessionEndpoint returns an endpoint function that calls the
+// method "SecureWithSession" of service "EndpointWithSession".
+func NewSecureWithSessionEndpoint(s Service, authSessionFn security.AuthSessionFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "session", Type: "Session"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithSessionPayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.SessionScheme{
+			Name:           "session",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+			Cookie:         "sid",
+			CSRF:           "double-submit",
+		}
+		var sessionID string
+		if p.SessionID != nil {
+			sessionID = *p.SessionID
+		}
+		ctx, err = authSessionFn(ctx, sessionID, &sc)
+		if err == nil {
+			err = security.VerifyCSRF(ctx, &sc)
+		}
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "session", Type: "Session", Err: err})
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		return nil, s.SecureWithSession(ctx, p)
+	}
+}
+`
+
+var EndpointWithAlternativeRequirementsCode = `// NewSecureWithAlternativeRequirementsEndpoint returns an endpoint function
+// that calls the method "SecureWithAlternativeRequirements" of service
+// "EndpointWithAlternativeRequirements".
+func NewSecureWithAlternativeRequirementsEndpoint(s Service, authBasicFn security.AuthBasicFunc, authJWTFn security.AuthJWTFunc, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "basic", Type: "Basic"},
+		{Name: "jwt", Type: "JWT"},
+		{Name: "api_key", Type: "APIKey"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*SecureWithAlternativeRequirementsPayload)
+		var (
+			err      error
+			failures []*security.SchemeError
+		)
+		sc := security.BasicScheme{
+			Name:           "basic",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{},
+		}
+		var user string
+		if p.User != nil {
+			user = *p.User
+		}
+		var pass string
+		if p.Pass != nil {
+			pass = *p.Pass
+		}
+		ctx, err = authBasicFn(ctx, user, pass, &sc)
+		if err != nil {
+			failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "basic", Type: "Basic", Err: err})
+		}
+		if err == nil {
+			sc := security.JWTScheme{
+				Name:           "jwt",
+				Scopes:         []string{"api:read", "api:write", "api:admin"},
+				RequiredScopes: []string{},
+			}
+			var token string
+			if p.Token != nil {
+				token = *p.Token
+			}
+			ctx, err = authJWTFn(ctx, token, &sc)
+			if err != nil {
+				failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "jwt", Type: "JWT", Err: err})
+			}
+		}
+		if err != nil {
+			sc := security.APIKeyScheme{
+				Name:           "api_key",
+				Scopes:         []string{"api:read", "api:write", "api:admin"},
+				RequiredScopes: []string{},
+			}
+			var key string
+			if p.Key != nil {
+				key = *p.Key
+			}
+			ctx, err = authAPIKeyFn(ctx, key, &sc)
+			if err != nil {
+				failures = append(failures, &security.SchemeError{Requirement: 1, Scheme: "api_key", Type: "APIKey", Err: err})
+			}
+		}
+		if err != nil {
+			return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+		}
+		return nil, s.SecureWithAlternativeRequirements(ctx, p)
+	}
+}
+`
+
+var EndpointInitWithAuditCode = `// NewEndpoints wraps the methods of the "EndpointWithAudit" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	// Casting service to audit.Sink interface
+	sink := s.(audit.Sink)
+	return &Endpoints{
+		Update:    NewUpdateEndpoint(s, a.JWTAuth, sink),
+		Unaudited: NewUnauditedEndpoint(s),
+	}
+}
+`
+
+var EndpointWithAuditCode = `// NewUpdateEndpoint returns an endpoint function that calls the method
+// "Update" of service "EndpointWithAudit".
+func NewUpdateEndpoint(s Service, authJWTFn security.AuthJWTFunc, sink audit.Sink) goa.Endpoint {
+	schemes := []*security.SchemeRef{
+		{Name: "jwt", Type: "JWT"},
+	}
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*UpdatePayload)
+		res, err := func() (any, error) {
+			var (
+				err      error
+				failures []*security.SchemeError
+			)
+			sc := security.JWTScheme{
+				Name:           "jwt",
+				Scopes:         []string{"api:read", "api:write", "api:admin"},
+				RequiredScopes: []string{},
+			}
+			var token string
+			if p.Token != nil {
+				token = *p.Token
+			}
+			ctx, err = authJWTFn(ctx, token, &sc)
+			if err != nil {
+				failures = append(failures, &security.SchemeError{Requirement: 0, Scheme: "jwt", Type: "JWT", Err: err})
+			}
+			if err != nil {
+				return nil, &security.AuthError{Schemes: schemes, Failures: failures}
+			}
+			return s.Update(ctx, p)
+		}()
+		ev := audit.NewEvent(ctx, err)
+		ev.Actor = audit.Claim(ctx, "sub")
+		ev.Targets = map[string]any{
+			"payload.id":     p.ID,
+			"payload.secret": goa.Redacted,
+		}
+		return res, audit.Emit(ctx, sink, ev, err)
+	}
+}
+`
@@ -67,6 +67,54 @@ func newResultWithSpaceView(res *ResultWithSpace) *servicewithspacesviews.Result
 }
 `
 
+const SensitiveMethod = `
+// Service is the SensitiveService service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *Credentials) (err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Credentials is the payload type of the SensitiveService service A method.
+type Credentials struct {
+	Login    *string
+	Password *string
+}
+
+// String returns a representation of Credentials with the sensitive fields
+// redacted.
+func (t *Credentials) String() string {
+	return goa.RedactString(t, "Password")
+}
+
+// GoString returns a Go representation of Credentials with the sensitive
+// fields redacted.
+func (t *Credentials) GoString() string {
+	return goa.RedactGoString(t, "Password")
+}
+
+// LogValue implements slog.LogValuer, it redacts the sensitive fields of
+// Credentials.
+func (t *Credentials) LogValue() slog.Value {
+	return goa.RedactLogValue(t, "Password")
+}
+`
+
 const SingleMethod = `
 // Service is the SingleMethod service interface.
 type Service interface {
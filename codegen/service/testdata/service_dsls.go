@@ -99,6 +99,20 @@ var UnionMethodDSL = func() {
 	})
 }
 
+var SensitiveMethodDSL = func() {
+	var Credentials = Type("Credentials", func() {
+		Attribute("login", String)
+		Attribute("password", String, func() {
+			Sensitive()
+		})
+	})
+	Service("SensitiveService", func() {
+		Method("A", func() {
+			Payload(Credentials)
+		})
+	})
+}
+
 var MultiUnionMethodDSL = func() {
 	var TypeA = Type("TypeA", func() {
 		Attribute("a", Int)
@@ -583,5 +583,17 @@ const (
 		}
 	}
 }
+`
+
+	JSONTaggedValidationCode = `func Validate() (err error) {
+	if target.FirstName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("first_name", "target"))
+	}
+	if target.FirstName != nil {
+		if utf8.RuneCountInString(*target.FirstName) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.first_name", *target.FirstName, utf8.RuneCountInString(*target.FirstName), 1, true))
+		}
+	}
+}
 `
 )
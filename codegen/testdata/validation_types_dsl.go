@@ -153,5 +153,13 @@ var ValidationTypesDSL = func() {
 				Attribute("integer", IntegerT)
 			})
 		})
+
+		_ = Type("JSONTagged", func() {
+			Attribute("firstName", String, func() {
+				Meta("struct:tag:json", "first_name")
+				MinLength(1)
+			})
+			Required("firstName")
+		})
 	)
 }
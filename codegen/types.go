@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -64,3 +65,83 @@ func AttributeTags(_, att *expr.AttributeExpr) string {
 	}
 	return ""
 }
+
+// JSONName returns the key of the JSON object field that corresponds to the
+// attribute with the given name according to the given JSON policy. The name
+// set via the "struct:tag:json" meta of the attribute takes precedence.
+func JSONName(p *expr.JSONPolicyExpr, att *expr.AttributeExpr, name string) string {
+	if att != nil {
+		if tag, ok := att.Meta["struct:tag:json"]; ok && len(tag) > 0 {
+			if n := strings.Split(tag[0], ",")[0]; n != "" && n != "-" {
+				return n
+			}
+		}
+	}
+	if p == nil {
+		return name
+	}
+	switch p.Naming {
+	case expr.JSONSnakeCase:
+		return SnakeCase(name)
+	case expr.JSONCamelCase:
+		return CamelCase(name, false, false)
+	case expr.JSONKebabCase:
+		return KebabCase(name)
+	}
+	return name
+}
+
+// JSONExample returns the JSON representation of the example value ex of the
+// attribute att according to the given JSON policy: the keys of the objects
+// are renamed and 64-bit integer fields are converted to strings as needed.
+// Elements of arrays and maps are left untouched as the Go ",string" tag
+// option only applies to scalar fields.
+func JSONExample(p *expr.JSONPolicyExpr, att *expr.AttributeExpr, ex any) any {
+	if p.IsDefault() || att == nil || ex == nil {
+		return ex
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		return JSONExample(p, dt.Attribute(), ex)
+	case *expr.Object:
+		m, ok := ex.(map[string]any)
+		if !ok {
+			return ex
+		}
+		res := make(map[string]any, len(m))
+		for k, v := range m {
+			if nat := dt.Attribute(k); nat != nil {
+				if p.EncodeAsString(nat) && v != nil {
+					res[JSONName(p, nat, k)] = fmt.Sprint(v)
+					continue
+				}
+				res[JSONName(p, nat, k)] = JSONExample(p, nat, v)
+				continue
+			}
+			res[k] = v
+		}
+		return res
+	case *expr.Array:
+		v := reflect.ValueOf(ex)
+		if v.Kind() != reflect.Slice {
+			return ex
+		}
+		res := make([]any, v.Len())
+		for i := range res {
+			res[i] = JSONExample(p, dt.ElemType, v.Index(i).Interface())
+		}
+		return res
+	case *expr.Map:
+		v := reflect.ValueOf(ex)
+		if v.Kind() != reflect.Map {
+			return ex
+		}
+		res := make(map[any]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res[iter.Key().Interface()] = JSONExample(p, dt.ElemType, iter.Value().Interface())
+		}
+		return res
+	}
+	return ex
+}
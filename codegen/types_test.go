@@ -187,3 +187,54 @@ func TestGoify(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONName(t *testing.T) {
+	var (
+		plain  = &expr.AttributeExpr{Type: expr.String}
+		tagged = &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:json": []string{"custom,omitempty"}}}
+	)
+	cases := map[string]struct {
+		policy   *expr.JSONPolicyExpr
+		att      *expr.AttributeExpr
+		name     string
+		expected string
+	}{
+		"no-policy":  {nil, plain, "firstName", "firstName"},
+		"snake-case": {&expr.JSONPolicyExpr{Naming: expr.JSONSnakeCase}, plain, "firstName", "first_name"},
+		"camel-case": {&expr.JSONPolicyExpr{Naming: expr.JSONCamelCase}, plain, "first_name", "firstName"},
+		"kebab-case": {&expr.JSONPolicyExpr{Naming: expr.JSONKebabCase}, plain, "firstName", "first-name"},
+		"tag":        {&expr.JSONPolicyExpr{Naming: expr.JSONSnakeCase}, tagged, "firstName", "custom"},
+	}
+
+	for k, tc := range cases {
+		actual := JSONName(tc.policy, tc.att, tc.name)
+		if actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestJSONExample(t *testing.T) {
+	var (
+		att = &expr.AttributeExpr{Type: &expr.Object{
+			{Name: "firstName", Attribute: &expr.AttributeExpr{Type: expr.String}},
+			{Name: "accountID", Attribute: &expr.AttributeExpr{Type: expr.Int64}},
+			{Name: "ids", Attribute: &expr.AttributeExpr{Type: &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.Int64}}}},
+		}}
+		ex     = map[string]any{"firstName": "joe", "accountID": int64(9007199254740993), "ids": []any{int64(1)}}
+		policy = &expr.JSONPolicyExpr{Naming: expr.JSONSnakeCase, Int64AsString: true}
+	)
+	actual := JSONExample(policy, att, ex).(map[string]any)
+	if actual["first_name"] != "joe" {
+		t.Errorf("got %#v, expected first_name to be %#v", actual, "joe")
+	}
+	if actual["account_id"] != "9007199254740993" {
+		t.Errorf("got %#v, expected account_id to be %#v", actual, "9007199254740993")
+	}
+	if ids, ok := actual["ids"].([]any); !ok || len(ids) != 1 || ids[0] != int64(1) {
+		t.Errorf("got %#v, expected ids elements to be left as numbers", actual)
+	}
+	if actual := JSONExample(nil, att, ex).(map[string]any); actual["firstName"] != "joe" {
+		t.Errorf("got %#v, expected example to be unchanged", actual)
+	}
+}
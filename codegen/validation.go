@@ -118,7 +118,7 @@ func recurseValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *A
 		}
 		for _, nat := range *(expr.AsObject(att.Type)) {
 			tgt := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
-			ctx := fmt.Sprintf("%s.%s", context, nat.Name)
+			ctx := fmt.Sprintf("%s.%s", context, JSONName(nil, nat.Attribute, nat.Name))
 			val := validateAttribute(attCtx, nat.Attribute, put, tgt, ctx, att.IsRequired(nat.Name), view)
 			if val != "" {
 				newline()
@@ -369,6 +369,8 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		reqAtt := obj.Attribute(r)
 		data["req"] = r
 		data["reqAtt"] = reqAtt
+		// Validation errors identify fields by their name on the wire.
+		data["reqName"] = JSONName(nil, reqAtt, r)
 		res = append(res, runTemplate(requiredValT, data))
 	}
 	return strings.Join(res, "\n")
@@ -588,6 +590,6 @@ if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target
 {{- end }}`
 
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .reqName }}", {{ printf "%q" $.context }}))
 }`
 )
@@ -27,6 +27,7 @@ func TestRecursiveValidationCode(t *testing.T) {
 		rtcolT   = root.UserType("Collection")
 		colT     = root.UserType("TypeWithCollection")
 		deepT    = root.UserType("Deep")
+		jsonT    = root.UserType("JSONTagged")
 	)
 	cases := []struct {
 		Name       string
@@ -62,6 +63,7 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"collection-pointer", rtcolT, false, true, false, testdata.ResultCollectionPointerValidationCode},
 		{"type-with-collection-pointer", colT, false, true, false, testdata.TypeWithCollectionPointerValidationCode},
 		{"type-with-embedded-type", deepT, false, true, false, testdata.TypeWithEmbeddedTypeValidationCode},
+		{"json-tag", jsonT, false, true, false, testdata.JSONTaggedValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -260,6 +260,90 @@ func Default(def any) {
 	a.SetDefault(def)
 }
 
+// ReadOnly marks an attribute as read-only. Read-only attributes are set by
+// the server: they are omitted from the generated request body types, gRPC
+// request messages and client CLI flags so that any value sent by clients is
+// ignored when decoding payloads. Read-only attributes are flagged with
+// "readOnly" in the generated OpenAPI specifications.
+//
+// ReadOnly must appear in an Attribute DSL.
+//
+// ReadOnly takes no argument.
+//
+// Example:
+//
+//	var Account = ResultType("application/vnd.account", func() {
+//	    Attribute("id", String, func() {
+//	        ReadOnly() // Computed by the server
+//	    })
+//	    Attribute("name", String)
+//	})
+func ReadOnly() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta(expr.ReadOnlyMetaKey)
+}
+
+// WriteOnly marks an attribute as write-only. Write-only attributes may be
+// provided by clients but are never rendered: they are omitted from the
+// generated response body types, gRPC response messages and result type
+// views. Write-only attributes are flagged with "writeOnly" in the generated
+// OpenAPI v3 specification.
+//
+// WriteOnly must appear in an Attribute DSL.
+//
+// WriteOnly takes no argument.
+//
+// Example:
+//
+//	var User = Type("User", func() {
+//	    Attribute("login", String)
+//	    Attribute("password", String, func() {
+//	        WriteOnly() // Never sent back to clients
+//	    })
+//	})
+func WriteOnly() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta(expr.WriteOnlyMetaKey)
+}
+
+// Sensitive marks an attribute as holding sensitive data such as passwords or
+// tokens. The generated Go types that define sensitive fields implement the
+// fmt.Stringer, fmt.GoStringer and slog.LogValuer interfaces so that the
+// sensitive values are redacted when printed or logged. The generated HTTP
+// client CLI also redacts the corresponding body fields, parameters and headers
+// when printing requests and responses in verbose mode.
+//
+// Sensitive must appear in an Attribute DSL, it may also be used in the
+// DSL of security attributes (Username, Password, APIKey, Token and
+// AccessToken).
+//
+// Sensitive takes no argument.
+//
+// Example:
+//
+//	var Login = Type("Login", func() {
+//	    Attribute("user", String)
+//	    Password("password", String, func() {
+//	        Sensitive()
+//	    })
+//	})
+func Sensitive() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta(expr.SensitiveMetaKey)
+}
+
 // Example provides an example value for a type, a parameter, a header or any
 // attribute. Example supports two syntaxes: one syntax accepts two arguments
 // where the first argument is a summary describing the example and the second a
@@ -0,0 +1,102 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Audit causes the generated endpoint to emit an audit event once it completes,
+// for example to keep a record of all the state-changing requests. The event
+// includes the service and method names, the request ID, the outcome of the
+// request ("success", "denied" or "failure") and the name of the error if any
+// as well as the values referenced by the Actor and Target DSLs. Requests that
+// fail authentication or authorization are audited too.
+//
+// The events are emitted to the audit.Sink that the service implementation must
+// implement, for example by embedding an audit.JSONLinesSink. The endpoint
+// returns a fault error if the sink fails to record the event of a successful
+// request. The values of sensitive payload attributes (see Sensitive) are
+// redacted.
+//
+// Audit must appear in Method.
+//
+// Audit accepts a single argument: the function defining the actor and the
+// targets.
+//
+// Example:
+//
+//	Method("update", func() {
+//	    Security(JWT)
+//	    Audit(func() {
+//	        Actor("auth.sub")
+//	        Target("payload.id")
+//	    })
+//	    Payload(func() {
+//	        Token("token", String)
+//	        Attribute("id", String)
+//	    })
+//	})
+func Audit(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a := &expr.AuditExpr{Method: m}
+	if !eval.Execute(fn, a) {
+		return
+	}
+	m.Audit = a
+}
+
+// Actor defines the value that identifies the actor making an audited request.
+// The value is either a claim of the authenticated principal ("auth.<claim>",
+// see security.WithClaims) or a top-level payload attribute of a primitive
+// type ("payload.<attribute>").
+//
+// Actor must appear in Audit.
+//
+// Actor accepts a single argument: the reference to the value.
+//
+// Example:
+//
+//	Audit(func() {
+//	    Actor("auth.sub")
+//	})
+func Actor(ref string) {
+	a, ok := eval.Current().(*expr.AuditExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Actor != "" {
+		eval.ReportError("actor already defined as %q", a.Actor)
+		return
+	}
+	a.Actor = ref
+}
+
+// Target defines a value that identifies the target of an audited request. The
+// value is either a claim of the authenticated principal ("auth.<claim>", see
+// security.WithClaims) or a top-level payload attribute of a primitive type
+// ("payload.<attribute>").
+//
+// Target must appear in Audit. Target may appear multiple times.
+//
+// Target accepts a single argument: the reference to the value.
+//
+// Example:
+//
+//	Audit(func() {
+//	    Actor("auth.sub")
+//	    Target("payload.account_id")
+//	    Target("payload.id")
+//	})
+func Target(ref string) {
+	a, ok := eval.Current().(*expr.AuditExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.Targets = append(a.Targets, ref)
+}
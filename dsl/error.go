@@ -273,3 +273,41 @@ func Fault() {
 	}
 	attr.AddMeta("goa:error:fault")
 }
+
+// Details describes the type of the details carried by errors that use the
+// default error type. The details are made available to the service code via
+// the Details field of the goa ServiceError and are encoded in the error
+// response body (HTTP) or status details (gRPC). Details makes it possible to
+// document the structure of the details in the generated OpenAPI
+// specifications.
+//
+// Details must appear in a Error expression.
+//
+// Details takes a single argument which must be a user type describing an
+// object.
+//
+// Example:
+//
+//	var QuotaDetails = Type("QuotaDetails", func() {
+//	    Attribute("limit", Int, "Maximum number of requests per minute")
+//	    Attribute("retry_after", Int, "Number of seconds until the next request can be made")
+//	})
+//
+//	var _ = Service("divider", func() {
+//	    Error("quota_exceeded", func() {
+//	        Details(QuotaDetails)
+//	        Temporary()
+//	    })
+//	})
+func Details(dt expr.UserType) {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if dt == nil {
+		eval.InvalidArgError("user type", dt)
+		return
+	}
+	attr.AddMeta(expr.ErrorDetailsMetaKey, dt.Name())
+}
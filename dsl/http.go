@@ -855,6 +855,109 @@ func SkipResponseBodyEncodeDecode() {
 	e.SkipResponseBodyEncodeDecode = true
 }
 
+// StrictDecoding causes the generated HTTP servers to reject requests whose
+// JSON body contains fields that are not defined in the design or that define
+// the same field more than once, as well as requests whose query string
+// contains parameters that are not defined in the design. Such requests
+// produce a "400 Bad Request" response listing the offending names. The
+// generated OpenAPI v3 specifications set "additionalProperties" to false
+// on the schemas of the request bodies.
+//
+// StrictDecoding must appear in an API, Service or Method expression. The
+// setting defined in a Method expression overrides the setting defined in the
+// Service expression which in turn overrides the setting defined in the API
+// expression.
+//
+// StrictDecoding accepts an optional boolean argument which defaults to true,
+// use StrictDecoding(false) to disable strict decoding for a given service or
+// method.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    StrictDecoding()
+//	})
+//
+//	var _ = Service("calc", func() {
+//	    Method("legacy", func() {
+//	        StrictDecoding(false) // Accept unknown fields
+//	    })
+//	})
+func StrictDecoding(enabled ...bool) {
+	if len(enabled) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	val := []string{"true"}
+	if len(enabled) == 1 && !enabled[0] {
+		val = []string{"false"}
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.StrictDecodingMetaKey] = val
+	case *expr.ServiceExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.StrictDecodingMetaKey] = val
+	case *expr.MethodExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.StrictDecodingMetaKey] = val
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ProblemDetails sets whether the HTTP error responses use the RFC 9457
+// "Problem Details for HTTP APIs" format. When enabled the generated servers
+// format errors using goahttp.NewProblemDetails unless a different formatter
+// is given to the generated New function, the errors defined in the design
+// using the default error type are also written as problem details with the
+// "application/problem+json" content type. The generated clients decode such
+// responses into goa.ServiceError values and the generated OpenAPI
+// specifications describe the corresponding error responses using the
+// problem details schema.
+//
+// ProblemDetails must appear in an API or Service expression. The setting
+// defined in a Service expression overrides the API level setting.
+//
+// ProblemDetails accepts an optional boolean argument which defaults to true.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    ProblemDetails()
+//	})
+func ProblemDetails(enabled ...bool) {
+	if len(enabled) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	val := []string{"true"}
+	if len(enabled) == 1 && !enabled[0] {
+		val = []string{"false"}
+	}
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.ProblemDetailsMetaKey] = val
+	case *expr.ServiceExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[expr.ProblemDetailsMetaKey] = val
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Body describes a HTTP request or response body.
 //
 // Body must appear in a Method HTTP expression to define the request body or in
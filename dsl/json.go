@@ -0,0 +1,103 @@
+package dsl
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// SnakeCase produces JSON object keys such as "first_name".
+	SnakeCase = expr.JSONSnakeCase
+
+	// CamelCase produces JSON object keys such as "firstName".
+	CamelCase = expr.JSONCamelCase
+
+	// KebabCase produces JSON object keys such as "first-name".
+	KebabCase = expr.JSONKebabCase
+)
+
+// JSONNaming sets the naming convention applied to the keys of the JSON
+// objects that represent the HTTP request and response bodies. The convention
+// applies to the generated transport body types, decoders, client CLI
+// examples and OpenAPI specifications. Attributes that define the
+// "struct:tag:json" meta keep the name specified in the tag.
+//
+// JSONNaming must appear in an API or Service expression. The setting defined
+// in a Service expression overrides the API level setting.
+//
+// JSONNaming takes one argument: SnakeCase, CamelCase or KebabCase.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    JSONNaming(SnakeCase)
+//	})
+func JSONNaming(naming expr.JSONNamingKind) {
+	switch naming {
+	case SnakeCase, CamelCase, KebabCase:
+	default:
+		eval.ReportError("invalid JSON naming convention %q, must be one of SnakeCase, CamelCase or KebabCase", naming)
+		return
+	}
+	setJSONMeta(expr.JSONNamingMetaKey, string(naming))
+}
+
+// JSONOmitEmpty sets whether the empty optional fields of the HTTP request and
+// response bodies are omitted from the JSON objects (true, the default) or
+// serialized with their zero values (false). Attributes that define the
+// "struct:tag:json" meta are not affected.
+//
+// JSONOmitEmpty must appear in an API or Service expression. The setting
+// defined in a Service expression overrides the API level setting.
+//
+// JSONOmitEmpty takes one argument.
+//
+// Example:
+//
+//	var _ = Service("calc", func() {
+//	    JSONOmitEmpty(false) // Always emit all fields
+//	})
+func JSONOmitEmpty(omit bool) {
+	setJSONMeta(expr.JSONOmitEmptyMetaKey, strconv.FormatBool(omit))
+}
+
+// JSONInt64AsString sets whether the 64-bit integer fields of the HTTP request
+// and response bodies are serialized as JSON strings. This makes it possible
+// for JavaScript clients to handle values that do not fit in a IEEE 754
+// double without losing precision. The generated OpenAPI specifications
+// describe such fields as strings with the "int64" format.
+//
+// JSONInt64AsString must appear in an API or Service expression. The setting
+// defined in a Service expression overrides the API level setting.
+//
+// JSONInt64AsString takes one argument.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    JSONInt64AsString(true)
+//	})
+func JSONInt64AsString(asString bool) {
+	setJSONMeta(expr.JSONInt64AsStringMetaKey, strconv.FormatBool(asString))
+}
+
+// setJSONMeta sets the given JSON policy meta on the current API or service
+// expression.
+func setJSONMeta(key, val string) {
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[key] = []string{val}
+	case *expr.ServiceExpr:
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta[key] = []string{val}
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -208,6 +208,15 @@ import (
 //	    Meta("openapi:example", "false")
 //	})
 //
+// - "example:logger" sets the logger used by the generated example service
+// and server implementations. The only supported value is "slog" which causes
+// the examples to log using the standard library log/slog package instead of
+// the goa.design/clue log package. Applicable to API only.
+//
+//	var _ = API("MyAPI", func() {
+//	    Meta("example:logger", "slog")
+//	})
+//
 // - "swagger:tag:xxx" DEPRECATED, use "openapi:tag:xxx" instead
 //
 // - "openapi:tag:xxx" sets the OpenAPI object field tag xxx. Applicable to
@@ -1,10 +1,21 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
 
+const (
+	// DoubleSubmitCSRF is the CSRF protection mode where clients echo the
+	// value of a CSRF cookie set by the server in a request header.
+	DoubleSubmitCSRF = expr.CSRFDoubleSubmit
+	// SynchronizerTokenCSRF is the CSRF protection mode where clients send
+	// a token stored server side in the session in a request header.
+	SynchronizerTokenCSRF = expr.CSRFSynchronizerToken
+)
+
 // BasicAuthSecurity defines a basic authentication security scheme.
 //
 // BasicAuthSecurity is a top level DSL.
@@ -175,17 +186,228 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// OpenIDConnectSecurity defines an HTTP security scheme where a token issued by
+// an OpenID Connect provider is passed in the request Authorization header as a
+// bearer token to perform auth. The provider configuration is described by the
+// OpenID Connect discovery document located at the given URL. The scheme
+// supports defining scopes that endpoint may require to authorize the request.
+//
+// The attribute holding the token is defined with Token in the method payload.
+//
+// OpenIDConnectSecurity is a top level DSL.
+//
+// OpenIDConnectSecurity takes a name and the discovery URL as first arguments
+// and an optional DSL as last argument.
+//
+// Example:
+//
+//	var OIDC = OpenIDConnectSecurity("oidc", "https://auth.example.com/.well-known/openid-configuration", func() {
+//	    Scope("openid", "OpenID Connect authentication")
+//	    Scope("profile", "Access to the user profile")
+//	})
+func OpenIDConnectSecurity(name, discoveryURL string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName:   name,
+		Kind:         expr.OpenIDConnectKind,
+		DiscoveryURL: discoveryURL,
+		In:           "header",
+		Name:         "Authorization",
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// MutualTLSSecurity defines a security scheme where clients authenticate using
+// a TLS certificate verified by the server during the TLS handshake. The
+// generated auth function receives the verified client certificate chain.
+// Methods secured with MutualTLSSecurity do not need to define any payload
+// attribute.
+//
+// MutualTLSSecurity is a top level DSL.
+//
+// MutualTLSSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//	var MTLS = MutualTLSSecurity("mtls", func() {
+//	    Description("Clients must present a certificate signed by the internal CA")
+//	})
+func MutualTLSSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.MutualTLSKind,
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// SignatureSecurity defines a security scheme where clients sign requests with
+// an HMAC computed over a set of request components using a secret key shared
+// with the server, following HTTP Message Signatures (RFC 9421). The signature
+// is sent in the Signature and Signature-Input headers together with the key
+// ID, its creation time and a nonce. Methods secured with SignatureSecurity do
+// not need to define any payload attribute.
+//
+// The generated HTTP handlers compute the signature base of incoming requests
+// and the generated endpoints verify it using the key lookup function and the
+// nonce store provided by the service to reject replayed requests. The generated HTTP clients sign
+// outgoing requests with the key stored in the request context via
+// security.WithSigningKey.
+//
+// The signature covers the request method, path, query string and body digest
+// by default, use SignedComponents to override. Signature schemes are only
+// supported by HTTP endpoints.
+//
+// SignatureSecurity is a top level DSL.
+//
+// SignatureSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//	var Signed = SignatureSecurity("partner", func() {
+//	    Description("Requests must be signed with the partner key")
+//	    SignedComponents("@method", "@path", "content-type", "content-digest")
+//	    MaxClockSkew(time.Minute)
+//	})
+func SignatureSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName:       name,
+		Kind:             expr.SignatureKind,
+		In:               "header",
+		Name:             "Signature",
+		SignedComponents: []string{"@method", "@path", "@query", "content-digest"},
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// SessionSecurity defines a security scheme where browsers authenticate with a
+// session ID stored in a cookie. Methods secured with SessionSecurity must
+// define the attribute that holds the session ID with SessionID. The session ID
+// is read from the cookie named "session" by default, use SessionCookie to
+// override. The generated code calls the AuthSessionFunc implemented by the
+// service with the session ID.
+//
+// Sessions may be combined with CSRF protection of requests made with unsafe
+// methods (POST, PUT, PATCH, DELETE...), see CSRF. Session schemes are only
+// supported by HTTP endpoints.
+//
+// SessionSecurity is a top level DSL.
+//
+// SessionSecurity takes a name as first argument and an optional DSL as second
+// argument.
+//
+// Example:
+//
+//	var Session = SessionSecurity("session", func() {
+//	    Description("Browser session")
+//	    SessionCookie("sid")
+//	    CSRF(DoubleSubmitCSRF)
+//	})
+func SessionSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName:    name,
+		Kind:          expr.SessionKind,
+		In:            "cookie",
+		Name:          "session",
+		SessionCookie: "session",
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
 // Security defines authentication requirements to access an entire API, service
 // or individual service method.
 //
 // The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
-// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
-// OAuth2Security or JWTSecurity scheme then required scopes may be listed by
+// APIKeySecurity, JWTSecurity, OpenIDConnectSecurity, MutualTLSSecurity,
+// SignatureSecurity or SessionSecurity security scheme. If the schemes include a OAuth2Security, JWTSecurity or
+// OpenIDConnectSecurity scheme then required scopes may be listed by
 // name in the Security DSL. All the listed schemes must be validated by the
 // client for the request to be authorized. Security may appear multiple times
 // in the same scope in which case the client may validate any one of the
 // requirements for the request to be authorized.
 //
+// The generated code evaluates the requirements in the order they are defined
+// and authorizes the request with the first requirement whose schemes all
+// succeed. The schemes of a requirement are evaluated in the order they are
+// listed and the evaluation of a requirement stops at the first scheme that
+// fails. If no requirement is satisfied the endpoint returns a
+// security.AuthError that lists the failure of each requirement, the HTTP
+// transport encodes it as a 401 response with one WWW-Authenticate header per
+// basic auth, JWT, OAuth2 or OpenID Connect scheme unless the scheme functions
+// return errors defined in the design.
+//
 // Security must appear in a API, Service or Method expression.
 //
 // Security accepts an arbitrary number of security schemes as argument
@@ -280,6 +502,46 @@ func Security(args ...any) {
 	}
 }
 
+// Authorize defines an authorization policy that requests must satisfy once
+// authenticated and before the service method is called. Policies are boolean
+// expressions that may refer to the top-level payload attributes with
+// "payload.<name>", to the claims of the authenticated principal with
+// "auth.<claim>" and may check that a claim contains a value with
+// "<claim>:<value>". Expressions are combined with "and", "or", "not",
+// parenthesis and the "==" and "!=" comparison operators, see
+// security.Policy for the complete grammar.
+//
+// The generated endpoint evaluates the policy with the claims stored in the
+// request context by the auth functions via security.WithClaims and returns a
+// "permission_denied" error (HTTP status 403, gRPC code PermissionDenied) if it
+// is not satisfied. Services may customize the evaluation by implementing the
+// generated Authorizer interface. Policies are listed in the generated OpenAPI
+// specifications.
+//
+// Authorize must appear in Method. Authorize may appear multiple times in
+// which case requests must satisfy all the policies.
+//
+// Authorize accepts a single argument: the policy expression.
+//
+// Example:
+//
+//	Method("update", func() {
+//	    Security(JWT)
+//	    Authorize("role:admin or (role:owner and payload.account_id == auth.account_id)")
+//	    Payload(func() {
+//	        Token("token", String)
+//	        Attribute("account_id", String)
+//	    })
+//	})
+func Authorize(policy string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Policies = append(m.Policies, policy)
+}
+
 // NoSecurity removes the need for an endpoint to perform authorization.
 //
 // NoSecurity must appear in Method.
@@ -463,8 +725,8 @@ func AccessTokenField(tag any, name string, args ...any) {
 }
 
 // Token defines the attribute used to provide the JWT to an endpoint secured
-// via JWT. The parameters and usage of Token are the same as the goa DSL
-// Attribute function.
+// via JWT or the token to an endpoint secured via OpenID Connect. The
+// parameters and usage of Token are the same as the goa DSL Attribute function.
 //
 // The generated code produced by goa uses the value of the corresponding
 // payload field to initialize the Authorization header.
@@ -498,10 +760,39 @@ func TokenField(tag any, name string, args ...any) {
 	Field(tag, name, args...)
 }
 
+// SessionID defines the attribute used to provide the session ID to an
+// endpoint secured with a cookie session scheme. The parameters and usage of
+// SessionID are the same as the goa DSL Attribute function.
+//
+// The generated code reads the value of the attribute from the session cookie
+// unless the attribute is explicitly mapped to another cookie. The attribute
+// should not be required so that generated clients may rely on their cookie
+// jar to provide the session cookie.
+//
+// Example:
+//
+//	Method("secured", func() {
+//	    Security(Session)
+//	    Payload(func() {
+//	        SessionID("session_id", String, "ID of the browser session")
+//	    })
+//	    Result(String)
+//	    HTTP(func() {
+//	        // The session cookie is defined implicitly.
+//	        POST("/")
+//	    })
+//	})
+func SessionID(name string, args ...any) {
+	args = useDSL(args, func() { Meta("security:session") })
+	Attribute(name, args...)
+}
+
 // Scope has two uses: in JWTSecurity or OAuth2Security it defines a scope
 // supported by the scheme. In Security it lists required scopes.
 //
-// Scope must appear in Security, BasicSecurity, APIKeySecurity, JWTSecurity or OAuth2Security.
+// Scope must appear in Security, BasicSecurity, APIKeySecurity, JWTSecurity,
+// OAuth2Security, OpenIDConnectSecurity, MutualTLSSecurity, SignatureSecurity
+// or SessionSecurity.
 //
 // Scope accepts one or two arguments: the first argument is the scope name and
 // when used in JWTSecurity or OAuth2Security the second argument is a
@@ -637,6 +928,204 @@ func ClientCredentialsFlow(tokenURL, refreshURL string) {
 	})
 }
 
+// JWKS sets the path to the JSON Web Key Set file used to verify the tokens of
+// a JWT security scheme. Setting JWKS causes the example generator to wire the
+// goa.design/goa/v3/security/jwt package into the generated JWTAuth function
+// so that token signatures, expiry and scopes get validated out of the box.
+//
+// JWKS must appear in JWTSecurity.
+//
+// JWKS accepts a single argument: the path to the key set file.
+//
+// Example:
+//
+//	var JWT = JWTSecurity("jwt", func() {
+//	    JWKS("keys/jwks.json")
+//	    JWTIssuer("https://auth.example.com")
+//	    JWTAudience("calc")
+//	    Scope("api:read", "Read access")
+//	})
+func JWKS(path string) {
+	if s := jwtScheme(); s != nil {
+		s.Meta = setMeta(s.Meta, expr.JWTJWKSMetaKey, path)
+	}
+}
+
+// JWTIssuer sets the issuer expected in the "iss" claim of the tokens of a JWT
+// security scheme. See JWKS.
+//
+// JWTIssuer must appear in JWTSecurity.
+//
+// JWTIssuer accepts a single argument: the expected issuer.
+func JWTIssuer(iss string) {
+	if s := jwtScheme(); s != nil {
+		s.Meta = setMeta(s.Meta, expr.JWTIssuerMetaKey, iss)
+	}
+}
+
+// JWTAudience sets the audiences accepted in the "aud" claim of the tokens of
+// a JWT security scheme. Tokens must list at least one of the audiences. See
+// JWKS.
+//
+// JWTAudience must appear in JWTSecurity.
+//
+// JWTAudience accepts one or more accepted audiences.
+func JWTAudience(aud ...string) {
+	if s := jwtScheme(); s != nil {
+		s.Meta = setMeta(s.Meta, expr.JWTAudienceMetaKey, aud...)
+	}
+}
+
+// SignedComponents lists the request components that must be covered by the
+// signature of a request signature scheme. Components are either derived
+// components ("@method", "@authority", "@path" or "@query") or lowercase header
+// names. The "content-digest" component covers the request body via the
+// Content-Digest header.
+//
+// SignedComponents must appear in SignatureSecurity.
+//
+// SignedComponents accepts one or more component names.
+//
+// Example:
+//
+//	var Signed = SignatureSecurity("webhook", func() {
+//	    SignedComponents("@method", "@path", "x-webhook-id", "content-digest")
+//	})
+func SignedComponents(components ...string) {
+	if s := signatureScheme(); s != nil {
+		s.SignedComponents = components
+	}
+}
+
+// MaxClockSkew sets the maximum difference between the creation time of the
+// signature of a request and the server clock. Requests signed earlier or
+// later are rejected. The default is five minutes.
+//
+// MaxClockSkew must appear in SignatureSecurity.
+//
+// MaxClockSkew accepts a single argument: the maximum clock skew.
+func MaxClockSkew(d time.Duration) {
+	if s := signatureScheme(); s != nil {
+		s.MaxClockSkew = d
+	}
+}
+
+// SessionCookie sets the name of the cookie that holds the session ID of a
+// cookie session scheme. The default is "session".
+//
+// SessionCookie must appear in SessionSecurity.
+//
+// SessionCookie accepts a single argument: the cookie name.
+func SessionCookie(name string) {
+	if s := sessionScheme(); s != nil {
+		s.SessionCookie = name
+		s.Name = name
+	}
+}
+
+// CSRF enables CSRF protection for the requests made with unsafe methods
+// (methods other than GET, HEAD, OPTIONS and TRACE) to endpoints secured with a
+// cookie session scheme. The mode is one of:
+//
+//   - DoubleSubmitCSRF: clients echo the value of a CSRF cookie set by the
+//     server in the CSRF header. The generated clients read the cookie from
+//     their cookie jar.
+//   - SynchronizerTokenCSRF: clients send the token associated with the
+//     session in the CSRF header. The AuthSessionFunc implemented by the
+//     service stores the token associated with the session in the context
+//     with security.WithCSRFToken.
+//
+// The generated endpoints return a "permission_denied" error if the token is
+// missing or invalid.
+//
+// CSRF must appear in SessionSecurity.
+//
+// CSRF accepts the mode as first argument, the name of the CSRF header as
+// optional second argument (defaults to "X-CSRF-Token") and the name of the CSRF
+// cookie in double-submit mode as optional third argument (defaults to
+// "csrf_token").
+//
+// Example:
+//
+//	var Session = SessionSecurity("session", func() {
+//	    CSRF(DoubleSubmitCSRF, "X-XSRF-Token", "XSRF-TOKEN")
+//	})
+func CSRF(mode string, names ...string) {
+	s := sessionScheme()
+	if s == nil {
+		return
+	}
+	if len(names) > 2 {
+		eval.TooManyArgError()
+		return
+	}
+	s.CSRF = mode
+	s.CSRFHeader = "X-CSRF-Token"
+	if len(names) > 0 {
+		s.CSRFHeader = names[0]
+	}
+	if mode == DoubleSubmitCSRF {
+		s.CSRFCookie = "csrf_token"
+		if len(names) > 1 {
+			s.CSRFCookie = names[1]
+		}
+	}
+}
+
+// jwtScheme returns the JWT scheme being defined, nil and reports an error if
+// the current expression isn't one.
+func jwtScheme() *expr.SchemeExpr {
+	current, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if current.Kind != expr.JWTKind {
+		eval.ReportError("cannot specify token validation for non-JWT security scheme.")
+		return nil
+	}
+	return current
+}
+
+// signatureScheme returns the request signature scheme being defined, nil and
+// reports an error if the current expression isn't one.
+func signatureScheme() *expr.SchemeExpr {
+	current, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if current.Kind != expr.SignatureKind {
+		eval.ReportError("cannot specify signed components for non-signature security scheme.")
+		return nil
+	}
+	return current
+}
+
+// sessionScheme returns the cookie session scheme being defined, nil and
+// reports an error if the current expression isn't one.
+func sessionScheme() *expr.SchemeExpr {
+	current, ok := eval.Current().(*expr.SchemeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if current.Kind != expr.SessionKind {
+		eval.ReportError("cannot specify session settings for non-session security scheme.")
+		return nil
+	}
+	return current
+}
+
+// setMeta sets the value of the given key in meta, creating meta if needed.
+func setMeta(meta expr.MetaExpr, key string, vals ...string) expr.MetaExpr {
+	if meta == nil {
+		meta = expr.MetaExpr{}
+	}
+	meta[key] = vals
+	return meta
+}
+
 func securitySchemeRedefined(name string) bool {
 	for _, s := range expr.Root.Schemes {
 		if s.SchemeName == name {
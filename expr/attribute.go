@@ -152,6 +152,23 @@ const (
 	FormatRFC1123 = "rfc1123"
 )
 
+const (
+	// ReadOnlyMetaKey is the key used to flag read-only attributes in the
+	// attribute meta. Read-only attributes are omitted from request bodies
+	// and messages.
+	ReadOnlyMetaKey = "readonly"
+
+	// WriteOnlyMetaKey is the key used to flag write-only attributes in the
+	// attribute meta. Write-only attributes are omitted from response bodies,
+	// messages and result type views.
+	WriteOnlyMetaKey = "writeonly"
+
+	// SensitiveMetaKey is the key used to flag sensitive attributes in the
+	// attribute meta. The values of sensitive attributes are redacted when
+	// logged or printed.
+	SensitiveMetaKey = "sensitive"
+)
+
 const (
 	CookieSameSiteStrict  CookieSameSiteValue = "strict"
 	CookieSameSiteLax     CookieSameSiteValue = "lax"
@@ -209,6 +226,9 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		ctx += " - "
 	}
 	verr.Merge(a.validateEnumDefault(ctx, parent))
+	if a.IsReadOnly() && a.IsWriteOnly() {
+		verr.Add(parent, "%sattribute cannot be both read-only and write-only", ctx)
+	}
 	if v := a.Validation; v != nil {
 		verr.Merge(v.Validate(ctx, parent))
 	}
@@ -432,6 +452,36 @@ func (a *AttributeExpr) IsRequiredNoDefault(attName string) bool {
 	return false
 }
 
+// IsReadOnly returns true if the attribute is read-only, i.e. if it is set by
+// the server and ignored when decoding payloads.
+func (a *AttributeExpr) IsReadOnly() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta[ReadOnlyMetaKey]
+	return ok
+}
+
+// IsWriteOnly returns true if the attribute is write-only, i.e. if it may be
+// provided in payloads but is never rendered in results.
+func (a *AttributeExpr) IsWriteOnly() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta[WriteOnlyMetaKey]
+	return ok
+}
+
+// IsSensitive returns true if the attribute value must be redacted when
+// logged or printed.
+func (a *AttributeExpr) IsSensitive() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta[SensitiveMetaKey]
+	return ok
+}
+
 // IsPrimitivePointer returns true if the field generated for the given
 // attribute should be a pointer to a primitive type. The receiver attribute must
 // be an object.
@@ -0,0 +1,65 @@
+package expr
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// AuditExpr describes the audit events emitted by a method endpoint.
+	AuditExpr struct {
+		// Actor is the reference to the value that identifies the actor
+		// making the request, e.g. "auth.sub", if any.
+		Actor string
+		// Targets lists the references to the values that identify the
+		// targets of the request, e.g. "payload.id".
+		Targets []string
+		// Method is the audited method.
+		Method *MethodExpr
+	}
+)
+
+const (
+	// AuditAuthPrefix is the prefix of audit references to the claims of
+	// the authenticated principal.
+	AuditAuthPrefix = "auth."
+	// AuditPayloadPrefix is the prefix of audit references to top-level
+	// primitive payload attributes.
+	AuditPayloadPrefix = "payload."
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (a *AuditExpr) EvalName() string {
+	return "audit of " + a.Method.EvalName()
+}
+
+// Validate makes sure the audit references are valid and refer to existing
+// primitive payload attributes.
+func (a *AuditExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	refs := a.Targets
+	if a.Actor != "" {
+		refs = append([]string{a.Actor}, refs...)
+	}
+	for _, ref := range refs {
+		if name, ok := strings.CutPrefix(ref, AuditPayloadPrefix); ok && name != "" {
+			obj := AsObject(a.Method.Payload.Type)
+			if obj == nil || obj.Attribute(name) == nil {
+				verr.Add(a, "audit reference %q refers to attribute %q which is not defined in the payload of method %q of service %q", ref, name, a.Method.Name, a.Method.Service.Name)
+				continue
+			}
+			if !IsPrimitive(obj.Attribute(name).Type) {
+				// Only the values of primitive attributes can be
+				// redacted when sensitive.
+				verr.Add(a, "audit reference %q refers to attribute %q which is not a primitive type", ref, name)
+			}
+			continue
+		}
+		if name, ok := strings.CutPrefix(ref, AuditAuthPrefix); ok && name != "" {
+			continue
+		}
+		verr.Add(a, "invalid audit reference %q, references must be of the form \"auth.<claim>\" or \"payload.<attribute>\"", ref)
+	}
+	return verr
+}
@@ -156,9 +156,27 @@ func (e *GRPCEndpointExpr) Validate() error {
 	verr.Merge(e.hasAnyType(e.MethodExpr.Payload, "Payload"))
 	verr.Merge(e.hasAnyType(e.MethodExpr.Result, "Result"))
 	for _, er := range e.MethodExpr.Errors {
+		if er.Type == ErrorResult {
+			// The default error type is encoded using the goa ErrorResponse
+			// message which carries the details as a google.protobuf.Struct.
+			continue
+		}
 		verr.Merge(e.hasAnyType(er.AttributeExpr, fmt.Sprintf("Error %q", er.Name)))
 	}
 
+	// request signatures cover HTTP request components and sessions rely
+	// on HTTP cookies.
+	for _, req := range e.MethodExpr.Requirements {
+		for _, sch := range req.Schemes {
+			switch sch.Kind {
+			case SignatureKind:
+				verr.Add(e, "security scheme %q is a request signature scheme which is not supported by gRPC endpoints", sch.SchemeName)
+			case SessionKind:
+				verr.Add(e, "security scheme %q is a cookie session scheme which is not supported by gRPC endpoints", sch.SchemeName)
+			}
+		}
+	}
+
 	var hasMessage, hasMetadata bool
 	// Validate request
 	if e.Request.Type != Empty {
@@ -254,7 +272,7 @@ func (e *GRPCEndpointExpr) Finalize() {
 				for _, sch := range dupReq.Schemes {
 					var field string
 					switch sch.Kind {
-					case NoKind:
+					case NoKind, MutualTLSKind, SignatureKind, SessionKind:
 						continue
 					case BasicAuthKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:username")
@@ -270,7 +288,7 @@ func (e *GRPCEndpointExpr) Finalize() {
 						continue
 					case APIKeyKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
-					case JWTKind:
+					case JWTKind, OpenIDConnectKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:token")
 					case OAuth2Kind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:accesstoken")
@@ -306,6 +324,13 @@ func (e *GRPCEndpointExpr) Finalize() {
 			// remove metadata attributes from the message attributes
 			msgObj.Delete(nat.Name)
 		}
+		// read-only attributes are set by the server, remove them from the
+		// message attributes
+		for _, nat := range *pobj {
+			if nat.Attribute.IsReadOnly() {
+				msgObj.Delete(nat.Name)
+			}
+		}
 
 		// add any message attributes to request message if not added already
 		if len(*msgObj) > 0 {
@@ -332,6 +357,9 @@ func (e *GRPCEndpointExpr) Finalize() {
 				nat.Attribute.Meta.Merge(patt.Meta)
 			}
 		}
+		// read-only attributes of nested types are set by the server as
+		// well, remove them from the message types
+		removeFlaggedMessage(e.Request, ReadOnlyMetaKey, "Input")
 		if ut, ok := e.MethodExpr.Payload.Type.(UserType); ok {
 			// propagate the user set protobuf struct name from the user type to
 			// the request message.
@@ -352,6 +380,7 @@ func (e *GRPCEndpointExpr) Finalize() {
 			e.Metadata.Validation.AddRequired("goa_payload")
 		} else {
 			initAttrFromDesign(e.Request, e.MethodExpr.Payload)
+			removeFlaggedMessage(e.Request, ReadOnlyMetaKey, "Input")
 		}
 	}
 
@@ -514,7 +543,7 @@ func getSecurityAttributes(m *MethodExpr) []string {
 				if field := TaggedAttribute(m.Payload, "security:apikey:"+sch.SchemeName); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
-			case JWTKind:
+			case JWTKind, OpenIDConnectKind:
 				if field := TaggedAttribute(m.Payload, "security:token"); field != "" {
 					secAttrs = append(secAttrs, field)
 				}
@@ -585,3 +614,29 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 	}
 	return verr
 }
+
+// removeFlaggedMessage removes the attributes that define the given meta key
+// from a copy of the message type recursively. The user types that define
+// such attributes are renamed by appending suffix so that the corresponding
+// protocol buffer messages do not clash with the messages generated for the
+// complete types.
+func removeFlaggedMessage(att *AttributeExpr, key, suffix string) {
+	att.Type = Dup(att.Type)
+	walk(att.Type, func(ut UserType) {
+		obj := AsObject(ut.Attribute().Type)
+		if obj == nil {
+			return
+		}
+		for _, nat := range *obj {
+			if _, ok := nat.Attribute.Meta[key]; !ok {
+				continue
+			}
+			if proto, ok := ut.Attribute().Meta.Last("struct:name:proto"); ok {
+				ut.Attribute().Meta["struct:name:proto"] = []string{proto + suffix}
+			}
+			ut.Rename(ut.Name() + suffix)
+			return
+		}
+	})
+	removeFlagged(att, key, make(map[string]struct{}))
+}
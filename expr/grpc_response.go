@@ -161,6 +161,13 @@ func (r *GRPCResponseExpr) Finalize(a *GRPCEndpointExpr, svcAtt *AttributeExpr)
 			// remove metadata attributes from the message attributes
 			msgObj.Delete(nat.Name)
 		}
+		// write-only attributes are never rendered, remove them from the
+		// message attributes
+		for _, nat := range *svcObj {
+			if nat.Attribute.IsWriteOnly() {
+				msgObj.Delete(nat.Name)
+			}
+		}
 		// add any message attributes to response message if not added already
 		if len(*msgObj) > 0 {
 			if r.Message.Type == Empty {
@@ -186,6 +193,9 @@ func (r *GRPCResponseExpr) Finalize(a *GRPCEndpointExpr, svcAtt *AttributeExpr)
 				nat.Attribute.Meta.Merge(svcAtt.Meta)
 			}
 		}
+		// write-only attributes of nested types are never rendered either,
+		// remove them from the message types
+		removeFlaggedMessage(r.Message, WriteOnlyMetaKey, "Output")
 		if ut, ok := svcAtt.Type.(UserType); ok {
 			// propagate the user set protobuf struct name from the user type to
 			// the request message.
@@ -206,6 +216,7 @@ func (r *GRPCResponseExpr) Finalize(a *GRPCEndpointExpr, svcAtt *AttributeExpr)
 			initAttrFromDesign(r.Trailers.AttributeExpr, svcAtt)
 		} else {
 			initAttrFromDesign(r.Message, svcAtt)
+			removeFlaggedMessage(r.Message, WriteOnlyMetaKey, "Output")
 		}
 	}
 }
@@ -85,7 +85,7 @@ func defaultRequestHeaderAttributes(e *HTTPEndpointExpr) map[string]bool {
 		for _, sch := range req.Schemes {
 			var field string
 			switch sch.Kind {
-			case NoKind:
+			case NoKind, MutualTLSKind, SignatureKind, SessionKind:
 				continue
 			case BasicAuthKind:
 				user := TaggedAttribute(e.MethodExpr.Payload, "security:username")
@@ -101,7 +101,7 @@ func defaultRequestHeaderAttributes(e *HTTPEndpointExpr) map[string]bool {
 				continue
 			case APIKeyKind:
 				field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
-			case JWTKind:
+			case JWTKind, OpenIDConnectKind:
 				field = TaggedAttribute(e.MethodExpr.Payload, "security:token")
 			case OAuth2Kind:
 				field = TaggedAttribute(e.MethodExpr.Payload, "security:accesstoken")
@@ -127,6 +127,7 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 	)
 	if a.Body != nil {
 		a.Body = DupAtt(a.Body)
+		removeFlagged(a.Body, ReadOnlyMetaKey, make(map[string]struct{}))
 		renameType(a.Body, name, suffix)
 		if ut, ok := a.Body.Type.(*UserTypeExpr); ok {
 			ut.UID = a.Service.Name() + "#" + name
@@ -164,10 +165,11 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		return &AttributeExpr{Type: Empty}
 	}
 
-	// 3. Remove header, param and cookies attributes
+	// 3. Remove read-only, header, param and cookies attributes
 	body := NewMappedAttributeExpr(payload)
 	RemovePkgPath(body.AttributeExpr)
 	extendBodyAttribute(body)
+	removeFlaggedAttributes(body, ReadOnlyMetaKey)
 	removeAttributes(body, headers)
 	removeAttributes(body, cookies)
 	removeAttributes(body, params)
@@ -222,8 +224,10 @@ func httpStreamingBody(e *HTTPEndpointExpr) *AttributeExpr {
 		return DupAtt(att)
 	}
 	const suffix = "StreamingBody"
+	body := DupAtt(att)
+	removeFlagged(body, ReadOnlyMetaKey, make(map[string]struct{}))
 	ut := &UserTypeExpr{
-		AttributeExpr: DupAtt(att),
+		AttributeExpr: body,
 		TypeName:      concat(e.Name(), "Streaming", "Body"),
 		UID:           e.Service.Name() + "#" + e.Name() + "StreamingBody",
 	}
@@ -284,6 +288,7 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 			return &AttributeExpr{Type: Empty}
 		}
 		att := DupAtt(resp.Body)
+		removeFlagged(att, WriteOnlyMetaKey, make(map[string]struct{}))
 		renameType(att, name, suffix)
 		if ut, ok := att.Type.(*UserTypeExpr); ok {
 			ut.UID = svc.Name() + "#" + name
@@ -318,7 +323,8 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	RemovePkgPath(body.AttributeExpr)
 	extendBodyAttribute(body)
 
-	// 4. Remove header and cookie attributes
+	// 4. Remove write-only, header and cookie attributes
+	removeFlaggedAttributes(body, WriteOnlyMetaKey)
 	removeAttributes(body, resp.Headers)
 	removeAttributes(body, resp.Cookies)
 
@@ -355,6 +361,7 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	views := make([]*ViewExpr, len(rt.Views))
 	for i, v := range rt.Views {
 		mv := NewMappedAttributeExpr(v.AttributeExpr)
+		removeFlaggedAttributes(mv, WriteOnlyMetaKey)
 		removeAttributes(mv, resp.Headers)
 		removeAttributes(mv, resp.Cookies)
 		nv := &ViewExpr{
@@ -490,6 +497,60 @@ func removeAttribute(attr *MappedAttributeExpr, name string) {
 	}
 }
 
+// removeFlaggedAttributes removes the child attributes of attr that define the
+// given meta key (ReadOnlyMetaKey or WriteOnlyMetaKey). Attributes of nested
+// types are removed as well.
+func removeFlaggedAttributes(attr *MappedAttributeExpr, key string) {
+	var (
+		names []string
+		seen  = make(map[string]struct{})
+	)
+	for _, nat := range *AsObject(attr.Type) {
+		if _, ok := nat.Attribute.Meta[key]; ok {
+			names = append(names, nat.Name)
+			continue
+		}
+		removeFlagged(nat.Attribute, key, seen)
+	}
+	for _, n := range names {
+		removeAttribute(attr, n)
+	}
+}
+
+// removeFlagged traverses the given attribute type recursively and removes
+// the object attributes that define the given meta key.
+func removeFlagged(att *AttributeExpr, key string, seen map[string]struct{}) {
+	switch t := att.Type.(type) {
+	case UserType:
+		if _, ok := seen[t.ID()]; ok {
+			return
+		}
+		seen[t.ID()] = struct{}{}
+		removeFlagged(t.Attribute(), key, seen)
+	case *Object:
+		var names []string
+		for _, nat := range *t {
+			if _, ok := nat.Attribute.Meta[key]; ok {
+				names = append(names, nat.Name)
+				continue
+			}
+			removeFlagged(nat.Attribute, key, seen)
+		}
+		for _, n := range names {
+			att.Delete(n)
+		}
+	case *Array:
+		removeFlagged(t.ElemType, key, seen)
+	case *Map:
+		removeFlagged(t.KeyType, key, seen)
+		removeFlagged(t.ElemType, key, seen)
+	case *Union:
+		for _, nat := range t.Values {
+			removeFlagged(nat.Attribute, key, seen)
+		}
+	}
+}
+
 // extendBodyAttribute returns an attribute describing the HTTP
 // request/response body type by merging any Bases and References to the parent
 // attribute. This must be invoked during validation or to determine the actual
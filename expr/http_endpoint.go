@@ -666,15 +666,39 @@ func (e *HTTPEndpointExpr) Finalize() {
 			for _, sch := range dupReq.Schemes {
 				var field string
 				switch sch.Kind {
-				case NoKind:
+				case NoKind, MutualTLSKind:
 					continue
 				case BasicAuthKind:
 					sch.In = "header"
 					sch.Name = "Authorization"
 					continue
+				case SignatureKind:
+					sch.In = "header"
+					sch.Name = "Signature"
+					continue
+				case SessionKind:
+					// The session ID is read from the session cookie
+					// unless explicitly mapped to another cookie.
+					field = TaggedAttribute(e.MethodExpr.Payload, "security:session")
+					sch.In = "cookie"
+					if n, ok := e.Cookies.FindKey(field); ok {
+						sch.Name = n
+						continue
+					}
+					sch.Name = sch.SessionCookie
+					attr := e.MethodExpr.Payload.Find(field)
+					e.Cookies.Type.(*Object).Set(field, attr)
+					e.Cookies.Map(sch.Name, field)
+					if e.MethodExpr.Payload.IsRequired(field) {
+						if e.Cookies.Validation == nil {
+							e.Cookies.Validation = &ValidationExpr{}
+						}
+						e.Cookies.Validation.AddRequired(field)
+					}
+					continue
 				case APIKeyKind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
-				case JWTKind:
+				case JWTKind, OpenIDConnectKind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:token")
 				case OAuth2Kind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:accesstoken")
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
@@ -300,3 +302,26 @@ func TestHTTPAuthorizationMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPEndpointReadOnlyWriteOnly(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ReadOnlyWriteOnlyDSL)
+	e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+
+	names := func(att *expr.AttributeExpr) []string {
+		var res []string
+		for _, nat := range *expr.AsObject(att.Type) {
+			res = append(res, nat.Name)
+		}
+		return res
+	}
+
+	body := e.Body
+	assert.Equal(t, []string{"name", "password", "credentials"}, names(body))
+	assert.ElementsMatch(t, []string{"name", "password"}, body.Validation.Required)
+	assert.Equal(t, []string{"key", "secret"}, names(body.Find("credentials")))
+
+	resp := e.Responses[0].Body
+	assert.Equal(t, []string{"id", "name", "credentials"}, names(resp))
+	assert.ElementsMatch(t, []string{"id", "name"}, resp.Validation.Required)
+	assert.Equal(t, []string{"key", "created_at"}, names(resp.Find("credentials")))
+}
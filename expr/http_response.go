@@ -358,6 +358,10 @@ func (r *HTTPResponseExpr) mapUnmappedAttrs(svcAtt *AttributeExpr) {
 				if originAttr == nat.Name {
 					continue
 				}
+				if IsMap(nat.Attribute.Type) {
+					// Maps (i.e. error details) cannot be encoded in headers.
+					continue
+				}
 				if _, ok := r.Headers.FindKey(nat.Name); ok {
 					continue
 				}
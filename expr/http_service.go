@@ -46,6 +46,10 @@ type (
 	}
 )
 
+// ProblemDetailsMetaKey is the meta key used to enable the RFC 9457 problem
+// details error format.
+const ProblemDetailsMetaKey = "http:problem"
+
 // Name of service (service)
 func (svc *HTTPServiceExpr) Name() string {
 	return svc.ServiceExpr.Name
@@ -161,6 +165,23 @@ func (svc *HTTPServiceExpr) HTTPError(name string) *HTTPErrorExpr {
 	return nil
 }
 
+// UsesProblemDetails returns true if the service errors are formatted as RFC
+// 9457 problem details. The setting defined on the service overrides the
+// setting defined on the API.
+func (svc *HTTPServiceExpr) UsesProblemDetails() bool {
+	if svc.ServiceExpr != nil {
+		if v, ok := svc.ServiceExpr.Meta.Last(ProblemDetailsMetaKey); ok {
+			return v == "true"
+		}
+	}
+	if Root != nil && Root.API != nil {
+		if v, ok := Root.API.Meta.Last(ProblemDetailsMetaKey); ok {
+			return v == "true"
+		}
+	}
+	return false
+}
+
 // EvalName returns the generic definition name used in error messages.
 func (svc *HTTPServiceExpr) EvalName() string {
 	if svc.Name() == "" {
@@ -0,0 +1,94 @@
+package expr
+
+type (
+	// JSONNamingKind is the naming convention applied to the keys of the
+	// JSON objects that represent HTTP request and response bodies.
+	JSONNamingKind string
+
+	// JSONPolicyExpr describes how HTTP request and response bodies are
+	// serialized to JSON. The policy is defined at the API or service
+	// level using Meta, see JSONPolicy.
+	JSONPolicyExpr struct {
+		// Naming is the naming convention applied to the JSON object
+		// keys, empty if the attribute names are used as is.
+		Naming JSONNamingKind
+		// EmitZeroValues is true if optional fields are serialized
+		// even when empty instead of being omitted.
+		EmitZeroValues bool
+		// Int64AsString is true if 64-bit integers are serialized as
+		// JSON strings.
+		Int64AsString bool
+	}
+)
+
+const (
+	// JSONSnakeCase produces JSON keys such as "first_name".
+	JSONSnakeCase JSONNamingKind = "snake_case"
+	// JSONCamelCase produces JSON keys such as "firstName".
+	JSONCamelCase JSONNamingKind = "camelCase"
+	// JSONKebabCase produces JSON keys such as "first-name".
+	JSONKebabCase JSONNamingKind = "kebab-case"
+)
+
+const (
+	// JSONNamingMetaKey is the meta key used to define the naming
+	// convention applied to the JSON object keys.
+	JSONNamingMetaKey = "json:naming"
+	// JSONOmitEmptyMetaKey is the meta key used to define whether empty
+	// optional fields are omitted from the JSON objects.
+	JSONOmitEmptyMetaKey = "json:omitempty"
+	// JSONInt64AsStringMetaKey is the meta key used to define whether
+	// 64-bit integers are serialized as JSON strings.
+	JSONInt64AsStringMetaKey = "json:int64string"
+)
+
+// JSONPolicy returns the JSON serialization policy that applies to the bodies
+// of the HTTP endpoints of the given service. Settings defined on the service
+// override settings defined on the API. svc may be nil in which case only the
+// API level settings apply.
+func JSONPolicy(svc *ServiceExpr) *JSONPolicyExpr {
+	lookup := func(key string) (string, bool) {
+		if svc != nil {
+			if v, ok := svc.Meta.Last(key); ok {
+				return v, true
+			}
+		}
+		if Root != nil && Root.API != nil {
+			return Root.API.Meta.Last(key)
+		}
+		return "", false
+	}
+	var p JSONPolicyExpr
+	if v, ok := lookup(JSONNamingMetaKey); ok {
+		p.Naming = JSONNamingKind(v)
+	}
+	if v, ok := lookup(JSONOmitEmptyMetaKey); ok {
+		p.EmitZeroValues = v == "false"
+	}
+	if v, ok := lookup(JSONInt64AsStringMetaKey); ok {
+		p.Int64AsString = v == "true"
+	}
+	return &p
+}
+
+// IsDefault returns true if the policy does not alter the default
+// serialization: attribute names are used as JSON keys, empty optional fields
+// are omitted and 64-bit integers are serialized as JSON numbers.
+func (p *JSONPolicyExpr) IsDefault() bool {
+	return p == nil || *p == JSONPolicyExpr{}
+}
+
+// EncodeAsString returns true if the policy requires the object field
+// described by the given attribute to be serialized as a JSON string. Only
+// scalar fields qualify: the Go ",string" tag option does not apply to the
+// elements of arrays and maps which are always serialized as JSON numbers.
+func (p *JSONPolicyExpr) EncodeAsString(att *AttributeExpr) bool {
+	if p == nil || !p.Int64AsString || att == nil {
+		return false
+	}
+	switch att.Type.Kind() {
+	case Int64Kind, UInt64Kind:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestJSONPolicy(t *testing.T) {
+	root := expr.RunDSL(t, testdata.JSONPolicyDSL)
+	cases := map[string]struct {
+		service  string
+		expected *expr.JSONPolicyExpr
+	}{
+		"api":      {"Default", &expr.JSONPolicyExpr{Naming: expr.JSONSnakeCase, Int64AsString: true}},
+		"override": {"Override", &expr.JSONPolicyExpr{Naming: expr.JSONCamelCase, EmitZeroValues: true, Int64AsString: true}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, tc.expected, expr.JSONPolicy(root.Service(tc.service)))
+		})
+	}
+	assert.True(t, (&expr.JSONPolicyExpr{}).IsDefault())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/security/policy"
 )
 
 type (
@@ -32,6 +33,12 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Policies lists the authorization policies that requests
+		// must satisfy once authenticated, see dsl.Authorize.
+		Policies []string
+		// Audit describes the audit events emitted by the method
+		// endpoint if any, see dsl.Audit.
+		Audit *AuditExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -58,6 +65,10 @@ const (
 	BidirectionalStreamKind
 )
 
+// StrictDecodingMetaKey is the meta key used to enable strict decoding of the
+// HTTP requests. It may be set on the API, a service or a method.
+const StrictDecodingMetaKey = "decoding:strict"
+
 // Error returns the error with the given name. It looks up recursively in the
 // endpoint then the service and finally the root expression.
 func (m *MethodExpr) Error(name string) *ErrorExpr {
@@ -115,6 +126,8 @@ func (m *MethodExpr) Validate() error {
 		hasAPIKey    bool
 		hasJWT       bool
 		hasOAuth     bool
+		hasOIDC      bool
+		hasSession   bool
 	)
 	for _, r := range requirements {
 		for _, s := range r.Schemes {
@@ -143,12 +156,22 @@ func (m *MethodExpr) Validate() error {
 				if !hasTag(m.Payload, "security:accesstoken") {
 					verr.Add(m, "payload of method %q of service %q does not define a OAuth2 access token attribute, use AccessToken to define one", m.Name, m.Service.Name)
 				}
+			case OpenIDConnectKind:
+				hasOIDC = true
+				if !hasTag(m.Payload, "security:token") {
+					verr.Add(m, "payload of method %q of service %q does not define an OpenID Connect token attribute, use Token to define one", m.Name, m.Service.Name)
+				}
+			case SessionKind:
+				hasSession = true
+				if !hasTag(m.Payload, "security:session") {
+					verr.Add(m, "payload of method %q of service %q does not define a session ID attribute, use SessionID to define one", m.Name, m.Service.Name)
+				}
 			}
 		}
 		for _, scope := range r.Scopes {
 			found := false
 			for _, s := range r.Schemes {
-				if s.Kind == BasicAuthKind || s.Kind == APIKeyKind || s.Kind == OAuth2Kind || s.Kind == JWTKind {
+				if s.Kind != NoKind {
 					for _, se := range s.Scopes {
 						if se.Name == scope {
 							found = true
@@ -175,7 +198,7 @@ func (m *MethodExpr) Validate() error {
 			verr.Add(m, "payload of method %q of service %q defines an API key attribute, but no APIKey security scheme exist", m.Name, m.Service.Name)
 		}
 	}
-	if !hasJWT {
+	if !hasJWT && !hasOIDC {
 		if hasTag(m.Payload, "security:token") {
 			verr.Add(m, "payload of method %q of service %q defines a JWT token attribute, but no JWT auth security scheme exist", m.Name, m.Service.Name)
 		}
@@ -185,6 +208,26 @@ func (m *MethodExpr) Validate() error {
 			verr.Add(m, "payload of method %q of service %q defines a OAuth2 access token attribute, but no OAuth2 security scheme exist", m.Name, m.Service.Name)
 		}
 	}
+	if !hasSession {
+		if hasTag(m.Payload, "security:session") {
+			verr.Add(m, "payload of method %q of service %q defines a session ID attribute, but no session security scheme exist", m.Name, m.Service.Name)
+		}
+	}
+	for _, p := range m.Policies {
+		pol, err := policy.Parse(p)
+		if err != nil {
+			verr.Add(m, "invalid authorization policy %q: %s", p, err)
+			continue
+		}
+		for _, att := range pol.PayloadAttributes() {
+			if obj := AsObject(m.Payload.Type); obj == nil || obj.Attribute(att) == nil {
+				verr.Add(m, "authorization policy %q refers to attribute %q which is not defined in the payload of method %q of service %q", p, att, m.Name, m.Service.Name)
+			}
+		}
+	}
+	if m.Audit != nil {
+		verr.Merge(m.Audit.Validate())
+	}
 	if m.StreamingPayload.Type != Empty {
 		verr.Merge(m.StreamingPayload.Validate("streaming_payload", m))
 	}
@@ -327,6 +370,27 @@ loop:
 	}
 }
 
+// IsStrictDecoding returns true if the server must reject requests whose
+// body or query string contain fields that are not defined in the design.
+// The setting defined on the method overrides the setting defined on the
+// service which in turn overrides the setting defined on the API.
+func (m *MethodExpr) IsStrictDecoding() bool {
+	if v, ok := m.Meta.Last(StrictDecodingMetaKey); ok {
+		return v == "true"
+	}
+	if m.Service != nil {
+		if v, ok := m.Service.Meta.Last(StrictDecodingMetaKey); ok {
+			return v == "true"
+		}
+	}
+	if Root != nil && Root.API != nil {
+		if v, ok := Root.API.Meta.Last(StrictDecodingMetaKey); ok {
+			return v == "true"
+		}
+	}
+	return false
+}
+
 // IsStreaming determines whether the method streams payload or result.
 func (m *MethodExpr) IsStreaming() bool {
 	return m.IsPayloadStreaming() || m.IsResultStreaming()
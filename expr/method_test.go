@@ -34,6 +34,24 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a JWT token attribute, but no JWT auth security scheme exist
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a OAuth2 access token attribute, but no OAuth2 security scheme exist`,
 		},
+		{"invalid-session", testdata.InvalidSessionDSL,
+			`service "InvalidSessionService" method "MissingSessionID": payload of method "MissingSessionID" of service "InvalidSessionService" does not define a session ID attribute, use SessionID to define one
+service "InvalidSessionService" method "MissingScheme": payload of method "MissingScheme" of service "InvalidSessionService" defines a session ID attribute, but no session security scheme exist`,
+		},
+		{"valid-policy", testdata.ValidPolicyDSL, ""},
+		{"invalid-policy", testdata.InvalidPolicyDSL,
+			`service "InvalidPolicyService" method "Method": invalid authorization policy "role:admin and (": unexpected end of policy
+service "InvalidPolicyService" method "Method": authorization policy "payload.missing == auth.id" refers to attribute "missing" which is not defined in the payload of method "Method" of service "InvalidPolicyService"
+service "InvalidPolicyService" method "NoPayload": authorization policy "payload.account_id == auth.account_id" refers to attribute "account_id" which is not defined in the payload of method "NoPayload" of service "InvalidPolicyService"`,
+		},
+		{"valid-audit", testdata.ValidAuditDSL, ""},
+		{"invalid-audit", testdata.InvalidAuditDSL,
+			`audit of service "InvalidAuditService" method "Method": invalid audit reference "sub", references must be of the form "auth.<claim>" or "payload.<attribute>"
+audit of service "InvalidAuditService" method "Method": audit reference "payload.missing" refers to attribute "missing" which is not defined in the payload of method "Method" of service "InvalidAuditService"
+audit of service "InvalidAuditService" method "Method": invalid audit reference "auth.", references must be of the form "auth.<claim>" or "payload.<attribute>"
+audit of service "InvalidAuditService" method "Method": audit reference "payload.user" refers to attribute "user" which is not a primitive type
+audit of service "InvalidAuditService" method "NoPayload": audit reference "payload.id" refers to attribute "id" which is not defined in the payload of method "NoPayload" of service "InvalidAuditService"`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
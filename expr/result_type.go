@@ -93,6 +93,10 @@ var (
 			Type:        Boolean,
 			Description: "Is the error a server-side fault?",
 		}},
+		{"details", &AttributeExpr{
+			Type:        &Map{KeyType: &AttributeExpr{Type: String}, ElemType: &AttributeExpr{Type: Any}},
+			Description: "Details contains additional machine-readable information about the error.",
+		}},
 	}
 
 	errorResultView = &ViewExpr{
@@ -178,6 +182,7 @@ func (rt *ResultTypeExpr) ViewHasAttribute(view, attr string) bool {
 func (rt *ResultTypeExpr) Finalize() {
 	rt.useExplicitView()
 	rt.ensureDefaultView()
+	rt.removeWriteOnly()
 	rt.UserTypeExpr.Finalize()
 	seen := make(map[string]struct{})
 	walkAttribute(rt.AttributeExpr, func(_ string, att *AttributeExpr) error { // nolint: errcheck
@@ -186,6 +191,7 @@ func (rt *ResultTypeExpr) Finalize() {
 				seen[rt.Identifier] = struct{}{}
 				rt.useExplicitView()
 				rt.ensureDefaultView()
+				rt.removeWriteOnly()
 			}
 		}
 		return nil
@@ -220,6 +226,26 @@ func (rt *ResultTypeExpr) ensureDefaultView() {
 	}
 }
 
+// removeWriteOnly removes the write-only attributes from all the views so that
+// they never get rendered.
+func (rt *ResultTypeExpr) removeWriteOnly() {
+	for _, v := range rt.Views {
+		o := AsObject(v.Type)
+		if o == nil {
+			continue
+		}
+		var names []string
+		for _, nat := range *o {
+			if nat.Attribute.IsWriteOnly() {
+				names = append(names, nat.Name)
+			}
+		}
+		for _, n := range names {
+			v.Delete(n)
+		}
+	}
+}
+
 // Project creates a ResultTypeExpr containing the fields defined in the view
 // expression of m named after the view argument.
 //
@@ -3,10 +3,17 @@ package expr
 import (
 	"fmt"
 	"net/url"
+	"regexp"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
 
+// signedComponentRegex matches the request components that may be covered by
+// the signature of a Signature scheme: the supported derived components and
+// lowercase header names.
+var signedComponentRegex = regexp.MustCompile(`^(@method|@authority|@path|@query|[a-z0-9!#$%&'*+.^_|~-]+)$`)
+
 // SchemeKind is a type of security scheme.
 type SchemeKind int
 
@@ -22,6 +29,18 @@ const (
 	JWTKind
 	// NoKind means to have no security for this endpoint.
 	NoKind
+	// OpenIDConnectKind means an "OpenID Connect" security scheme where
+	// the client provides a token issued by an OpenID Connect provider.
+	OpenIDConnectKind
+	// MutualTLSKind means a "mutual TLS" security scheme where the client
+	// authenticates with a TLS certificate.
+	MutualTLSKind
+	// SignatureKind means a "request signature" security scheme where the
+	// client signs the request with a key shared with the server.
+	SignatureKind
+	// SessionKind means a "cookie session" security scheme where browsers
+	// send a session ID in a cookie.
+	SessionKind
 )
 
 // FlowKind is a type of OAuth2 flow.
@@ -39,6 +58,27 @@ const (
 	ClientCredentialsFlowKind
 )
 
+const (
+	// JWTJWKSMetaKey is the meta key used to store the path to the JSON Web
+	// Key Set file used to verify the tokens of a JWT scheme.
+	JWTJWKSMetaKey = "jwt:jwks"
+	// JWTIssuerMetaKey is the meta key used to store the expected issuer of
+	// the tokens of a JWT scheme.
+	JWTIssuerMetaKey = "jwt:issuer"
+	// JWTAudienceMetaKey is the meta key used to store the accepted
+	// audiences of the tokens of a JWT scheme.
+	JWTAudienceMetaKey = "jwt:audience"
+)
+
+const (
+	// CSRFDoubleSubmit is the CSRF protection mode where clients echo the
+	// value of a CSRF cookie in a request header.
+	CSRFDoubleSubmit = "double-submit"
+	// CSRFSynchronizerToken is the CSRF protection mode where clients send
+	// a token stored server side in the session in a request header.
+	CSRFSynchronizerToken = "synchronizer-token"
+)
+
 type (
 	// SecurityExpr defines a security requirement.
 	SecurityExpr struct {
@@ -69,6 +109,28 @@ type (
 		Scopes []*ScopeExpr
 		// Flows determine the oauth2 flows supported by this scheme.
 		Flows []*FlowExpr
+		// DiscoveryURL is the OpenID Connect discovery URL of
+		// OpenIDConnect schemes.
+		DiscoveryURL string
+		// SignedComponents lists the request components that must be
+		// covered by the signature of Signature schemes.
+		SignedComponents []string
+		// MaxClockSkew is the maximum difference between the signature
+		// creation time and the server clock of Signature schemes.
+		MaxClockSkew time.Duration
+		// SessionCookie is the name of the cookie that holds the session
+		// ID of Session schemes.
+		SessionCookie string
+		// CSRF is the CSRF protection mode of Session schemes, one of
+		// CSRFDoubleSubmit or CSRFSynchronizerToken. CSRF protection is
+		// disabled if empty.
+		CSRF string
+		// CSRFHeader is the name of the header that holds the CSRF
+		// token of Session schemes.
+		CSRFHeader string
+		// CSRFCookie is the name of the cookie that holds the CSRF token
+		// of Session schemes that use the double-submit mode.
+		CSRFCookie string
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
 	}
@@ -120,13 +182,20 @@ func DupRequirement(req *SecurityExpr) *SecurityExpr {
 // DupScheme creates a copy of the given scheme expression.
 func DupScheme(sch *SchemeExpr) *SchemeExpr {
 	dup := SchemeExpr{
-		Kind:        sch.Kind,
-		SchemeName:  sch.SchemeName,
-		Description: sch.Description,
-		In:          sch.In,
-		Scopes:      sch.Scopes,
-		Flows:       sch.Flows,
-		Meta:        sch.Meta,
+		Kind:             sch.Kind,
+		SchemeName:       sch.SchemeName,
+		Description:      sch.Description,
+		In:               sch.In,
+		Scopes:           sch.Scopes,
+		Flows:            sch.Flows,
+		DiscoveryURL:     sch.DiscoveryURL,
+		SignedComponents: sch.SignedComponents,
+		MaxClockSkew:     sch.MaxClockSkew,
+		SessionCookie:    sch.SessionCookie,
+		CSRF:             sch.CSRF,
+		CSRFHeader:       sch.CSRFHeader,
+		CSRFCookie:       sch.CSRFCookie,
+		Meta:             sch.Meta,
 	}
 	return &dup
 }
@@ -142,6 +211,14 @@ func (s *SchemeExpr) Type() string {
 		return "APIKey"
 	case JWTKind:
 		return "JWT"
+	case OpenIDConnectKind:
+		return "OpenIDConnect"
+	case MutualTLSKind:
+		return "MutualTLS"
+	case SignatureKind:
+		return "Signature"
+	case SessionKind:
+		return "Session"
 	default:
 		panic(fmt.Sprintf("unknown scheme kind: %#v", s.Kind)) // bug
 	}
@@ -166,6 +243,41 @@ func (s *SchemeExpr) Validate() *eval.ValidationErrors {
 			verr.Merge(err)
 		}
 	}
+	if s.Kind == OpenIDConnectKind {
+		if u, err := url.Parse(s.DiscoveryURL); err != nil || !u.IsAbs() {
+			verr.Add(s, "invalid OpenID Connect discovery URL %q, must be an absolute URL", s.DiscoveryURL)
+		}
+	}
+	if s.Kind == SignatureKind {
+		if len(s.SignedComponents) == 0 {
+			verr.Add(s, "signature scheme must sign at least one request component")
+		}
+		for _, c := range s.SignedComponents {
+			if !signedComponentRegex.MatchString(c) {
+				verr.Add(s, "invalid signed component %q, must be one of \"@method\", \"@authority\", \"@path\", \"@query\" or a lowercase header name", c)
+			}
+		}
+		if s.MaxClockSkew < 0 {
+			verr.Add(s, "invalid maximum clock skew %s, must be positive", s.MaxClockSkew)
+		}
+	}
+	if s.Kind == SessionKind {
+		if s.SessionCookie == "" {
+			verr.Add(s, "session scheme must define the name of the session cookie")
+		}
+		switch s.CSRF {
+		case "", CSRFSynchronizerToken:
+		case CSRFDoubleSubmit:
+			if s.CSRFCookie == "" {
+				verr.Add(s, "double-submit CSRF protection must define the name of the CSRF cookie")
+			}
+		default:
+			verr.Add(s, "invalid CSRF protection mode %q, must be %q or %q", s.CSRF, CSRFDoubleSubmit, CSRFSynchronizerToken)
+		}
+		if s.CSRF != "" && s.CSRFHeader == "" {
+			verr.Add(s, "CSRF protection must define the name of the CSRF header")
+		}
+	}
 	return verr
 }
 
@@ -215,6 +327,14 @@ func (k SchemeKind) String() string {
 		return "JWT"
 	case OAuth2Kind:
 		return "OAuth2"
+	case OpenIDConnectKind:
+		return "OpenIDConnect"
+	case MutualTLSKind:
+		return "MutualTLS"
+	case SignatureKind:
+		return "Signature"
+	case SessionKind:
+		return "Session"
 	case NoKind:
 		return "None"
 	default:
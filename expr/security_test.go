@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -221,6 +222,22 @@ func TestSchemeExprType(t *testing.T) {
 			kind:     JWTKind,
 			expected: "JWT",
 		},
+		"openid connect": {
+			kind:     OpenIDConnectKind,
+			expected: "OpenIDConnect",
+		},
+		"mutual tls": {
+			kind:     MutualTLSKind,
+			expected: "MutualTLS",
+		},
+		"signature": {
+			kind:     SignatureKind,
+			expected: "Signature",
+		},
+		"session": {
+			kind:     SessionKind,
+			expected: "Session",
+		},
 		"NoKind": {
 			kind:     NoKind,
 			expected: "", // should have panicked!
@@ -348,6 +365,67 @@ func TestSchemeExprValidate(t *testing.T) {
 	}
 }
 
+func TestSchemeExprValidateDiscoveryURL(t *testing.T) {
+	cases := map[string]struct {
+		url     string
+		invalid bool
+	}{
+		"valid":    {url: "https://auth.example.com/.well-known/openid-configuration"},
+		"relative": {url: "/.well-known/openid-configuration", invalid: true},
+		"empty":    {url: "", invalid: true},
+		"invalid":  {url: "http://%", invalid: true},
+	}
+	for k, tc := range cases {
+		s := SchemeExpr{Kind: OpenIDConnectKind, SchemeName: "oidc", DiscoveryURL: tc.url}
+		if actual := s.Validate(); tc.invalid != (len(actual.Errors) == 1) {
+			t.Errorf("%s: got errors %v", k, actual.Errors)
+		}
+	}
+}
+
+func TestSchemeExprValidateSignedComponents(t *testing.T) {
+	cases := map[string]struct {
+		components []string
+		skew       time.Duration
+		invalid    bool
+	}{
+		"valid":             {components: []string{"@method", "@authority", "@path", "@query", "content-digest", "x-request-id"}},
+		"empty":             {invalid: true},
+		"unsupported":       {components: []string{"@target-uri"}, invalid: true},
+		"uppercase-header":  {components: []string{"Content-Type"}, invalid: true},
+		"negative-skew":     {components: []string{"@method"}, skew: -time.Second, invalid: true},
+		"positive-skew":     {components: []string{"@method"}, skew: time.Minute},
+		"invalid-character": {components: []string{"x header"}, invalid: true},
+	}
+	for k, tc := range cases {
+		s := SchemeExpr{Kind: SignatureKind, SchemeName: "signed", SignedComponents: tc.components, MaxClockSkew: tc.skew}
+		if actual := s.Validate(); tc.invalid != (len(actual.Errors) == 1) {
+			t.Errorf("%s: got errors %v", k, actual.Errors)
+		}
+	}
+}
+
+func TestSchemeExprValidateSession(t *testing.T) {
+	cases := map[string]struct {
+		cookie, csrf, header, csrfCookie string
+		invalid                          bool
+	}{
+		"valid":                {cookie: "session"},
+		"double-submit":        {cookie: "session", csrf: CSRFDoubleSubmit, header: "X-CSRF-Token", csrfCookie: "csrf_token"},
+		"synchronizer-token":   {cookie: "session", csrf: CSRFSynchronizerToken, header: "X-CSRF-Token"},
+		"no-cookie":            {invalid: true},
+		"invalid-mode":         {cookie: "session", csrf: "origin", header: "X-CSRF-Token", invalid: true},
+		"no-header":            {cookie: "session", csrf: CSRFSynchronizerToken, invalid: true},
+		"double-submit-cookie": {cookie: "session", csrf: CSRFDoubleSubmit, header: "X-CSRF-Token", invalid: true},
+	}
+	for k, tc := range cases {
+		s := SchemeExpr{Kind: SessionKind, SchemeName: "session", SessionCookie: tc.cookie, CSRF: tc.csrf, CSRFHeader: tc.header, CSRFCookie: tc.csrfCookie}
+		if actual := s.Validate(); tc.invalid != (len(actual.Errors) == 1) {
+			t.Errorf("%s: got errors %v", k, actual.Errors)
+		}
+	}
+}
+
 func TestSchemeKindString(t *testing.T) {
 	var unknownKind SchemeKind
 	cases := map[string]struct {
@@ -370,6 +448,22 @@ func TestSchemeKindString(t *testing.T) {
 			kind:     OAuth2Kind,
 			expected: "OAuth2",
 		},
+		"openid connect": {
+			kind:     OpenIDConnectKind,
+			expected: "OpenIDConnect",
+		},
+		"mutual tls": {
+			kind:     MutualTLSKind,
+			expected: "MutualTLS",
+		},
+		"signature": {
+			kind:     SignatureKind,
+			expected: "Signature",
+		},
+		"session": {
+			kind:     SessionKind,
+			expected: "Session",
+		},
 		"no kind": {
 			kind:     NoKind,
 			expected: "None",
@@ -42,6 +42,10 @@ type (
 	}
 )
 
+// ErrorDetailsMetaKey is the meta key used to record the name of the type
+// that describes the details of errors that use the default error type.
+const ErrorDetailsMetaKey = "goa:error:details"
+
 // Method returns the method expression with the given name, nil if there isn't
 // one.
 func (s *ServiceExpr) Method(n string) *MethodExpr {
@@ -117,12 +121,31 @@ func (e *ErrorExpr) Validate() error {
 		}
 		return nil
 	})
+	if n, ok := e.AttributeExpr.Meta.Last(ErrorDetailsMetaKey); ok {
+		if e.AttributeExpr.Type != ErrorResult {
+			verr.Add(e, "details can only be defined on errors that use the default error type")
+		}
+		if dt := Root.UserType(n); dt == nil {
+			verr.Add(e, "details type %q not found", n)
+		} else if !IsObject(dt) {
+			verr.Add(e, "details type %q must be an object", n)
+		}
+	}
 	if len(verr.Errors) > 0 {
 		return verr
 	}
 	return nil
 }
 
+// DetailsType returns the type that describes the error details as defined
+// with the Details DSL, nil if there isn't one.
+func (e *ErrorExpr) DetailsType() UserType {
+	if n, ok := e.AttributeExpr.Meta.Last(ErrorDetailsMetaKey); ok {
+		return Root.UserType(n)
+	}
+	return nil
+}
+
 // Finalize makes sure the error type is a user type since it has to generate a
 // Go error.
 // Note: this may produce a user type with an attribute that is not an object!
@@ -713,3 +713,37 @@ var GRPCEndpointWithExtendedTypes = func() {
 		})
 	})
 }
+
+var ReadOnlyWriteOnlyDSL = func() {
+	var Credentials = Type("Credentials", func() {
+		Attribute("key", String)
+		Attribute("secret", String, func() {
+			WriteOnly()
+		})
+		Attribute("created_at", String, func() {
+			ReadOnly()
+		})
+	})
+
+	var Account = Type("Account", func() {
+		Attribute("id", String, func() {
+			ReadOnly()
+		})
+		Attribute("name", String)
+		Attribute("password", String, func() {
+			WriteOnly()
+		})
+		Attribute("credentials", Credentials)
+		Required("id", "name", "password")
+	})
+
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Account)
+			Result(Account)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -71,3 +71,92 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var ValidPolicyDSL = func() {
+	Service("ValidPolicyService", func() {
+		Method("Method", func() {
+			Security(JWTAuth)
+			Authorize("role:admin or payload.account_id == auth.account_id")
+			Authorize("not auth.suspended")
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String)
+			})
+		})
+	})
+}
+
+var InvalidPolicyDSL = func() {
+	Service("InvalidPolicyService", func() {
+		Method("Method", func() {
+			Authorize("role:admin and (")
+			Authorize("payload.missing == auth.id")
+			Payload(func() {
+				Attribute("account_id", String)
+			})
+		})
+		Method("NoPayload", func() {
+			Authorize("payload.account_id == auth.account_id")
+		})
+	})
+}
+
+var ValidAuditDSL = func() {
+	Service("ValidAuditService", func() {
+		Method("Method", func() {
+			Security(JWTAuth)
+			Audit(func() {
+				Actor("auth.sub")
+				Target("payload.id")
+				Target("auth.tenant")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+			})
+		})
+	})
+}
+
+var InvalidAuditDSL = func() {
+	Service("InvalidAuditService", func() {
+		Method("Method", func() {
+			Audit(func() {
+				Actor("sub")
+				Target("payload.missing")
+				Target("auth.")
+				Target("payload.user")
+			})
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("user", func() {
+					Attribute("password", String, func() {
+						Sensitive()
+					})
+				})
+			})
+		})
+		Method("NoPayload", func() {
+			Audit(func() {
+				Target("payload.id")
+			})
+		})
+	})
+}
+
+var InvalidSessionDSL = func() {
+	var Session = SessionSecurity("session")
+	Service("InvalidSessionService", func() {
+		Method("MissingSessionID", func() {
+			Security(Session)
+			Payload(func() {
+				Attribute("id", String)
+			})
+		})
+		Method("MissingScheme", func() {
+			Payload(func() {
+				SessionID("session_id", String)
+			})
+		})
+	})
+}
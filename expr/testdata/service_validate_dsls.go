@@ -65,3 +65,15 @@ var ServiceErrorDSL = func() {
 		Method("Method", func() {})
 	})
 }
+
+var JSONPolicyDSL = func() {
+	API("json-policy", func() {
+		JSONNaming(SnakeCase)
+		JSONInt64AsString(true)
+	})
+	Service("Default", func() {})
+	Service("Override", func() {
+		JSONNaming(CamelCase)
+		JSONOmitEmpty(false)
+	})
+}
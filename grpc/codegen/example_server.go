@@ -8,6 +8,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -49,8 +50,11 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			{Path: "net/url"},
 			{Path: "sync"},
 			codegen.GoaNamedImport("grpc", "goagrpc"),
+			codegen.GoaImport("middleware"),
+			codegen.GoaNamedImport("grpc/middleware", "grpcmdlwr"),
 			{Path: "goa.design/clue/debug"},
 			{Path: "goa.design/clue/log"},
+			{Path: "log/slog"},
 			{Path: "google.golang.org/grpc"},
 			{Path: "google.golang.org/grpc/reflection"},
 		}
@@ -92,6 +96,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 	)
 	{
 		var svcdata []*ServiceData
+		slog := service.ExampleSlog(root)
 		for _, svc := range svr.Services {
 			if data := GRPCServices.Get(svc); data != nil {
 				svcdata = append(svcdata, data)
@@ -116,6 +121,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Source: readTemplate("server_grpc_register"),
 				Data: map[string]any{
 					"Services": svcdata,
+					"Slog":     slog,
 				},
 				FuncMap: map[string]any{
 					"goify":      codegen.Goify,
@@ -126,6 +132,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Source: readTemplate("server_grpc_end"),
 				Data: map[string]any{
 					"Services": svcdata,
+					"Slog":     slog,
 				},
 			},
 		}
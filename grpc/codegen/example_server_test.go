@@ -46,6 +46,7 @@ func TestExampleServerFiles(t *testing.T) {
 		{"no-server", ctestdata.NoServerDSL},
 		{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL},
 		{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL},
+		{"slog-logger", ctestdata.SlogLoggerDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -70,6 +70,7 @@ func TestMessageDefSection(t *testing.T) {
 		{"primitive", testdata.MessagePrimitiveDSL, testdata.MessagePrimitiveCode},
 		{"with-metadata", testdata.MessageWithMetadataDSL, testdata.MessageWithMetadataCode},
 		{"with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.MessageWithSecurityAttrsCode},
+		{"with-nested-write-only", testdata.MessageWithNestedWriteOnlyDSL, testdata.MessageWithNestedWriteOnlyCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -350,9 +350,9 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					if ta.proto {
 						nativeTypeName = protoBufNativeGoTypeName(tgtc.Type)
 					}
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", nativeTypeName, tdef, tgtVar)
+					code += fmt.Sprintf("var tmp %s = %s\n\t%s = &tmp\n", nativeTypeName, codegen.GoDefaultLiteral(tgtc, tdef), tgtVar)
 				} else {
-					code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
+					code += fmt.Sprintf("%s = %s\n", tgtVar, codegen.GoDefaultLiteral(tgtc, tdef))
 				}
 				code += "}\n"
 			case expr.IsPrimitive(srcc.Type) && srcMatt.HasDefaultValue(n) && ta.SourceCtx.UseDefault:
@@ -371,7 +371,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 						code += fmt.Sprintf("var zero %s\n\t", codegen.GoNativeTypeName(tgtc.Type))
 					}
 				}
-				code += fmt.Sprintf("if %s == zero {\n\t%s = %#v\n}\n", tgtVar, tgtVar, tdef)
+				code += fmt.Sprintf("if %s == zero {\n\t%s = %s\n}\n", tgtVar, tgtVar, codegen.GoDefaultLiteral(tgtc, tdef))
 				code += "}\n"
 			}
 		}
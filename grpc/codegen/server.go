@@ -51,9 +51,10 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server", "server", imports),
 			{
-				Name:   "server-struct",
-				Source: readTemplate("server_struct_type"),
-				Data:   data,
+				Name:    "server-struct",
+				Source:  readTemplate("server_struct_type"),
+				Data:    data,
+				FuncMap: map[string]any{"hooksTypeArgs": hooksTypeArgs},
 			},
 		}
 		for _, e := range data.Endpoints {
@@ -72,9 +73,10 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		})
 		for _, e := range data.Endpoints {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "grpc-handler-init",
-				Source: readTemplate("grpc_handler_init"),
-				Data:   e,
+				Name:    "grpc-handler-init",
+				Source:  readTemplate("grpc_handler_init"),
+				Data:    e,
+				FuncMap: map[string]any{"hooksTypeArgs": hooksTypeArgs},
 			})
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "server-grpc-interface",
@@ -200,3 +202,21 @@ func metadataEncodeDecodeData(md *MetadataData, vname string) map[string]any {
 		"VarName":  vname,
 	}
 }
+
+// hooksTypeArgs returns the type arguments of the goagrpc.ServerHooks invoked
+// by the handler of the given endpoint: the payload type and the type of the
+// value returned by the endpoint.
+func hooksTypeArgs(e *EndpointData) string {
+	payload, result := "any", "any"
+	if e.PayloadRef != "" {
+		payload = e.PayloadRef
+	}
+	switch {
+	case e.ServerStream != nil:
+	case e.ViewedResultRef != "":
+		result = e.ViewedResultRef
+	case e.ResultRef != "":
+		result = e.ResultRef
+	}
+	return payload + ", " + result
+}
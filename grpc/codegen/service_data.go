@@ -37,6 +37,9 @@ type (
 		Messages []*service.UserTypeData
 		// ServerStruct is the name of the gRPC server struct.
 		ServerStruct string
+		// HooksStruct is the name of the struct listing the server
+		// lifecycle hooks.
+		HooksStruct string
 		// ClientStruct is the name of the gRPC client struct,
 		ClientStruct string
 		// ServerInit is the name of the constructor of the server struct.
@@ -444,6 +447,7 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			Description:         svc.Description,
 			PkgName:             pkg,
 			ServerStruct:        "Server",
+			HooksStruct:         "Hooks",
 			ClientStruct:        "Client",
 			ServerInit:          "New",
 			ClientInit:          "NewClient",
@@ -610,6 +614,10 @@ func (ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 		{
 			for _, req := range e.Requirements {
 				for _, sch := range req.Schemes {
+					if sch.Kind == expr.MutualTLSKind {
+						// credentials come from the TLS handshake
+						continue
+					}
 					s := md.Requirements.Scheme(sch.SchemeName).Dup()
 					s.In = sch.In
 					switch s.In {
@@ -22,7 +22,7 @@ func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 				{{- end }}
 			{{- end }}
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				return nil, goagrpc.NewServiceError(message).WithDetails(goagrpc.DecodeErrorDetails(err)).WithViolations(goagrpc.DecodeViolations(err)...)
 			default:
 				return nil, goa.Fault(err.Error())
 			}
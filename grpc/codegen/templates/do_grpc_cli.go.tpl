@@ -1,5 +1,13 @@
 func doGRPC(_, host string, _ int, _ bool) (goa.Endpoint, any, error) {
+{{- if .MutualTLS }}
+	creds := insecure.NewCredentials()
+	if clientTLSConfig != nil {
+		creds = credentials.NewTLS(clientTLSConfig)
+	}
+	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(creds))
+{{- else }}
 	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+{{- end }}
 	if err != nil {
     fmt.Fprintf(os.Stderr, "could not connect to gRPC server at %s: %v\n", host, err)
   }
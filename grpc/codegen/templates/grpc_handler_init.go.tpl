@@ -1,7 +1,12 @@
 {{ printf "New%sHandler creates a gRPC handler which serves the %q service %q endpoint." .Method.VarName .ServiceName .Method.Name | comment }}
 func New{{ .Method.VarName }}Handler(endpoint goa.Endpoint, h goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler) goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler {
+	return New{{ .Method.VarName }}HandlerWithHooks(endpoint, h, nil)
+}
+
+{{ printf "New%sHandlerWithHooks is like New%sHandler but the handler it creates if h is nil also invokes the given lifecycle hooks. hooks may be nil." .Method.VarName .Method.VarName | comment }}
+func New{{ .Method.VarName }}HandlerWithHooks(endpoint goa.Endpoint, h goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler, hooks *goagrpc.ServerHooks[{{ hooksTypeArgs . }}]) goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler {
 	if h == nil {
-		h = goagrpc.New{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler(endpoint, {{ if .Method.Payload }}Decode{{ .Method.VarName }}Request{{ else }}nil{{ end }}{{ if not .ServerStream }}, Encode{{ .Method.VarName }}Response{{ end }})
+		h = goagrpc.New{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}HandlerWithHooks(endpoint, {{ if .Method.Payload }}Decode{{ .Method.VarName }}Request{{ else }}nil{{ end }}{{ if not .ServerStream }}, Encode{{ .Method.VarName }}Response{{ end }}, hooks)
 	}
 	return h
 }
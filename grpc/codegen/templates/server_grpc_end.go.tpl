@@ -12,12 +12,20 @@
 			if lis == nil {
 				errc <- fmt.Errorf("failed to listen on %q", u.Host)
 			}
+		{{- if .Slog }}
+			slog.InfoContext(ctx, "gRPC server listening", "host", u.Host)
+		{{- else }}
 			log.Printf(ctx, "gRPC server listening on %q", u.Host)
+		{{- end }}
 			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
+	{{- if .Slog }}
+		slog.InfoContext(ctx, "shutting down gRPC server", "host", u.Host)
+	{{- else }}
 		log.Printf(ctx, "shutting down gRPC server at %q", u.Host)
+	{{- end }}
 		srv.Stop()
   }()
 }
@@ -2,15 +2,16 @@
 func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 	{{- if not .ServerStream }}ctx context.Context, {{ end }}
 	{{- if not .Method.StreamingPayload }}message {{ .Request.Message.Ref }}{{ if .ServerStream }}, {{ end }}{{ end }}
-	{{- if .ServerStream }}stream {{ .ServerStream.Interface }}{{ end }}) {{ if .ServerStream }}error{{ else if .Response.Message }}({{ .Response.Message.Ref }},	error{{ if .Response.Message }}){{ end }}{{ end }} {
+	{{- if .ServerStream }}stream {{ .ServerStream.Interface }}{{ end }}) {{ if .ServerStream }}(err error){{ else if .Response.Message }}(resp {{ .Response.Message.Ref }}, err error){{ end }} {
 {{- if .ServerStream }}
 	ctx := stream.Context()
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	defer func() { s.hooks.{{ .Method.VarName }}.ResponseWritten(ctx, err) }()
 
 {{- if .ServerStream }}
-	{{if .PayloadRef }}p{{ else }}_{{ end }}, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
+	{{if .PayloadRef }}p, err :={{ else }}_, err ={{ end }} s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
 	{{- template "handle_error" . }}
 	ep := &{{ .ServicePkgName }}.{{ .Method.VarName }}EndpointInput{
 		Stream: &{{ .ServerStream.VarName }}{stream: stream},
@@ -19,11 +20,12 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 	{{- end }}
 	}
 	err = s.{{ .Method.VarName }}H.Handle(ctx, ep)
+	s.hooks.{{ .Method.VarName }}.EndpointResult(ctx, {{ if .PayloadRef }}ep.Payload{{ else }}nil{{ end }}, nil, err)
 {{- else }}
-	resp, err := s.{{ .Method.VarName }}H.Handle(ctx, message)
+	res, err := s.{{ .Method.VarName }}H.Handle(ctx, message)
 {{- end }}
 	{{- template "handle_error" . }}
-	return {{ if not $.ServerStream }}resp.({{ .Response.ServerConvert.TgtRef }}), {{ end }}nil
+	return {{ if not $.ServerStream }}res.({{ .Response.ServerConvert.TgtRef }}), {{ end }}nil
 }
 
 {{- define "handle_error" }}
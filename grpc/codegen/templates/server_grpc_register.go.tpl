@@ -1,19 +1,35 @@
-
-	// Create interceptor which sets up the logger in each request context.
-	chain := grpc.ChainUnaryInterceptor(log.UnaryServerInterceptor(ctx))
-	if dbg {
-		// Log request and response content if debug logs are enabled.
-		chain = grpc.ChainUnaryInterceptor(log.UnaryServerInterceptor(ctx), debug.UnaryServerInterceptor())
+{{- if .Slog }}
+	// Create interceptors which log the requests.
+	unary := []grpc.UnaryServerInterceptor{grpcmdlwr.UnaryServerSlog(slog.Default())}
+	{{- if needStream .Services }}
+	stream := []grpc.StreamServerInterceptor{grpcmdlwr.StreamServerSlog(slog.Default())}
+	{{- end }}
+{{- else }}
+	// Create interceptors which set up the logger in each request context.
+	unary := []grpc.UnaryServerInterceptor{log.UnaryServerInterceptor(ctx)}
+	{{- if needStream .Services }}
+	stream := []grpc.StreamServerInterceptor{log.StreamServerInterceptor(ctx)}
+	{{- end }}
+{{- end }}
+	if metrics != nil {
+		// Record the request metrics if enabled.
+		unary = append(unary, grpcmdlwr.UnaryServerMetrics(metrics))
+	{{- if needStream .Services }}
+		stream = append(stream, grpcmdlwr.StreamServerMetrics(metrics))
+	{{- end }}
 	}
-	{{- if needStream .Services}}
-	streamchain := grpc.ChainStreamInterceptor(log.StreamServerInterceptor(ctx))
+{{- if not .Slog }}
 	if dbg {
-		streamchain = grpc.ChainStreamInterceptor(log.StreamServerInterceptor(ctx), debug.StreamServerInterceptor())
-	}
+		// Log request and response content if debug logs are enabled.
+		unary = append(unary, debug.UnaryServerInterceptor())
+	{{- if needStream .Services }}
+		stream = append(stream, debug.StreamServerInterceptor())
 	{{- end }}
+	}
+{{- end }}
 
 	// Initialize gRPC server
-	srv := grpc.NewServer(chain{{ if needStream .Sevices }}, streamchain{{ end }})
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...){{ if needStream .Services }}, grpc.ChainStreamInterceptor(stream...){{ end }})
 
 	// Register the servers.
 	{{- range .Services }}
@@ -22,7 +38,11 @@
 
 	for svc, info := range srv.GetServiceInfo() {
 		for _, m := range info.Methods {
+		{{- if .Slog }}
+			slog.InfoContext(ctx, "serving gRPC method", "method", svc + "/" + m.Name)
+		{{- else }}
 			log.Printf(ctx, "serving gRPC method %s", svc + "/" + m.Name)
+		{{- end }}
 		}
 	}
 
@@ -1,8 +1,15 @@
-{{ printf "%s instantiates the server struct with the %s service endpoints." .ServerInit .Service.Name | comment }}
+{{ printf "%s instantiates the server struct with the %s service endpoints. Use SetHooks to register lifecycle hooks, the handlers given as argument only invoke the OnResponseWritten hooks and the OnEndpointResult hooks of the streaming methods." .ServerInit .Service.Name | comment }}
 func {{ .ServerInit }}(e *{{ .Service.PkgName }}.Endpoints{{ if .HasUnaryEndpoint }}, uh goagrpc.UnaryHandler{{ end }}{{ if .HasStreamingEndpoint }}, sh goagrpc.StreamHandler{{ end }}) *{{ .ServerStruct }} {
+	hooks := &{{ .HooksStruct }}{}
 	return &{{ .ServerStruct }}{
 	{{- range .Endpoints }}
-		{{ .Method.VarName }}H: New{{ .Method.VarName }}Handler(e.{{ .Method.VarName }}{{ if .ServerStream }}, sh{{ else }}, uh{{ end }}),
+		{{ .Method.VarName }}H: New{{ .Method.VarName }}HandlerWithHooks(e.{{ .Method.VarName }}{{ if .ServerStream }}, sh{{ else }}, uh{{ end }}, &hooks.{{ .Method.VarName }}),
 	{{- end }}
+		hooks: hooks,
 	}
 }
+
+{{ printf "SetHooks sets the lifecycle hooks invoked by the %s service handlers. It must be called before the server starts handling requests." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) SetHooks(hooks {{ .HooksStruct }}) {
+	*s.hooks = hooks
+}
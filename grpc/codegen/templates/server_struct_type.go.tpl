@@ -3,5 +3,13 @@ type {{ .ServerStruct }} struct {
 {{- range .Endpoints }}
 	{{ .Method.VarName }}H {{ if .ServerStream }}goagrpc.StreamHandler{{ else }}goagrpc.UnaryHandler{{ end }}
 {{- end }}
+	hooks *{{ .HooksStruct }}
 	{{ .PkgName }}.Unimplemented{{ .ServerInterface }}
 }
+
+{{ printf "%s lists the lifecycle hooks of the %s service endpoint gRPC handlers, see goagrpc.ServerHooks." .HooksStruct .Service.Name | comment }}
+type {{ .HooksStruct }} struct {
+{{- range .Endpoints }}
+	{{ .Method.VarName }} goagrpc.ServerHooks[{{ hooksTypeArgs . }}]
+{{- end }}
+}
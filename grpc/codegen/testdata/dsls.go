@@ -812,6 +812,30 @@ var MessageWithSecurityAttrsDSL = func() {
 	})
 }
 
+var MessageWithNestedWriteOnlyDSL = func() {
+	var Account = Type("Account", func() {
+		Field(1, "name", String)
+		Field(2, "password", String, func() {
+			WriteOnly()
+		})
+		Field(3, "id", String, func() {
+			ReadOnly()
+		})
+	})
+	Service("ServiceMessageWithNestedWriteOnly", func() {
+		Method("MethodMessageWithNestedWriteOnly", func() {
+			Payload(func() {
+				Field(1, "account", Account)
+			})
+			Result(func() {
+				Field(1, "account", Account)
+				Field(2, "accounts", ArrayOf(Account))
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
 var MessageWithServiceNameDSL = func() {
 	var UT = Type("MyNameConflicts", func() {
 		Field(1, "BooleanField", Boolean)
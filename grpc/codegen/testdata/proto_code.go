@@ -354,6 +354,27 @@ message MethodMessageWithSecurityResponse {
 }
 `
 
+const MessageWithNestedWriteOnlyCode = `
+message MethodMessageWithNestedWriteOnlyRequest {
+	AccountInput account = 1;
+}
+
+message AccountInput {
+	optional string name = 1;
+	optional string password = 2;
+}
+
+message MethodMessageWithNestedWriteOnlyResponse {
+	AccountOutput account = 1;
+	repeated AccountOutput accounts = 2;
+}
+
+message AccountOutput {
+	optional string name = 1;
+	optional string id = 3;
+}
+`
+
 const MethodWithReservedNameProtoCode = `
 syntax = "proto3";
 
@@ -4,8 +4,15 @@ const (
 	UnaryRPCsServerHandlerInitCode = `// NewMethodUnaryRPCAHandler creates a gRPC handler which serves the
 // "ServiceUnaryRPCs" service "MethodUnaryRPCA" endpoint.
 func NewMethodUnaryRPCAHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) goagrpc.UnaryHandler {
+	return NewMethodUnaryRPCAHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodUnaryRPCAHandlerWithHooks is like NewMethodUnaryRPCAHandler but the
+// handler it creates if h is nil also invokes the given lifecycle hooks. hooks
+// may be nil.
+func NewMethodUnaryRPCAHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.UnaryHandler, hooks *goagrpc.ServerHooks[*serviceunaryrpcs.PayloadA, *serviceunaryrpcsviews.ResultT]) goagrpc.UnaryHandler {
 	if h == nil {
-		h = goagrpc.NewUnaryHandler(endpoint, DecodeMethodUnaryRPCARequest, EncodeMethodUnaryRPCAResponse)
+		h = goagrpc.NewUnaryHandlerWithHooks(endpoint, DecodeMethodUnaryRPCARequest, EncodeMethodUnaryRPCAResponse, hooks)
 	}
 	return h
 }
@@ -13,8 +20,15 @@ func NewMethodUnaryRPCAHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) go
 // NewMethodUnaryRPCBHandler creates a gRPC handler which serves the
 // "ServiceUnaryRPCs" service "MethodUnaryRPCB" endpoint.
 func NewMethodUnaryRPCBHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) goagrpc.UnaryHandler {
+	return NewMethodUnaryRPCBHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodUnaryRPCBHandlerWithHooks is like NewMethodUnaryRPCBHandler but the
+// handler it creates if h is nil also invokes the given lifecycle hooks. hooks
+// may be nil.
+func NewMethodUnaryRPCBHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.UnaryHandler, hooks *goagrpc.ServerHooks[*serviceunaryrpcs.PayloadB, *serviceunaryrpcsviews.ResultT]) goagrpc.UnaryHandler {
 	if h == nil {
-		h = goagrpc.NewUnaryHandler(endpoint, DecodeMethodUnaryRPCBRequest, EncodeMethodUnaryRPCBResponse)
+		h = goagrpc.NewUnaryHandlerWithHooks(endpoint, DecodeMethodUnaryRPCBRequest, EncodeMethodUnaryRPCBResponse, hooks)
 	}
 	return h
 }
@@ -23,8 +37,15 @@ func NewMethodUnaryRPCBHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) go
 	UnaryRPCNoPayloadServerHandlerInitCode = `// NewMethodUnaryRPCNoPayloadHandler creates a gRPC handler which serves the
 // "ServiceUnaryRPCNoPayload" service "MethodUnaryRPCNoPayload" endpoint.
 func NewMethodUnaryRPCNoPayloadHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) goagrpc.UnaryHandler {
+	return NewMethodUnaryRPCNoPayloadHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodUnaryRPCNoPayloadHandlerWithHooks is like
+// NewMethodUnaryRPCNoPayloadHandler but the handler it creates if h is nil
+// also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodUnaryRPCNoPayloadHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.UnaryHandler, hooks *goagrpc.ServerHooks[any, string]) goagrpc.UnaryHandler {
 	if h == nil {
-		h = goagrpc.NewUnaryHandler(endpoint, nil, EncodeMethodUnaryRPCNoPayloadResponse)
+		h = goagrpc.NewUnaryHandlerWithHooks(endpoint, nil, EncodeMethodUnaryRPCNoPayloadResponse, hooks)
 	}
 	return h
 }
@@ -33,8 +54,15 @@ func NewMethodUnaryRPCNoPayloadHandler(endpoint goa.Endpoint, h goagrpc.UnaryHan
 	UnaryRPCNoResultServerHandlerInitCode = `// NewMethodUnaryRPCNoResultHandler creates a gRPC handler which serves the
 // "ServiceUnaryRPCNoResult" service "MethodUnaryRPCNoResult" endpoint.
 func NewMethodUnaryRPCNoResultHandler(endpoint goa.Endpoint, h goagrpc.UnaryHandler) goagrpc.UnaryHandler {
+	return NewMethodUnaryRPCNoResultHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodUnaryRPCNoResultHandlerWithHooks is like
+// NewMethodUnaryRPCNoResultHandler but the handler it creates if h is nil also
+// invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodUnaryRPCNoResultHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.UnaryHandler, hooks *goagrpc.ServerHooks[[]string, any]) goagrpc.UnaryHandler {
 	if h == nil {
-		h = goagrpc.NewUnaryHandler(endpoint, DecodeMethodUnaryRPCNoResultRequest, EncodeMethodUnaryRPCNoResultResponse)
+		h = goagrpc.NewUnaryHandlerWithHooks(endpoint, DecodeMethodUnaryRPCNoResultRequest, EncodeMethodUnaryRPCNoResultResponse, hooks)
 	}
 	return h
 }
@@ -43,8 +71,15 @@ func NewMethodUnaryRPCNoResultHandler(endpoint goa.Endpoint, h goagrpc.UnaryHand
 	ServerStreamingRPCServerHandlerInitCode = `// NewMethodServerStreamingRPCHandler creates a gRPC handler which serves the
 // "ServiceServerStreamingRPC" service "MethodServerStreamingRPC" endpoint.
 func NewMethodServerStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.StreamHandler) goagrpc.StreamHandler {
+	return NewMethodServerStreamingRPCHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodServerStreamingRPCHandlerWithHooks is like
+// NewMethodServerStreamingRPCHandler but the handler it creates if h is nil
+// also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodServerStreamingRPCHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.StreamHandler, hooks *goagrpc.ServerHooks[int, any]) goagrpc.StreamHandler {
 	if h == nil {
-		h = goagrpc.NewStreamHandler(endpoint, DecodeMethodServerStreamingRPCRequest)
+		h = goagrpc.NewStreamHandlerWithHooks(endpoint, DecodeMethodServerStreamingRPCRequest, hooks)
 	}
 	return h
 }
@@ -53,8 +88,15 @@ func NewMethodServerStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.StreamH
 	ClientStreamingRPCServerHandlerInitCode = `// NewMethodClientStreamingRPCHandler creates a gRPC handler which serves the
 // "ServiceClientStreamingRPC" service "MethodClientStreamingRPC" endpoint.
 func NewMethodClientStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.StreamHandler) goagrpc.StreamHandler {
+	return NewMethodClientStreamingRPCHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodClientStreamingRPCHandlerWithHooks is like
+// NewMethodClientStreamingRPCHandler but the handler it creates if h is nil
+// also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodClientStreamingRPCHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.StreamHandler, hooks *goagrpc.ServerHooks[any, any]) goagrpc.StreamHandler {
 	if h == nil {
-		h = goagrpc.NewStreamHandler(endpoint, nil)
+		h = goagrpc.NewStreamHandlerWithHooks(endpoint, nil, hooks)
 	}
 	return h
 }
@@ -64,8 +106,15 @@ func NewMethodClientStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.StreamH
 // serves the "ServiceClientStreamingRPCWithPayload" service
 // "MethodClientStreamingRPCWithPayload" endpoint.
 func NewMethodClientStreamingRPCWithPayloadHandler(endpoint goa.Endpoint, h goagrpc.StreamHandler) goagrpc.StreamHandler {
+	return NewMethodClientStreamingRPCWithPayloadHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodClientStreamingRPCWithPayloadHandlerWithHooks is like
+// NewMethodClientStreamingRPCWithPayloadHandler but the handler it creates if
+// h is nil also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodClientStreamingRPCWithPayloadHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.StreamHandler, hooks *goagrpc.ServerHooks[int, any]) goagrpc.StreamHandler {
 	if h == nil {
-		h = goagrpc.NewStreamHandler(endpoint, DecodeMethodClientStreamingRPCWithPayloadRequest)
+		h = goagrpc.NewStreamHandlerWithHooks(endpoint, DecodeMethodClientStreamingRPCWithPayloadRequest, hooks)
 	}
 	return h
 }
@@ -75,8 +124,15 @@ func NewMethodClientStreamingRPCWithPayloadHandler(endpoint goa.Endpoint, h goag
 // serves the "ServiceBidirectionalStreamingRPC" service
 // "MethodBidirectionalStreamingRPC" endpoint.
 func NewMethodBidirectionalStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.StreamHandler) goagrpc.StreamHandler {
+	return NewMethodBidirectionalStreamingRPCHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodBidirectionalStreamingRPCHandlerWithHooks is like
+// NewMethodBidirectionalStreamingRPCHandler but the handler it creates if h is
+// nil also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodBidirectionalStreamingRPCHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.StreamHandler, hooks *goagrpc.ServerHooks[any, any]) goagrpc.StreamHandler {
 	if h == nil {
-		h = goagrpc.NewStreamHandler(endpoint, nil)
+		h = goagrpc.NewStreamHandlerWithHooks(endpoint, nil, hooks)
 	}
 	return h
 }
@@ -86,8 +142,15 @@ func NewMethodBidirectionalStreamingRPCHandler(endpoint goa.Endpoint, h goagrpc.
 // which serves the "ServiceBidirectionalStreamingRPCWithPayload" service
 // "MethodBidirectionalStreamingRPCWithPayload" endpoint.
 func NewMethodBidirectionalStreamingRPCWithPayloadHandler(endpoint goa.Endpoint, h goagrpc.StreamHandler) goagrpc.StreamHandler {
+	return NewMethodBidirectionalStreamingRPCWithPayloadHandlerWithHooks(endpoint, h, nil)
+}
+
+// NewMethodBidirectionalStreamingRPCWithPayloadHandlerWithHooks is like
+// NewMethodBidirectionalStreamingRPCWithPayloadHandler but the handler it
+// creates if h is nil also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodBidirectionalStreamingRPCWithPayloadHandlerWithHooks(endpoint goa.Endpoint, h goagrpc.StreamHandler, hooks *goagrpc.ServerHooks[*servicebidirectionalstreamingrpcwithpayload.Payload, any]) goagrpc.StreamHandler {
 	if h == nil {
-		h = goagrpc.NewStreamHandler(endpoint, DecodeMethodBidirectionalStreamingRPCWithPayloadRequest)
+		h = goagrpc.NewStreamHandlerWithHooks(endpoint, DecodeMethodBidirectionalStreamingRPCWithPayloadRequest, hooks)
 	}
 	return h
 }
@@ -2,61 +2,66 @@ package testdata
 
 const UnaryRPCsServerInterfaceCode = `// MethodUnaryRPCA implements the "MethodUnaryRPCA" method in
 // service_unary_rp_cspb.ServiceUnaryRPCsServer interface.
-func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCARequest) (*service_unary_rp_cspb.MethodUnaryRPCAResponse, error) {
+func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCARequest) (resp *service_unary_rp_cspb.MethodUnaryRPCAResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCA")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
-	resp, err := s.MethodUnaryRPCAH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCA.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCAH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rp_cspb.MethodUnaryRPCAResponse), nil
+	return res.(*service_unary_rp_cspb.MethodUnaryRPCAResponse), nil
 }
 
 // MethodUnaryRPCB implements the "MethodUnaryRPCB" method in
 // service_unary_rp_cspb.ServiceUnaryRPCsServer interface.
-func (s *Server) MethodUnaryRPCB(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCBRequest) (*service_unary_rp_cspb.MethodUnaryRPCBResponse, error) {
+func (s *Server) MethodUnaryRPCB(ctx context.Context, message *service_unary_rp_cspb.MethodUnaryRPCBRequest) (resp *service_unary_rp_cspb.MethodUnaryRPCBResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCB")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
-	resp, err := s.MethodUnaryRPCBH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCB.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCBH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rp_cspb.MethodUnaryRPCBResponse), nil
+	return res.(*service_unary_rp_cspb.MethodUnaryRPCBResponse), nil
 }
 `
 
 const UnaryRPCNoPayloadServerInterfaceCode = `// MethodUnaryRPCNoPayload implements the "MethodUnaryRPCNoPayload" method in
 // service_unary_rpc_no_payloadpb.ServiceUnaryRPCNoPayloadServer interface.
-func (s *Server) MethodUnaryRPCNoPayload(ctx context.Context, message *service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadRequest) (*service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse, error) {
+func (s *Server) MethodUnaryRPCNoPayload(ctx context.Context, message *service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadRequest) (resp *service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoPayload")
-	resp, err := s.MethodUnaryRPCNoPayloadH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCNoPayload.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCNoPayloadH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse), nil
+	return res.(*service_unary_rpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse), nil
 }
 `
 
 const UnaryRPCNoResultServerInterfaceCode = `// MethodUnaryRPCNoResult implements the "MethodUnaryRPCNoResult" method in
 // service_unary_rpc_no_resultpb.ServiceUnaryRPCNoResultServer interface.
-func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultRequest) (*service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse, error) {
+func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultRequest) (resp *service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCNoResult")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoResult")
-	resp, err := s.MethodUnaryRPCNoResultH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCNoResult.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCNoResultH.Handle(ctx, message)
 	if err != nil {
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse), nil
+	return res.(*service_unary_rpc_no_resultpb.MethodUnaryRPCNoResultResponse), nil
 }
 `
 
 const UnaryRPCWithErrorsServerInterfaceCode = `// MethodUnaryRPCWithErrors implements the "MethodUnaryRPCWithErrors" method in
 // service_unary_rpc_with_errorspb.ServiceUnaryRPCWithErrorsServer interface.
-func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsRequest) (*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse, error) {
+func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsRequest) (resp *service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithErrors")
-	resp, err := s.MethodUnaryRPCWithErrorsH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCWithErrors.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCWithErrorsH.Handle(ctx, message)
 	if err != nil {
 		var en goa.GoaErrorNamer
 		if errors.As(err, &en) {
@@ -79,7 +84,7 @@ func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_
 		}
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse), nil
+	return res.(*service_unary_rpc_with_errorspb.MethodUnaryRPCWithErrorsResponse), nil
 }
 `
 
@@ -87,10 +92,11 @@ const UnaryRPCWithOverridingErrorsServerInterfaceCode = `// MethodUnaryRPCWithOv
 // "MethodUnaryRPCWithOverridingErrors" method in
 // service_unary_rpc_with_overriding_errorspb.ServiceUnaryRPCWithOverridingErrorsServer
 // interface.
-func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message *service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsRequest) (*service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse, error) {
+func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message *service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsRequest) (resp *service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse, err error) {
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithOverridingErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithOverridingErrors")
-	resp, err := s.MethodUnaryRPCWithOverridingErrorsH.Handle(ctx, message)
+	defer func() { s.hooks.MethodUnaryRPCWithOverridingErrors.ResponseWritten(ctx, err) }()
+	res, err := s.MethodUnaryRPCWithOverridingErrorsH.Handle(ctx, message)
 	if err != nil {
 		var en goa.GoaErrorNamer
 		if errors.As(err, &en) {
@@ -103,16 +109,17 @@ func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message
 		}
 		return nil, goagrpc.EncodeError(err)
 	}
-	return resp.(*service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse), nil
+	return res.(*service_unary_rpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse), nil
 }
 `
 
 const ServerStreamingRPCServerInterfaceCode = `// MethodServerStreamingRPC implements the "MethodServerStreamingRPC" method in
 // service_server_streaming_rpcpb.ServiceServerStreamingRPCServer interface.
-func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcpb.MethodServerStreamingRPCRequest, stream service_server_streaming_rpcpb.ServiceServerStreamingRPC_MethodServerStreamingRPCServer) error {
+func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcpb.MethodServerStreamingRPCRequest, stream service_server_streaming_rpcpb.ServiceServerStreamingRPC_MethodServerStreamingRPCServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodServerStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceServerStreamingRPC")
+	defer func() { s.hooks.MethodServerStreamingRPC.ResponseWritten(ctx, err) }()
 	p, err := s.MethodServerStreamingRPCH.Decode(ctx, message)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -122,6 +129,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcp
 		Payload: p.(int),
 	}
 	err = s.MethodServerStreamingRPCH.Handle(ctx, ep)
+	s.hooks.MethodServerStreamingRPC.EndpointResult(ctx, ep.Payload, nil, err)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -131,11 +139,12 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streaming_rpcp
 
 const ClientStreamingRPCServerInterfaceCode = `// MethodClientStreamingRPC implements the "MethodClientStreamingRPC" method in
 // service_client_streaming_rpcpb.ServiceClientStreamingRPCServer interface.
-func (s *Server) MethodClientStreamingRPC(stream service_client_streaming_rpcpb.ServiceClientStreamingRPC_MethodClientStreamingRPCServer) error {
+func (s *Server) MethodClientStreamingRPC(stream service_client_streaming_rpcpb.ServiceClientStreamingRPC_MethodClientStreamingRPCServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPC")
-	_, err := s.MethodClientStreamingRPCH.Decode(ctx, nil)
+	defer func() { s.hooks.MethodClientStreamingRPC.ResponseWritten(ctx, err) }()
+	_, err = s.MethodClientStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -143,6 +152,7 @@ func (s *Server) MethodClientStreamingRPC(stream service_client_streaming_rpcpb.
 		Stream: &MethodClientStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodClientStreamingRPCH.Handle(ctx, ep)
+	s.hooks.MethodClientStreamingRPC.EndpointResult(ctx, nil, nil, err)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -154,10 +164,11 @@ const ClientStreamingRPCWithPayloadServerInterfaceCode = `// MethodClientStreami
 // "MethodClientStreamingRPCWithPayload" method in
 // service_client_streaming_rpc_with_payloadpb.ServiceClientStreamingRPCWithPayloadServer
 // interface.
-func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_streaming_rpc_with_payloadpb.ServiceClientStreamingRPCWithPayload_MethodClientStreamingRPCWithPayloadServer) error {
+func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_streaming_rpc_with_payloadpb.ServiceClientStreamingRPCWithPayload_MethodClientStreamingRPCWithPayloadServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodClientStreamingRPCWithPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPCWithPayload")
+	defer func() { s.hooks.MethodClientStreamingRPCWithPayload.ResponseWritten(ctx, err) }()
 	p, err := s.MethodClientStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -167,6 +178,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 		Payload: p.(int),
 	}
 	err = s.MethodClientStreamingRPCWithPayloadH.Handle(ctx, ep)
+	s.hooks.MethodClientStreamingRPCWithPayload.EndpointResult(ctx, ep.Payload, nil, err)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -178,11 +190,12 @@ const BidirectionalStreamingRPCServerInterfaceCode = `// MethodBidirectionalStre
 // "MethodBidirectionalStreamingRPC" method in
 // service_bidirectional_streaming_rpcpb.ServiceBidirectionalStreamingRPCServer
 // interface.
-func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_streaming_rpcpb.ServiceBidirectionalStreamingRPC_MethodBidirectionalStreamingRPCServer) error {
+func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_streaming_rpcpb.ServiceBidirectionalStreamingRPC_MethodBidirectionalStreamingRPCServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPC")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPC")
-	_, err := s.MethodBidirectionalStreamingRPCH.Decode(ctx, nil)
+	defer func() { s.hooks.MethodBidirectionalStreamingRPC.ResponseWritten(ctx, err) }()
+	_, err = s.MethodBidirectionalStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -190,6 +203,7 @@ func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_st
 		Stream: &MethodBidirectionalStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodBidirectionalStreamingRPCH.Handle(ctx, ep)
+	s.hooks.MethodBidirectionalStreamingRPC.EndpointResult(ctx, nil, nil, err)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -201,10 +215,11 @@ const BidirectionalStreamingRPCWithPayloadServerInterfaceCode = `// MethodBidire
 // "MethodBidirectionalStreamingRPCWithPayload" method in
 // service_bidirectional_streaming_rpc_with_payloadpb.ServiceBidirectionalStreamingRPCWithPayloadServer
 // interface.
-func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidirectional_streaming_rpc_with_payloadpb.ServiceBidirectionalStreamingRPCWithPayload_MethodBidirectionalStreamingRPCWithPayloadServer) error {
+func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidirectional_streaming_rpc_with_payloadpb.ServiceBidirectionalStreamingRPCWithPayload_MethodBidirectionalStreamingRPCWithPayloadServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithPayload")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithPayload")
+	defer func() { s.hooks.MethodBidirectionalStreamingRPCWithPayload.ResponseWritten(ctx, err) }()
 	p, err := s.MethodBidirectionalStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
 		return goagrpc.EncodeError(err)
@@ -214,6 +229,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 		Payload: p.(*servicebidirectionalstreamingrpcwithpayload.Payload),
 	}
 	err = s.MethodBidirectionalStreamingRPCWithPayloadH.Handle(ctx, ep)
+	s.hooks.MethodBidirectionalStreamingRPCWithPayload.EndpointResult(ctx, ep.Payload, nil, err)
 	if err != nil {
 		return goagrpc.EncodeError(err)
 	}
@@ -225,11 +241,12 @@ const BidirectionalStreamingRPCWithErrorsServerInterfaceCode = `// MethodBidirec
 // "MethodBidirectionalStreamingRPCWithErrors" method in
 // service_bidirectional_streaming_rpc_with_errorspb.ServiceBidirectionalStreamingRPCWithErrorsServer
 // interface.
-func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidirectional_streaming_rpc_with_errorspb.ServiceBidirectionalStreamingRPCWithErrors_MethodBidirectionalStreamingRPCWithErrorsServer) error {
+func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidirectional_streaming_rpc_with_errorspb.ServiceBidirectionalStreamingRPCWithErrors_MethodBidirectionalStreamingRPCWithErrorsServer) (err error) {
 	ctx := stream.Context()
 	ctx = context.WithValue(ctx, goa.MethodKey, "MethodBidirectionalStreamingRPCWithErrors")
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithErrors")
-	_, err := s.MethodBidirectionalStreamingRPCWithErrorsH.Decode(ctx, nil)
+	defer func() { s.hooks.MethodBidirectionalStreamingRPCWithErrors.ResponseWritten(ctx, err) }()
+	_, err = s.MethodBidirectionalStreamingRPCWithErrorsH.Decode(ctx, nil)
 	if err != nil {
 		var en goa.GoaErrorNamer
 		if errors.As(err, &en) {
@@ -248,6 +265,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 		Stream: &MethodBidirectionalStreamingRPCWithErrorsServerStream{stream: stream},
 	}
 	err = s.MethodBidirectionalStreamingRPCWithErrorsH.Handle(ctx, ep)
+	s.hooks.MethodBidirectionalStreamingRPCWithErrors.EndpointResult(ctx, nil, nil, err)
 	if err != nil {
 		var en goa.GoaErrorNamer
 		if errors.As(err, &en) {
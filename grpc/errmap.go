@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ErrorMapper maps errors that are not defined in the design to gRPC
+	// status codes and error names. Servers use the endpoint middleware
+	// returned by Endpoint to encode the errors that would otherwise be
+	// encoded as faults with code Unknown and clients use DecodeError to
+	// recover the mapped errors. An ErrorMapper must be configured before
+	// it is used, it is then safe for concurrent use.
+	//
+	// Unlike HTTP servers, gRPC servers have no error handler: the errors
+	// returned by the endpoints, mapped or not, are reported to the server
+	// interceptors which are thus where mapped errors get logged.
+	ErrorMapper struct {
+		// mappings lists the mappings in registration order.
+		mappings []*ErrorMapping
+	}
+
+	// ErrorMapping maps errors that are not defined in the design to a gRPC
+	// status code and error name, see ErrorMapper.
+	ErrorMapping struct {
+		// Name is the name of the error written in the error response.
+		Name string
+		// Code is the gRPC status code.
+		Code codes.Code
+		// Target is the error matched using errors.Is, nil if the
+		// mapping was registered with MapFunc or MapErrorAs. The
+		// client returns errors that wrap Target when receiving a
+		// response for a mapped error so that errors.Is may be used to
+		// test for it.
+		Target error
+
+		// match returns true if the mapping applies to the given error.
+		match func(error) bool
+	}
+)
+
+// NewErrorMapper returns an error mapper with no mapping.
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// Map adds a mapping that encodes errors matching target (as tested with
+// errors.Is) with the given status code and error name and returns the mapper
+// so that calls can be chained, e.g.:
+//
+//	mapper := goagrpc.NewErrorMapper().
+//	    Map(sql.ErrNoRows, codes.NotFound, "not_found").
+//	    Map(context.DeadlineExceeded, codes.DeadlineExceeded, "timeout")
+//
+// Mappings are consulted in registration order and only apply to errors
+// that are neither goa service errors nor gRPC status errors. Servers and
+// clients should use mappers configured with the same mappings.
+func (m *ErrorMapper) Map(target error, code codes.Code, name string) *ErrorMapper {
+	m.mappings = append(m.mappings, &ErrorMapping{
+		Name:   name,
+		Code:   code,
+		Target: target,
+		match:  func(err error) bool { return errors.Is(err, target) },
+	})
+	return m
+}
+
+// MapFunc adds a mapping that encodes errors for which match returns true
+// with the given status code and error name. See Map.
+func (m *ErrorMapper) MapFunc(match func(error) bool, code codes.Code, name string) *ErrorMapper {
+	m.mappings = append(m.mappings, &ErrorMapping{Name: name, Code: code, match: match})
+	return m
+}
+
+// MapErrorAs adds a mapping to m that encodes errors that have an error of
+// type T in their chain (as tested with errors.As) with the given status code
+// and error name. See ErrorMapper.Map.
+func MapErrorAs[T error](m *ErrorMapper, code codes.Code, name string) *ErrorMapper {
+	return m.MapFunc(func(err error) bool {
+		var t T
+		return errors.As(err, &t)
+	}, code, name)
+}
+
+// Lookup returns the first mapping that applies to err, nil if there isn't
+// one or if err is a goa service error or a gRPC status error.
+func (m *ErrorMapper) Lookup(err error) *ErrorMapping {
+	var gerr *goa.ServiceError
+	if errors.As(err, &gerr) {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil
+	}
+	for _, em := range m.mappings {
+		if em.match(err) {
+			return em
+		}
+	}
+	return nil
+}
+
+// Endpoint is an endpoint middleware that converts the mapped errors returned
+// by the endpoint into gRPC status errors with the mapping code. The status
+// details contain an error response named after the mapping. Errors that are
+// not mapped are returned as is, e.g.:
+//
+//	endpoints.Use(mapper.Endpoint)
+func (m *ErrorMapper) Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		res, err := e(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		em := m.Lookup(err)
+		if em == nil {
+			return res, err
+		}
+		return res, NewStatusError(em.Code, err, NewErrorResponse(goa.NewServiceError(err, em.Name, false, false, false)))
+	}
+}
+
+// DecodeError returns a ServiceError that wraps the target of the mapping
+// with the same name as err if err is a ServiceError returned by the client,
+// see NewServiceError. It returns err unchanged otherwise.
+func (m *ErrorMapper) DecodeError(err error) error {
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		return err
+	}
+	for _, em := range m.mappings {
+		if em.Name != gerr.Name || em.Target == nil {
+			continue
+		}
+		merr := goa.NewServiceError(em.Target, gerr.Name, gerr.Timeout, gerr.Temporary, gerr.Fault)
+		merr.ID = gerr.ID
+		merr.Message = gerr.Message
+		return merr.WithDetails(gerr.Details)
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goapb "goa.design/goa/v3/grpc/pb"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestErrorMapperEndpoint(t *testing.T) {
+	mapper := NewErrorMapper().Map(sql.ErrNoRows, codes.NotFound, "not_found")
+
+	cases := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantName string
+	}{
+		{"mapped", errors.Join(errors.New("lookup"), sql.ErrNoRows), codes.NotFound, "not_found"},
+		{"not mapped", errors.New("boom"), codes.Unknown, "fault"},
+		{"service error", goa.PermanentError("bad", "bad"), codes.Unknown, "bad"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := mapper.Endpoint(func(context.Context, any) (any, error) { return nil, c.err })
+
+			_, err := e(context.Background(), nil)
+
+			err = EncodeError(err)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, c.wantCode, st.Code())
+			resp, ok := DecodeError(err).(*goapb.ErrorResponse)
+			require.True(t, ok)
+			assert.Equal(t, c.wantName, resp.Name)
+		})
+	}
+}
+
+func TestErrorMapperDecodeError(t *testing.T) {
+	mapper := NewErrorMapper().Map(sql.ErrNoRows, codes.NotFound, "not_found")
+	e := mapper.Endpoint(func(context.Context, any) (any, error) { return nil, sql.ErrNoRows })
+	_, err := e(context.Background(), nil)
+	resp := DecodeError(EncodeError(err)).(*goapb.ErrorResponse)
+
+	err = mapper.DecodeError(NewServiceError(resp))
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	var gerr *goa.ServiceError
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, "not_found", gerr.Name)
+	assert.NotErrorIs(t, NewErrorMapper().DecodeError(NewServiceError(resp)), sql.ErrNoRows)
+}
@@ -1,15 +1,19 @@
 package grpc
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type (
@@ -78,9 +82,14 @@ func NewStatusError(code codes.Code, err error, details ...protoiface.MessageV1)
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
-// ServiceError or a gRPC status error it returns a gRPC status error with
-// Unknown code and Fault characteristic set.
+// Fault, and Temporary characteristics of the ServiceError and adds a
+// google.rpc.BadRequest message listing the field violations to the details
+// if the error is a validation error as well as a google.protobuf.Struct
+// message holding the error details if any. If error is not a ServiceError or a
+// gRPC status error it returns a gRPC status error with Unknown code and Fault
+// characteristic set, see ErrorMapper to encode such errors with other codes.
+// Errors that wrap a security.AuthError and no ServiceError are encoded as
+// "unauthorized" errors with the Unauthenticated code.
 func EncodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		if s, err := st.WithDetails(NewErrorResponse(err)); err == nil {
@@ -88,7 +97,13 @@ func EncodeError(err error) error {
 		}
 		return st.Err()
 	}
-	var gerr *goa.ServiceError
+	var (
+		gerr *goa.ServiceError
+		aerr *security.AuthError
+	)
+	if errors.As(err, &aerr) && !errors.As(err, &gerr) {
+		err = goa.NewServiceError(err, goa.Unauthorized, false, false, false)
+	}
 	if errors.As(err, &gerr) {
 		// goa service error type. Compute the status code from the service error
 		// characteristics and create a new detailed gRPC status error.
@@ -102,7 +117,22 @@ func EncodeError(err error) error {
 		if gerr.Temporary {
 			code = codes.Unavailable
 		}
-		return NewStatusError(code, err, NewErrorResponse(err))
+		if gerr.Name == goa.PermissionDenied {
+			code = codes.PermissionDenied
+		}
+		if gerr.Name == goa.Unauthorized {
+			code = codes.Unauthenticated
+		}
+		details := []protoiface.MessageV1{NewErrorResponse(err)}
+		if vs := gerr.Violations(); len(vs) > 0 {
+			details = append(details, NewBadRequest(vs))
+		}
+		if len(gerr.Details) > 0 {
+			if s, err := NewErrorDetails(gerr.Details); err == nil {
+				details = append(details, s)
+			}
+		}
+		return NewStatusError(code, err, details...)
 	}
 	// Return an unknown gRPC status error with fault characteristic set.
 	return NewStatusError(codes.Unknown, err, NewErrorResponse(err))
@@ -124,6 +154,79 @@ func DecodeError(err error) proto.Message {
 	return details[0].(proto.Message)
 }
 
+// NewBadRequest creates a google.rpc.BadRequest message listing the given
+// validation failures as field violations.
+func NewBadRequest(vs []*goa.Violation) *errdetails.BadRequest {
+	fvs := make([]*errdetails.BadRequest_FieldViolation, len(vs))
+	for i, v := range vs {
+		fvs[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Path,
+			Description: v.Message,
+		}
+	}
+	return &errdetails.BadRequest{FieldViolations: fvs}
+}
+
+// DecodeViolations returns the validation failures encoded in the
+// google.rpc.BadRequest message of the status details if error is a gRPC
+// status error. The violations returned by DecodeViolations do not define a
+// code or params as BadRequest does not carry them. It returns nil if the
+// error is not a gRPC status error or if the details do not contain a
+// BadRequest message.
+func DecodeViolations(err error) []*goa.Violation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		vs := make([]*goa.Violation, len(br.FieldViolations))
+		for i, fv := range br.FieldViolations {
+			vs[i] = &goa.Violation{
+				Path:    fv.Field,
+				Message: fv.Description,
+			}
+		}
+		return vs
+	}
+	return nil
+}
+
+// NewErrorDetails creates a google.protobuf.Struct message from the given
+// ServiceError details. The details values are first converted to their JSON
+// representation so that any JSON serializable value may be used.
+func NewErrorDetails(details map[string]any) (*structpb.Struct, error) {
+	b, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(m)
+}
+
+// DecodeErrorDetails returns the ServiceError details encoded in the
+// google.protobuf.Struct message of the status details if error is a gRPC
+// status error. It returns nil if the error is not a gRPC status error or if
+// the details do not contain a Struct message.
+func DecodeErrorDetails(err error) map[string]any {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		if s, ok := d.(*structpb.Struct); ok {
+			return s.AsMap()
+		}
+	}
+	return nil
+}
+
 // ErrInvalidType is the error returned when the wrong type is given to a
 // encoder or decoder.
 func ErrInvalidType(svc, m, expected string, actual any) error {
@@ -6,10 +6,13 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
 )
 
 // Inspired from https://github.com/go-kit/kit/blob/1c17eccf28596f5a2c59314f7923ca66301b90e4/transport/grpc/server.go
@@ -44,33 +47,52 @@ type (
 		endpoint goa.Endpoint
 		decoder  RequestDecoder
 		encoder  ResponseEncoder
+		hooks    handlerHooks
 	}
 
 	streamHandler struct {
 		endpoint goa.Endpoint
 		decoder  RequestDecoder
+		hooks    handlerHooks
 	}
 )
 
 // NewUnaryHandler returns a handler to handle unary gRPC endpoints.
 func NewUnaryHandler(e goa.Endpoint, dec RequestDecoder, enc ResponseEncoder) UnaryHandler {
+	return NewUnaryHandlerWithHooks[any, any](e, dec, enc, nil)
+}
+
+// NewUnaryHandlerWithHooks returns a handler to handle unary gRPC endpoints
+// which invokes the OnRequestDecoded, OnDecodeError, OnEndpointResult and
+// OnEncodeError hooks. hooks may be nil.
+func NewUnaryHandlerWithHooks[Payload, Result any](e goa.Endpoint, dec RequestDecoder, enc ResponseEncoder, hooks *ServerHooks[Payload, Result]) UnaryHandler {
 	return &unaryHandler{
 		endpoint: e,
 		decoder:  dec,
 		encoder:  enc,
+		hooks:    hooks,
 	}
 }
 
 // NewStreamHandler returns a handler to handle streaming gRPC endpoints.
 func NewStreamHandler(e goa.Endpoint, dec RequestDecoder) StreamHandler {
+	return NewStreamHandlerWithHooks[any, any](e, dec, nil)
+}
+
+// NewStreamHandlerWithHooks returns a handler to handle streaming gRPC
+// endpoints which invokes the OnRequestDecoded and OnDecodeError hooks. hooks
+// may be nil.
+func NewStreamHandlerWithHooks[Payload, Result any](e goa.Endpoint, dec RequestDecoder, hooks *ServerHooks[Payload, Result]) StreamHandler {
 	return &streamHandler{
 		endpoint: e,
 		decoder:  dec,
+		hooks:    hooks,
 	}
 }
 
 // Handle serves a gRPC request.
 func (h *unaryHandler) Handle(ctx context.Context, reqpb any) (any, error) {
+	ctx = withClientCertificates(ctx)
 	var (
 		req any
 		err error
@@ -80,12 +102,14 @@ func (h *unaryHandler) Handle(ctx context.Context, reqpb any) (any, error) {
 			// Decode gRPC request message and incoming metadata
 			md, _ := metadata.FromIncomingContext(ctx)
 			if req, err = h.decoder(ctx, reqpb, md); err != nil {
+				h.hooks.decodeError(ctx, err)
 				var e *goa.ServiceError
 				if errors.As(err, &e) {
-					return nil, err
+					return nil, goa.LocalizeError(ctx, err)
 				}
 				return nil, status.Error(codes.InvalidArgument, err.Error())
 			}
+			h.hooks.requestDecoded(ctx, req)
 		}
 	}
 
@@ -94,8 +118,10 @@ func (h *unaryHandler) Handle(ctx context.Context, reqpb any) (any, error) {
 	)
 	{
 		// Invoke goa endpoint
-		if resp, err = h.endpoint(ctx, req); err != nil {
-			return nil, err
+		resp, err = h.endpoint(ctx, req)
+		h.hooks.endpointResult(ctx, req, resp, err)
+		if err != nil {
+			return nil, goa.LocalizeError(ctx, err)
 		}
 	}
 
@@ -109,6 +135,7 @@ func (h *unaryHandler) Handle(ctx context.Context, reqpb any) (any, error) {
 		if h.encoder != nil {
 			// Encode gRPC response
 			if respb, err = h.encoder(ctx, resp, &hdr, &trlr); err != nil {
+				h.hooks.encodeError(ctx, err)
 				var e *goa.ServiceError
 				if errors.As(err, &e) {
 					return nil, err
@@ -145,12 +172,14 @@ func (h *streamHandler) Decode(ctx context.Context, reqpb any) (any, error) {
 		if h.decoder != nil {
 			md, _ := metadata.FromIncomingContext(ctx)
 			if req, err = h.decoder(ctx, reqpb, md); err != nil {
+				h.hooks.decodeError(ctx, err)
 				var e *goa.ServiceError
 				if errors.As(err, &e) {
-					return nil, err
+					return nil, goa.LocalizeError(ctx, err)
 				}
 				return nil, status.Error(codes.InvalidArgument, err.Error())
 			}
+			h.hooks.requestDecoded(ctx, req)
 		}
 	}
 	return req, nil
@@ -158,6 +187,25 @@ func (h *streamHandler) Decode(ctx context.Context, reqpb any) (any, error) {
 
 // Handle serves a gRPC request.
 func (h *streamHandler) Handle(ctx context.Context, stream any) error {
-	_, err := h.endpoint(ctx, stream)
-	return err
+	ctx = withClientCertificates(ctx)
+	if _, err := h.endpoint(ctx, stream); err != nil {
+		return goa.LocalizeError(ctx, err)
+	}
+	return nil
+}
+
+// withClientCertificates returns a copy of ctx that holds the client
+// certificate chain verified during the TLS handshake of the peer if any so
+// that endpoints secured with mutual TLS may retrieve it with
+// security.ContextClientCertificates.
+func withClientCertificates(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return ctx
+	}
+	return security.WithClientCertificates(ctx, info.State.VerifiedChains[0])
 }
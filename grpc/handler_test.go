@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestUnaryHandlerHooks(t *testing.T) {
+	var (
+		decodeErr = errors.New("bad request")
+		encodeErr = errors.New("encode")
+	)
+	cases := []struct {
+		name      string
+		decodeErr error
+		endpErr   error
+		encodeErr error
+		calls     []string
+		code      codes.Code
+	}{
+		{"success", nil, nil, nil, []string{"decoded p", "result p r <nil>"}, codes.OK},
+		{"decode error", decodeErr, nil, nil, []string{"decode error bad request"}, codes.InvalidArgument},
+		{"endpoint error", nil, goa.Fault("oops"), nil, []string{"decoded p", "result p  oops"}, codes.Internal},
+		{"encode error", nil, nil, encodeErr, []string{"decoded p", "result p r <nil>", "encode error encode"}, codes.Unknown},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls []string
+			hooks := testServerHooks(t, &calls)
+			dec := func(context.Context, any, metadata.MD) (any, error) {
+				if c.decodeErr != nil {
+					return nil, c.decodeErr
+				}
+				return "p", nil
+			}
+			endpoint := func(context.Context, any) (any, error) {
+				if c.endpErr != nil {
+					return nil, c.endpErr
+				}
+				return "r", nil
+			}
+			enc := func(_ context.Context, v any, _, _ *metadata.MD) (any, error) {
+				return v, c.encodeErr
+			}
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+
+			_, err := NewUnaryHandlerWithHooks(endpoint, dec, enc, hooks).Handle(ctx, nil)
+
+			if c.code == codes.OK {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			assert.Equal(t, c.calls, calls)
+		})
+	}
+}
+
+func TestStreamHandlerHooks(t *testing.T) {
+	var calls []string
+	hooks := testServerHooks(t, &calls)
+	dec := func(context.Context, any, metadata.MD) (any, error) { return "p", nil }
+	endpoint := func(context.Context, any) (any, error) { return nil, goa.Fault("oops") }
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+	h := NewStreamHandlerWithHooks(endpoint, dec, hooks)
+
+	p, err := h.Decode(ctx, nil)
+	require.NoError(t, err)
+	err = h.Handle(ctx, "s")
+	hooks.EndpointResult(ctx, p, nil, err)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"decoded p", "result p  oops"}, calls)
+}
+
+func TestServerHooksResponseWritten(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"mapped error", NewStatusError(codes.NotFound, goa.PermanentError("not_found", "missing")), codes.NotFound},
+		{"encoded error", EncodeError(goa.Fault("oops")), codes.Internal},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var calls []string
+			hooks := testServerHooks(t, &calls)
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+
+			hooks.ResponseWritten(ctx, c.err)
+
+			assert.Equal(t, []string{"written " + c.code.String()}, calls)
+		})
+	}
+}
+
+func TestHandlersNilHooks(t *testing.T) {
+	endpoint := func(context.Context, any) (any, error) { return "r", nil }
+	res, err := NewUnaryHandler(endpoint, nil, nil).Handle(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	assert.NoError(t, NewStreamHandler(endpoint, nil).Handle(context.Background(), nil))
+	var hooks *ServerHooks[any, any]
+	hooks.EndpointResult(context.Background(), nil, nil, nil)
+	hooks.ResponseWritten(context.Background(), nil)
+}
+
+// testServerHooks returns hooks that record the calls in calls.
+func testServerHooks(t *testing.T, calls *[]string) *ServerHooks[string, string] {
+	t.Helper()
+	check := func(service, method string) {
+		assert.Equal(t, "svc", service)
+		assert.Equal(t, "meth", method)
+	}
+	return &ServerHooks[string, string]{
+		OnRequestDecoded: func(_ context.Context, service, method string, payload string) {
+			check(service, method)
+			*calls = append(*calls, "decoded "+payload)
+		},
+		OnDecodeError: func(_ context.Context, service, method string, err error) {
+			check(service, method)
+			*calls = append(*calls, "decode error "+err.Error())
+		},
+		OnEndpointResult: func(_ context.Context, service, method string, payload, result string, err error) {
+			check(service, method)
+			*calls = append(*calls, fmt.Sprintf("result %v %v %v", payload, result, err))
+		},
+		OnEncodeError: func(_ context.Context, service, method string, err error) {
+			check(service, method)
+			*calls = append(*calls, "encode error "+err.Error())
+		},
+		OnResponseWritten: func(_ context.Context, service, method string, code codes.Code) {
+			check(service, method)
+			*calls = append(*calls, "written "+code.String())
+		},
+	}
+}
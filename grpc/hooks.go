@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ServerHooks lists callbacks invoked by a generated gRPC handler at the
+	// different stages of the request lifecycle. Payload and Result are the
+	// types of the method payload and of the value returned by the method
+	// endpoint, they are any if the method has no payload or no result
+	// (e.g. for streaming methods). All the callbacks are optional and
+	// receive the names of the service and method handling the request.
+	// The payload and result values must not be modified. Use the SetHooks
+	// method of the generated servers to set the hooks.
+	ServerHooks[Payload, Result any] struct {
+		// OnRequestDecoded is called with the decoded payload once the
+		// request message and metadata are decoded.
+		OnRequestDecoded func(ctx context.Context, service, method string, payload Payload)
+		// OnDecodeError is called when the request fails to be decoded.
+		OnDecodeError func(ctx context.Context, service, method string, err error)
+		// OnEndpointResult is called with the endpoint payload, result and
+		// error once the endpoint returns. The result of streaming
+		// endpoints is always the zero value.
+		OnEndpointResult func(ctx context.Context, service, method string, payload Payload, result Result, err error)
+		// OnEncodeError is called when the response fails to be encoded.
+		OnEncodeError func(ctx context.Context, service, method string, err error)
+		// OnResponseWritten is called with the status code of the
+		// response once the handler returns, including the codes of the
+		// errors mapped in the design.
+		OnResponseWritten func(ctx context.Context, service, method string, code codes.Code)
+	}
+
+	// handlerHooks is the interface implemented by ServerHooks used by the
+	// unary and stream handlers regardless of the payload and result types.
+	handlerHooks interface {
+		requestDecoded(ctx context.Context, payload any)
+		decodeError(ctx context.Context, err error)
+		endpointResult(ctx context.Context, payload, result any, err error)
+		encodeError(ctx context.Context, err error)
+	}
+)
+
+// EndpointResult calls the OnEndpointResult hook if set. It is used by the
+// generated streaming handlers, the unary handlers created with
+// NewUnaryHandlerWithHooks call the hook themselves. payload and result must
+// be nil or of type Payload and Result respectively. h may be nil.
+func (h *ServerHooks[Payload, Result]) EndpointResult(ctx context.Context, payload, result any, err error) {
+	h.endpointResult(ctx, payload, result, err)
+}
+
+// ResponseWritten calls the OnResponseWritten hook if set with the status code
+// of err, the error returned by the generated gRPC server method. h may be
+// nil.
+func (h *ServerHooks[Payload, Result]) ResponseWritten(ctx context.Context, err error) {
+	if h == nil || h.OnResponseWritten == nil {
+		return
+	}
+	svc, m := hookNames(ctx)
+	h.OnResponseWritten(ctx, svc, m, status.Code(err))
+}
+
+// requestDecoded calls the OnRequestDecoded hook if set.
+func (h *ServerHooks[Payload, Result]) requestDecoded(ctx context.Context, payload any) {
+	if h == nil || h.OnRequestDecoded == nil {
+		return
+	}
+	p, _ := payload.(Payload)
+	svc, m := hookNames(ctx)
+	h.OnRequestDecoded(ctx, svc, m, p)
+}
+
+// decodeError calls the OnDecodeError hook if set.
+func (h *ServerHooks[Payload, Result]) decodeError(ctx context.Context, err error) {
+	if h == nil || h.OnDecodeError == nil {
+		return
+	}
+	svc, m := hookNames(ctx)
+	h.OnDecodeError(ctx, svc, m, err)
+}
+
+// endpointResult calls the OnEndpointResult hook if set.
+func (h *ServerHooks[Payload, Result]) endpointResult(ctx context.Context, payload, result any, err error) {
+	if h == nil || h.OnEndpointResult == nil {
+		return
+	}
+	p, _ := payload.(Payload)
+	r, _ := result.(Result)
+	svc, m := hookNames(ctx)
+	h.OnEndpointResult(ctx, svc, m, p, r, err)
+}
+
+// encodeError calls the OnEncodeError hook if set.
+func (h *ServerHooks[Payload, Result]) encodeError(ctx context.Context, err error) {
+	if h == nil || h.OnEncodeError == nil {
+		return
+	}
+	svc, m := hookNames(ctx)
+	h.OnEncodeError(ctx, svc, m, err)
+}
+
+// hookNames returns the service and method names stored in ctx.
+func hookNames(ctx context.Context) (service, method string) {
+	service, _ = ctx.Value(goa.ServiceKey).(string)
+	method, _ = ctx.Value(goa.MethodKey).(string)
+	return
+}
@@ -7,6 +7,11 @@
 //   - Request ID server middleware for unary and streaming endpoints.
 //   - Stream Canceler server middleware for canceling streaming requests.
 //   - Tracing middleware for unary and streaming server and client.
+//   - OpenTelemetry tracing and metrics middleware for unary and streaming
+//     server and client.
+//   - Prometheus compatible metrics server middleware for unary and streaming
+//     endpoints.
+//   - Panic recovery server middleware for unary and streaming endpoints.
 //   - AWS X-Ray middleware for producing X-Ray segments for unary and streaming
 //     client and server.
 //
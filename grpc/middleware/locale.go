@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AcceptLanguageMetadataKey is the key containing the languages accepted by
+// the client in the gRPC metadata. The value uses the same syntax as the HTTP
+// Accept-Language header.
+const AcceptLanguageMetadataKey = "accept-language"
+
+// UnaryLocale returns a middleware for unary gRPC requests which selects the
+// language used to localize the error messages returned to the client. The
+// language is negotiated from the "accept-language" request metadata against
+// the languages defined in the given catalog and defaults to English. The
+// goa.DefaultMessageCatalog is used if catalog is nil.
+//
+// example of use:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryLocale(catalog)))
+func UnaryLocale(catalog *goa.MessageCatalog) grpc.UnaryServerInterceptor {
+	if catalog == nil {
+		catalog = goa.DefaultMessageCatalog
+	}
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withLocale(ctx, catalog), req)
+	})
+}
+
+// StreamLocale returns a middleware for streaming gRPC requests which selects
+// the language used to localize the error messages returned to the client.
+// See UnaryLocale.
+//
+// example of use:
+//
+//	grpc.NewServer(grpc.StreamInterceptor(middleware.StreamLocale(catalog)))
+func StreamLocale(catalog *goa.MessageCatalog) grpc.StreamServerInterceptor {
+	if catalog == nil {
+		catalog = goa.DefaultMessageCatalog
+	}
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wss := NewWrappedServerStream(withLocale(ss.Context(), catalog), ss)
+		return handler(srv, wss)
+	})
+}
+
+// withLocale sets the locale negotiated from the incoming metadata in ctx.
+func withLocale(ctx context.Context, catalog *goa.MessageCatalog) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	lang := catalog.Negotiate(MetadataValue(md, AcceptLanguageMetadataKey))
+	return goa.WithLocale(ctx, catalog, lang)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"goa.design/goa/v3/middleware"
+)
+
+// metricsServerStream is a server stream that records the messages sent and
+// received by a streaming method.
+type metricsServerStream struct {
+	*WrappedServerStream
+	req *middleware.MetricsRequest
+}
+
+// UnaryServerMetrics returns a server interceptor that records the RED metrics
+// of the unary gRPC requests in m. The requests are labelled with the gRPC full
+// method (route label) and status code, e.g. "NotFound". The service and
+// method labels as well as the error names are recorded by the m.Endpoint
+// endpoint middleware which must also be mounted.
+//
+// Example:
+//
+//	metrics := middleware.NewMetrics()
+//	endpoints.Use(metrics.Endpoint)
+//	grpc.NewServer(grpc.UnaryInterceptor(grpcmdlwr.UnaryServerMetrics(metrics)))
+func UnaryServerMetrics(m *middleware.Metrics) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, mr := m.StartRequest(ctx, info.FullMethod)
+		mr.RequestSize = messageLength(req)
+		resp, err := handler(ctx, req)
+		mr.ResponseSize = messageLength(resp)
+		mr.End(status.Code(err).String(), err != nil)
+		return resp, err
+	})
+}
+
+// StreamServerMetrics returns a server interceptor that records the RED
+// metrics of the streaming gRPC requests in m, see UnaryServerMetrics. The
+// stream is recorded as a single request once the handler returns and each
+// message sent or received is counted. The request and response sizes are the
+// total sizes of the messages received and sent respectively.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.StreamInterceptor(grpcmdlwr.StreamServerMetrics(metrics)))
+func StreamServerMetrics(m *middleware.Metrics) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, mr := m.StartRequest(ss.Context(), info.FullMethod)
+		err := handler(srv, &metricsServerStream{WrappedServerStream: NewWrappedServerStream(ctx, ss), req: mr})
+		mr.End(status.Code(err).String(), err != nil)
+		return err
+	})
+}
+
+// SendMsg records the message sent.
+func (s *metricsServerStream) SendMsg(m any) error {
+	if err := s.WrappedServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.req.ResponseSize += messageLength(m)
+	s.req.MessageSent()
+	return nil
+}
+
+// RecvMsg records the message received.
+func (s *metricsServerStream) RecvMsg(m any) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.req.RequestSize += messageLength(m)
+	s.req.MessageReceived()
+	return nil
+}
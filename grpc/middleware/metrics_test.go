@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type testMetricsStream struct {
+	grpc.ServerStream
+}
+
+func (s *testMetricsStream) Context() context.Context { return context.Background() }
+func (s *testMetricsStream) SendMsg(any) error        { return nil }
+func (s *testMetricsStream) RecvMsg(any) error        { return nil }
+
+func TestUnaryServerMetrics(t *testing.T) {
+	var (
+		m    = middleware.NewMetrics()
+		info = &grpc.UnaryServerInfo{FullMethod: "/calc.Calc/Add"}
+	)
+	endpoint := m.Endpoint(func(context.Context, any) (any, error) {
+		return nil, goa.PermanentError("overflow", "overflow")
+	})
+	interceptor := grpcm.UnaryServerMetrics(m)
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		ctx = context.WithValue(context.WithValue(ctx, goa.ServiceKey, "calc"), goa.MethodKey, "add")
+		if _, err := endpoint(ctx, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, nil
+	})
+	if err == nil {
+		t.Fatal("got no error, expected the handler error")
+	}
+	assertMetrics(t, m,
+		`goa_requests_total{service="calc",method="add",route="/calc.Calc/Add",code="InvalidArgument"} 1`,
+		`goa_request_errors_total{service="calc",method="add",route="/calc.Calc/Add",code="InvalidArgument",error="overflow"} 1`,
+	)
+}
+
+func TestStreamServerMetrics(t *testing.T) {
+	var (
+		m    = middleware.NewMetrics()
+		info = &grpc.StreamServerInfo{FullMethod: "/chat.Chat/Listen"}
+	)
+	interceptor := grpcm.StreamServerMetrics(m)
+	err := interceptor(nil, &testMetricsStream{}, info, func(_ any, ss grpc.ServerStream) error {
+		if middleware.ContextMetricsRequest(ss.Context()) == nil {
+			t.Error("got a stream context without the request metrics")
+		}
+		for range 3 {
+			if err := ss.SendMsg(nil); err != nil {
+				return err
+			}
+		}
+		return ss.RecvMsg(nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMetrics(t, m,
+		`goa_requests_total{service="",method="",route="/chat.Chat/Listen",code="OK"} 1`,
+		`goa_stream_messages_sent_total{service="",method="",route="/chat.Chat/Listen"} 3`,
+		`goa_stream_messages_received_total{service="",method="",route="/chat.Chat/Listen"} 1`,
+	)
+}
+
+func assertMetrics(t *testing.T, m *middleware.Metrics, lines ...string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range lines {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("got metrics:\n%s\nexpected line %q", out, line)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// otelInstruments holds the tracer, propagator and duration histogram
+	// used by the OpenTelemetry interceptors.
+	otelInstruments struct {
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+		duration   metric.Float64Histogram
+		kind       trace.SpanKind
+	}
+
+	// otelClientStream is a client stream that ends the span of the stream
+	// once the stream is done.
+	otelClientStream struct {
+		grpc.ClientStream
+		end func(error)
+	}
+
+	// metadataCarrier adapts metadata.MD to the OpenTelemetry
+	// propagation.TextMapCarrier interface.
+	metadataCarrier metadata.MD
+)
+
+// UnaryServerOTel returns a server interceptor that traces the unary gRPC
+// requests using OpenTelemetry and records their duration in the
+// "rpc.server.duration" histogram. The interceptor extracts the trace context
+// from the request metadata (W3C traceparent and tracestate keys with the
+// default propagator) and creates a server span named after the gRPC full
+// method, e.g. "calc.Calc/Add". The span is stored in the request context, see
+// middleware.OTelEndpoint.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryServerOTel()))
+func UnaryServerOTel(opts ...middleware.OTelOption) grpc.UnaryServerInterceptor {
+	o := newOTelInstruments("rpc.server.duration", trace.SpanKindServer, opts)
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, end := o.startServer(ctx, info.FullMethod)
+		resp, err = handler(ctx, req)
+		end(err)
+		return resp, err
+	})
+}
+
+// StreamServerOTel returns a server interceptor that traces the streaming gRPC
+// requests using OpenTelemetry, see UnaryServerOTel.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.StreamInterceptor(middleware.StreamServerOTel()))
+func StreamServerOTel(opts ...middleware.OTelOption) grpc.StreamServerInterceptor {
+	o := newOTelInstruments("rpc.server.duration", trace.SpanKindServer, opts)
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, end := o.startServer(ss.Context(), info.FullMethod)
+		err := handler(srv, NewWrappedServerStream(ctx, ss))
+		end(err)
+		return err
+	})
+}
+
+// UnaryClientOTel returns a client interceptor that creates a OpenTelemetry
+// client span for each unary request, injects the trace context in the
+// outgoing request metadata and records the request duration in the
+// "rpc.client.duration" histogram. The span is a child of the span stored in
+// the request context if any.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(url, grpc.WithUnaryInterceptor(middleware.UnaryClientOTel()))
+func UnaryClientOTel(opts ...middleware.OTelOption) grpc.UnaryClientInterceptor {
+	o := newOTelInstruments("rpc.client.duration", trace.SpanKindClient, opts)
+	return grpc.UnaryClientInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, end := o.startClient(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		end(err)
+		return err
+	})
+}
+
+// StreamClientOTel returns a client interceptor that traces the streaming gRPC
+// requests using OpenTelemetry, see UnaryClientOTel. The span ends when the
+// stream fails to be created or once receiving a message returns an error,
+// including io.EOF.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(url, grpc.WithStreamInterceptor(middleware.StreamClientOTel()))
+func StreamClientOTel(opts ...middleware.OTelOption) grpc.StreamClientInterceptor {
+	o := newOTelInstruments("rpc.client.duration", trace.SpanKindClient, opts)
+	return grpc.StreamClientInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, end := o.startClient(ctx, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			end(err)
+			return nil, err
+		}
+		var once sync.Once
+		return &otelClientStream{ClientStream: cs, end: func(err error) { once.Do(func() { end(err) }) }}, nil
+	})
+}
+
+// RecvMsg ends the span of the stream if receiving the message fails.
+func (s *otelClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		s.end(nil)
+	} else if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+// Get returns the first value associated with key.
+func (c metadataCarrier) Get(key string) string {
+	return MetadataValue(metadata.MD(c), key)
+}
+
+// Set sets the value associated with key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// newOTelInstruments creates the instruments used by the interceptors.
+func newOTelInstruments(histogram string, kind trace.SpanKind, opts []middleware.OTelOption) *otelInstruments {
+	o := middleware.NewOTelOptions(opts...)
+	duration, _ := o.Meter().Float64Histogram(histogram,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Duration of gRPC requests."))
+	return &otelInstruments{
+		tracer:     o.Tracer(),
+		propagator: o.Propagator(),
+		duration:   duration,
+		kind:       kind,
+	}
+}
+
+// startServer extracts the trace context from the incoming metadata and starts
+// a server span. The returned function ends the span given the handler error.
+func (o *otelInstruments) startServer(ctx context.Context, fullMethod string) (context.Context, func(error)) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = o.propagator.Extract(ctx, metadataCarrier(md))
+	return o.start(ctx, fullMethod)
+}
+
+// startClient starts a client span and injects the trace context in the
+// outgoing metadata. The returned function ends the span given the request
+// error.
+func (o *otelInstruments) startClient(ctx context.Context, fullMethod string) (context.Context, func(error)) {
+	ctx, end := o.start(ctx, fullMethod)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	o.propagator.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), end
+}
+
+// start starts a span named after the gRPC full method.
+func (o *otelInstruments) start(ctx context.Context, fullMethod string) (context.Context, func(error)) {
+	start := time.Now()
+	name := strings.TrimPrefix(fullMethod, "/")
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if svc, m, ok := strings.Cut(name, "/"); ok {
+		attrs = append(attrs, semconv.RPCService(svc), semconv.RPCMethod(m))
+	}
+	ctx, span := o.tracer.Start(ctx, name, trace.WithSpanKind(o.kind), trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		defer span.End()
+		code := status.Code(err)
+		attrs = append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if err != nil {
+			span.RecordError(err)
+			if o.kind == trace.SpanKindClient || isServerFault(code) {
+				span.SetStatus(codes.Error, status.Convert(err).Message())
+			}
+		}
+		o.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), metric.WithAttributes(attrs...))
+	}
+}
+
+// isServerFault returns true if code denotes a server error as defined by the
+// OpenTelemetry semantic conventions for gRPC.
+func isServerFault(code grpccodes.Code) bool {
+	switch code {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware"
+)
+
+func TestUnaryServerOTel(t *testing.T) {
+	var (
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		info     = &grpc.UnaryServerInfo{FullMethod: "/calc.Calc/Add"}
+		md       = metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		ctx      = metadata.NewIncomingContext(context.Background(), md)
+		inner    trace.SpanContext
+	)
+	interceptor := grpcm.UnaryServerOTel(middleware.OTelTracerProvider(tp), middleware.OTelPropagator(propagation.TraceContext{}))
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		inner = trace.SpanContextFromContext(ctx)
+		return nil, status.Error(codes.Internal, "boom")
+	})
+	if err == nil {
+		t.Fatal("got no error, expected the handler error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "calc.Calc/Add" {
+		t.Errorf("got span name %q, expected %q", span.Name, "calc.Calc/Add")
+	}
+	if got := span.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("got parent span ID %q, expected the span ID of the traceparent metadata", got)
+	}
+	if inner.SpanID() != span.SpanContext.SpanID() {
+		t.Error("got a request context without the server span")
+	}
+	if span.Status.Code.String() != "Error" {
+		t.Errorf("got status %s, expected Error", span.Status.Code)
+	}
+}
+
+func TestUnaryClientOTel(t *testing.T) {
+	var (
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		sent     metadata.MD
+	)
+	interceptor := grpcm.UnaryClientOTel(middleware.OTelTracerProvider(tp), middleware.OTelPropagator(propagation.TraceContext{}))
+	err := interceptor(context.Background(), "/calc.Calc/Add", nil, nil, nil, func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	span := spans[0]
+	expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	if got := grpcm.MetadataValue(sent, "traceparent"); got != expected {
+		t.Errorf("got traceparent %q, expected %q", got, expected)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	"goa.design/goa/v3/middleware"
+)
+
+// UnaryServerRecover returns a server interceptor that recovers from panics
+// raised by the unary gRPC handlers, including the generated request decoders
+// and response encoders. The panic value and stack trace are logged with l
+// (which may be nil) and the panic is converted into a goa fault error encoded
+// with goagrpc.EncodeError so that clients get a well-formed Internal status
+// with the error details.
+//
+// Use the endpoint middleware middleware.Recover to have the panics raised by
+// the service methods encoded by the method error encoder.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(grpcmdlwr.UnaryServerRecover(logger)))
+func UnaryServerRecover(l middleware.Logger) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				resp, err = nil, goagrpc.EncodeError(middleware.LogPanic(ctx, l, v))
+			}
+		}()
+		return handler(ctx, req)
+	})
+}
+
+// StreamServerRecover returns a server interceptor that recovers from panics
+// raised by the streaming gRPC handlers, see UnaryServerRecover.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.StreamInterceptor(grpcmdlwr.StreamServerRecover(logger)))
+func StreamServerRecover(l middleware.Logger) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = goagrpc.EncodeError(middleware.LogPanic(ss.Context(), l, v))
+			}
+		}()
+		return handler(srv, ss)
+	})
+}
@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	goapb "goa.design/goa/v3/grpc/pb"
+)
+
+type testLogger struct {
+	keyvals []any
+}
+
+func (l *testLogger) Log(keyvals ...any) error {
+	l.keyvals = append(l.keyvals, keyvals...)
+	return nil
+}
+
+func TestUnaryServerRecover(t *testing.T) {
+	var (
+		l    = &testLogger{}
+		info = &grpc.UnaryServerInfo{FullMethod: "/calc.Calc/Add"}
+	)
+	interceptor := grpcm.UnaryServerRecover(l)
+	res, err := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		panic("boom")
+	})
+	if res != nil {
+		t.Errorf("got response %v, expected nil", res)
+	}
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("got code %s, expected %s", code, codes.Internal)
+	}
+	resp, ok := goagrpc.DecodeError(err).(*goapb.ErrorResponse)
+	if !ok {
+		t.Fatalf("got error %v, expected the goa error details", err)
+	}
+	gerr := goagrpc.NewServiceError(resp)
+	if gerr.Name != "fault" || !gerr.Fault {
+		t.Errorf("got error %+v, expected a fault", gerr)
+	}
+	if len(l.keyvals) < 4 || l.keyvals[3] != gerr.ID {
+		t.Errorf("got log %v, expected the error ID %q", l.keyvals, gerr.ID)
+	}
+}
+
+func TestStreamServerRecover(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/chat.Chat/Listen"}
+	interceptor := grpcm.StreamServerRecover(nil)
+	err := interceptor(nil, &testMetricsStream{}, info, func(any, grpc.ServerStream) error {
+		panic("boom")
+	})
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("got code %s, expected %s", code, codes.Internal)
+	}
+}
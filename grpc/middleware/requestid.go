@@ -18,7 +18,8 @@ const (
 // initializes the request metadata with a unique value under the
 // RequestIDMetadata key. Optionally, it uses the incoming "x-request-id"
 // request metadata key, if present, with or without a length limit to use as
-// request ID. The default behavior is to always generate a new ID.
+// request ID. The default behavior is to always generate a new ID. The request
+// ID is also sent back to the client in the "x-request-id" header metadata.
 //
 // examples of use:
 //
@@ -28,6 +29,10 @@ const (
 //	grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryRequestID(
 //	  middleware.UseXRequestIDMetadataOption(true),
 //	  middleware.XRequestMetadataLimitOption(128))))
+//
+//	// derive the request ID from the "traceparent" metadata key.
+//	grpc.NewServer(grpc.UnaryInterceptor(middleware.UnaryRequestID(
+//	  middleware.TraceparentRequestIDOption(true))))
 func UnaryRequestID(options ...middleware.RequestIDOption) grpc.UnaryServerInterceptor {
 	o := middleware.NewRequestIDOptions(options...)
 	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
@@ -40,7 +45,8 @@ func UnaryRequestID(options ...middleware.RequestIDOption) grpc.UnaryServerInter
 // initializes the stream metadata with a unique value under the
 // RequestIDMetadata key. Optionally, it uses the incoming "x-request-id"
 // request metadata key, if present, with or without a length limit to use as
-// request ID. The default behavior is to always generate a new ID.
+// request ID. The default behavior is to always generate a new ID. The request
+// ID is also sent back to the client in the "x-request-id" header metadata.
 //
 // examples of use:
 //
@@ -70,18 +76,74 @@ func XRequestMetadataLimitOption(limit int) middleware.RequestIDOption {
 	return middleware.RequestIDLimitOption(limit)
 }
 
-// generateRequestID sets the request ID in the incoming request metadata.
+// TraceparentRequestIDOption enables/disables deriving the request ID from the
+// incoming "traceparent" metadata.
+func TraceparentRequestIDOption(f bool) middleware.RequestIDOption {
+	return middleware.TraceparentRequestIDOption(f)
+}
+
+// UnaryClientRequestID sets the outgoing unary request "x-request-id" metadata
+// to the request ID found in the context if any so that the downstream service
+// may use the same request ID. Requests whose outgoing metadata already contain
+// a request ID are left untouched.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(url, grpc.WithUnaryInterceptor(UnaryClientRequestID()))
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return grpc.UnaryClientInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = setRequestID(ctx)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+// StreamClientRequestID sets the outgoing stream request "x-request-id"
+// metadata to the request ID found in the context if any, see
+// UnaryClientRequestID.
+//
+// Example:
+//
+//	conn, err := grpc.Dial(url, grpc.WithStreamInterceptor(StreamClientRequestID()))
+func StreamClientRequestID() grpc.StreamClientInterceptor {
+	return grpc.StreamClientInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = setRequestID(ctx)
+		return streamer(ctx, desc, cc, method, opts...)
+	})
+}
+
+// generateRequestID sets the request ID in the incoming request metadata and
+// in the response header metadata.
 func generateRequestID(ctx context.Context, opts *middleware.RequestIDOptions) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.MD{}
 	}
+	var id string
 	if opts.IsUseRequestID() {
-		if id := MetadataValue(md, RequestIDMetadataKey); id != "" {
-			ctx = context.WithValue(ctx, middleware.RequestIDKey, id) // nolint: staticcheck
-		}
+		id = MetadataValue(md, RequestIDMetadataKey)
+	}
+	if id == "" && opts.IsUseTraceparent() {
+		id = middleware.TraceparentTraceID(MetadataValue(md, middleware.TraceparentHeader))
+	}
+	if id != "" {
+		ctx = context.WithValue(ctx, middleware.RequestIDKey, id) // nolint: staticcheck
 	}
 	ctx = middleware.GenerateRequestID(ctx, opts)
-	md.Set(RequestIDMetadataKey, ctx.Value(middleware.RequestIDKey).(string))
+	id = ctx.Value(middleware.RequestIDKey).(string)
+	md.Set(RequestIDMetadataKey, id)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id)) // nolint: errcheck
 	return metadata.NewIncomingContext(ctx, md)
 }
+
+// setRequestID sets the request ID found in ctx in the outgoing request
+// metadata.
+func setRequestID(ctx context.Context) context.Context {
+	id, ok := ctx.Value(middleware.RequestIDKey).(string)
+	if !ok || id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDMetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+}
@@ -15,6 +15,11 @@ type (
 	testServerStream struct {
 		grpc.ServerStream
 	}
+
+	testServerTransportStream struct {
+		grpc.ServerTransportStream
+		header metadata.MD
+	}
 )
 
 func TestUnaryRequestID(t *testing.T) {
@@ -93,6 +98,33 @@ func TestUnaryRequestID(t *testing.T) {
 				return "response", nil
 			},
 		},
+		{
+			name: "with-traceparent-option",
+			options: []middleware.RequestIDOption{
+				grpcm.TraceparentRequestIDOption(true),
+			},
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")),
+			handler: func(ctx context.Context, req any) (any, error) {
+				if val := ctx.Value(middleware.RequestIDKey); val != "4bf92f3577b34da6a3ce929d0e0e4736" {
+					return nil, fmt.Errorf("incorrect request ID: got %q, expected %q", val, "4bf92f3577b34da6a3ce929d0e0e4736")
+				}
+				return "response", nil
+			},
+		},
+		{
+			name: "with-generator-option",
+			options: []middleware.RequestIDOption{
+				middleware.RequestIDGeneratorOption(func() string { return "generated" }),
+			},
+			ctx: context.Background(),
+			handler: func(ctx context.Context, req any) (any, error) {
+				if val := ctx.Value(middleware.RequestIDKey); val != "generated" {
+					return nil, fmt.Errorf("incorrect request ID: got %q, expected %q", val, "generated")
+				}
+				return "response", nil
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -192,6 +224,59 @@ func TestStreamRequestID(t *testing.T) {
 	}
 }
 
+func TestRequestIDResponseHeader(t *testing.T) {
+	var (
+		sts = &testServerTransportStream{}
+		ctx = grpc.NewContextWithServerTransportStream(populateRequestID("xyz"), sts)
+	)
+	interceptor := grpcm.UnaryRequestID(grpcm.UseXRequestIDMetadataOption(true))
+	_, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{FullMethod: "Test.Test"}, func(context.Context, any) (any, error) {
+		return "response", nil
+	})
+	if err != nil {
+		t.Fatalf("UnaryRequestID error: %v", err)
+	}
+	if val := grpcm.MetadataValue(sts.header, grpcm.RequestIDMetadataKey); val != "xyz" {
+		t.Errorf("incorrect request ID in header metadata: got %q, expected %q", val, "xyz")
+	}
+}
+
+func TestUnaryClientRequestID(t *testing.T) {
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{"no-request-id", context.Background(), ""},
+		{"request-id", context.WithValue(context.Background(), middleware.RequestIDKey, "xyz"), "xyz"}, // nolint: staticcheck
+		{"existing-metadata", metadata.AppendToOutgoingContext(
+			context.WithValue(context.Background(), middleware.RequestIDKey, "xyz"), // nolint: staticcheck
+			grpcm.RequestIDMetadataKey, "abc"), "abc"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var md metadata.MD
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			if err := grpcm.UnaryClientRequestID()(c.ctx, "Test.Test", nil, nil, nil, invoker); err != nil {
+				t.Fatalf("UnaryClientRequestID error: %v", err)
+			}
+			vals := md.Get(grpcm.RequestIDMetadataKey)
+			if c.expected == "" && len(vals) > 0 || c.expected != "" && (len(vals) != 1 || vals[0] != c.expected) {
+				t.Errorf("incorrect request ID in outgoing metadata: got %v, expected %q", vals, c.expected)
+			}
+		})
+	}
+}
+
+// SetHeader records the header metadata.
+func (s *testServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
 // populateRequestID populates the context with incoming gRPC request metadata
 // containing the RequestIDMetadataKey key set to the given ID.
 func populateRequestID(id string) context.Context {
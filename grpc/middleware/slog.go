@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	goapb "goa.design/goa/v3/grpc/pb"
+	"goa.design/goa/v3/middleware"
+)
+
+// slogServerStream is a server stream that counts the bytes sent.
+type slogServerStream struct {
+	grpc.ServerStream
+	bytes int64
+}
+
+// UnaryServerSlog returns a server interceptor that logs the unary gRPC
+// requests using the given log/slog logger. The interceptor logs a single
+// entry once the request is handled with the following attributes:
+//
+//   - request_id: the request ID set by the RequestID middleware or a short
+//     unique ID if missing.
+//   - trace_id: the trace ID if any, see middleware.ContextTraceID.
+//   - route: the gRPC full method.
+//   - status, duration and bytes: the response gRPC status code, the time it
+//     took to handle the request and the response message length.
+//   - error: the name of the error returned by the endpoint if any.
+//
+// Requests that fail with a goa fault or a server error status code (e.g.
+// Internal or Unavailable) are logged at the error level, the others at the
+// info level.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(grpcmdlwr.UnaryServerSlog(slog.Default())))
+func UnaryServerSlog(l *slog.Logger) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		logSlog(ctx, l, info.FullMethod, started, messageLength(resp), err)
+		return resp, err
+	})
+}
+
+// StreamServerSlog returns a server interceptor that logs the streaming gRPC
+// requests using the given log/slog logger once the stream is closed, see
+// UnaryServerSlog. The bytes attribute is the total length of the messages
+// sent.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.StreamInterceptor(grpcmdlwr.StreamServerSlog(slog.Default())))
+func StreamServerSlog(l *slog.Logger) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		stream := &slogServerStream{ServerStream: ss}
+		err := handler(srv, stream)
+		logSlog(ss.Context(), l, info.FullMethod, started, stream.bytes, err)
+		return err
+	})
+}
+
+// SendMsg counts the bytes sent.
+func (s *slogServerStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.bytes += messageLength(m)
+	return nil
+}
+
+// logSlog logs a handled request.
+func logSlog(ctx context.Context, l *slog.Logger, fullMethod string, started time.Time, bytes int64, err error) {
+	reqID, _ := ctx.Value(middleware.RequestIDKey).(string)
+	if reqID == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			reqID = MetadataValue(md, RequestIDMetadataKey)
+		}
+		if reqID == "" {
+			reqID = shortID()
+		}
+	}
+	attrs := []slog.Attr{slog.String("request_id", reqID)}
+	if traceID := middleware.ContextTraceID(ctx); traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+	s := status.Convert(err)
+	attrs = append(attrs,
+		slog.String("route", fullMethod),
+		slog.String("status", s.Code().String()),
+		slog.Duration("duration", time.Since(started)),
+		slog.Int64("bytes", bytes))
+	fault := isServerFault(s.Code())
+	for _, d := range s.Details() {
+		if resp, ok := d.(*goapb.ErrorResponse); ok {
+			attrs = append(attrs, slog.String("error", resp.Name))
+			fault = resp.Fault
+			break
+		}
+	}
+	level := slog.LevelInfo
+	if fault {
+		level = slog.LevelError
+	}
+	l.LogAttrs(ctx, level, "request", attrs...)
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	goagrpc "goa.design/goa/v3/grpc"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestUnaryServerSlog(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		info = &grpc.UnaryServerInfo{FullMethod: "/calc.Calc/Add"}
+		ctx  = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcm.RequestIDMetadataKey, "reqid"))
+	)
+	interceptor := grpcm.UnaryServerSlog(slog.New(slog.NewJSONHandler(&buf, nil)))
+	_, err := interceptor(ctx, nil, info, func(context.Context, any) (any, error) {
+		return nil, goagrpc.EncodeError(goa.PermanentError("overflow", "overflow"))
+	})
+	if err == nil {
+		t.Fatal("got no error, expected the handler error")
+	}
+	assertSlogEntry(t, &buf, map[string]any{
+		"level":      "INFO",
+		"request_id": "reqid",
+		"route":      "/calc.Calc/Add",
+		"status":     "Unknown",
+		"error":      "overflow",
+	})
+}
+
+func TestStreamServerSlog(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		info = &grpc.StreamServerInfo{FullMethod: "/chat.Chat/Listen"}
+	)
+	interceptor := grpcm.StreamServerSlog(slog.New(slog.NewJSONHandler(&buf, nil)))
+	err := interceptor(nil, &testMetricsStream{}, info, func(_ any, ss grpc.ServerStream) error {
+		if err := ss.SendMsg(nil); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("got no error, expected the handler error")
+	}
+	assertSlogEntry(t, &buf, map[string]any{
+		"level":  "ERROR",
+		"route":  "/chat.Chat/Listen",
+		"status": "Unknown",
+		"bytes":  float64(0),
+	})
+}
+
+func assertSlogEntry(t *testing.T, buf *bytes.Buffer, expected map[string]any) {
+	t.Helper()
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+		}
+	}
+	if _, ok := entry["request_id"]; !ok {
+		t.Error("got no request ID attribute")
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"regexp"
+	"time"
 
 	"goa.design/goa/v3/middleware"
 	"google.golang.org/grpc"
@@ -37,9 +38,12 @@ const (
 //	  middleware.SamplingPercent(100)))
 func UnaryServerTrace(opts ...middleware.TraceOption) grpc.UnaryServerInterceptor {
 	o := middleware.NewTraceOptions(opts...)
+	sampler := o.NewTraceSampler()
 	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-		ctx = withTrace(ctx, info.FullMethod, o)
-		return handler(ctx, req)
+		ctx, complete := withTrace(ctx, info.FullMethod, o, sampler)
+		resp, err = handler(ctx, req)
+		complete(err)
+		return resp, err
 	})
 }
 
@@ -57,10 +61,13 @@ func UnaryServerTrace(opts ...middleware.TraceOption) grpc.UnaryServerIntercepto
 //	  middleware.MaxSamplingRate(50)))
 func StreamServerTrace(opts ...middleware.TraceOption) grpc.StreamServerInterceptor {
 	o := middleware.NewTraceOptions(opts...)
+	sampler := o.NewTraceSampler()
 	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctx := withTrace(ss.Context(), info.FullMethod, o)
+		ctx, complete := withTrace(ss.Context(), info.FullMethod, o, sampler)
 		wss := NewWrappedServerStream(ctx, ss)
-		return handler(srv, wss)
+		err := handler(srv, wss)
+		complete(err)
+		return err
 	})
 }
 
@@ -123,18 +130,45 @@ func DiscardFromTrace(discard *regexp.Regexp) middleware.TraceOption {
 	return middleware.DiscardFromTrace(discard)
 }
 
-// withTrace sets the trace ID, span ID, and parent span ID in the context.
-func withTrace(ctx context.Context, fullMethod string, opts *middleware.TraceOptions) context.Context {
-	sampler := opts.NewSampler()
+// SampleRoute is a wrapper for the top-level SampleRoute. The route is matched
+// against the full gRPC method name (e.g. "/calc.Calc/Add").
+func SampleRoute(route *regexp.Regexp, p int) middleware.TraceOption {
+	return middleware.SampleRoute(route, p)
+}
+
+// SampleErrors is a wrapper for the top-level SampleErrors.
+func SampleErrors() middleware.TraceOption {
+	return middleware.SampleErrors()
+}
+
+// SampleSlow is a wrapper for the top-level SampleSlow.
+func SampleSlow(threshold time.Duration) middleware.TraceOption {
+	return middleware.SampleSlow(threshold)
+}
+
+// TailSampling is a wrapper for the top-level TailSampling.
+func TailSampling() middleware.TraceOption {
+	return middleware.TailSampling()
+}
+
+// withTrace sets the trace ID, span ID, and parent span ID in the context. The
+// returned function must be called with the handler error once the request
+// completes to make the tail sampling decision if enabled.
+func withTrace(ctx context.Context, fullMethod string, opts *middleware.TraceOptions, sampler *middleware.TraceSampler) (context.Context, func(error)) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.MD{}
 	}
 	// insert a new trace ID only if not already being traced.
-	var traceID string
+	var (
+		traceID string
+		traced  bool
+		sampled bool
+	)
 	{
 		traceID = MetadataValue(md, TraceIDMetadataKey)
-		if traceID == "" {
+		traced = traceID != ""
+		if !traced {
 			var discarded bool
 			for _, discard := range opts.Discards() {
 				if discard.MatchString(fullMethod) {
@@ -142,14 +176,18 @@ func withTrace(ctx context.Context, fullMethod string, opts *middleware.TraceOpt
 					break
 				}
 			}
-			if !discarded && sampler.Sample() {
-				// insert tracing only within sample.
-				traceID = opts.TraceID()
+			if !discarded {
+				sampled = sampler.SampleRoute(fullMethod)
+				if sampled || sampler.Tail() {
+					// insert tracing only within sample or if the
+					// decision is deferred.
+					traceID = opts.TraceID()
+				}
 			}
 		}
 	}
 	if traceID == "" {
-		return ctx
+		return ctx, func(error) {}
 	}
 
 	var (
@@ -162,7 +200,17 @@ func withTrace(ctx context.Context, fullMethod string, opts *middleware.TraceOpt
 	}
 
 	// insert IDs into context to enable tracing.
-	return middleware.WithSpan(ctx, traceID, spanID, parentID)
+	ctx = middleware.WithSpan(ctx, traceID, spanID, parentID)
+	if traced || !sampler.Tail() {
+		return ctx, func(error) {}
+	}
+
+	// buffer the spans until the request completes.
+	buf := middleware.NewSpanBuffer()
+	started := time.Now()
+	return middleware.WithSpanBuffer(ctx, buf), func(err error) {
+		buf.Complete(sampler.SampleCompleted(sampled, err != nil, time.Since(started)))
+	}
 }
 
 // setTrace sets the trace information to the request context's outgoing
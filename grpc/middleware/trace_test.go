@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	grpcm "goa.design/goa/v3/grpc/middleware"
 	"goa.design/goa/v3/middleware"
@@ -265,3 +266,52 @@ func TestStreamClientTrace(t *testing.T) {
 		})
 	}
 }
+
+func TestUnaryServerTraceTailSampling(t *testing.T) {
+	var (
+		unary = &grpc.UnaryServerInfo{FullMethod: "/calc.Calc/Add"}
+	)
+
+	cases := map[string]struct {
+		Options []middleware.TraceOption
+		Err     error
+		// output
+		Buffered bool
+		Sampled  bool
+	}{
+		"head-sampled":       {[]middleware.TraceOption{grpcm.SamplingPercent(100)}, nil, false, true},
+		"method-not-sampled": {[]middleware.TraceOption{grpcm.SampleRoute(regexp.MustCompile("/Add$"), 0)}, nil, false, false},
+		"method-sampled":     {[]middleware.TraceOption{grpcm.SamplingPercent(0), grpcm.SampleRoute(regexp.MustCompile("/Add$"), 100)}, nil, false, true},
+		"tail-dropped":       {[]middleware.TraceOption{grpcm.SamplingPercent(0), grpcm.TailSampling()}, nil, true, false},
+		"error-sampled":      {[]middleware.TraceOption{grpcm.SamplingPercent(0), grpcm.SampleErrors()}, fmt.Errorf("error"), true, true},
+		"slow-dropped":       {[]middleware.TraceOption{grpcm.SamplingPercent(0), grpcm.SampleSlow(time.Hour)}, nil, true, false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var (
+				buffered bool
+				sampled  bool
+			)
+			handler := func(ctx context.Context, req any) (any, error) {
+				if ctx.Value(middleware.TraceIDKey) != nil {
+					if buf := middleware.ContextSpanBuffer(ctx); buf != nil {
+						buffered = true
+						buf.Record(func() { sampled = true })
+					} else {
+						sampled = true
+					}
+				}
+				return "response", c.Err
+			}
+
+			grpcm.UnaryServerTrace(c.Options...)(context.Background(), "request", unary, handler) // nolint: errcheck
+
+			if buffered != c.Buffered {
+				t.Errorf("invalid buffered, expected %v - got %v", c.Buffered, buffered)
+			}
+			if sampled != c.Sampled {
+				t.Errorf("invalid sampled, expected %v - got %v", c.Sampled, sampled)
+			}
+		})
+	}
+}
@@ -84,7 +84,7 @@ func NewUnaryServer(service, daemon string) (grpc.UnaryServerInterceptor, error)
 			return handler(ctx, req)
 		}
 
-		s := &GRPCSegment{xray.NewSegment(service, traceID.(string), spanID.(string), connection())}
+		s := &GRPCSegment{xray.NewSegment(service, traceID.(string), spanID.(string), xray.BufferConn(ctx, connection()))}
 		defer s.Close()
 		s.RecordRequest(ctx, info.FullMethod, req, "")
 		if parentID != nil {
@@ -122,7 +122,7 @@ func NewStreamServer(service, daemon string) (grpc.StreamServerInterceptor, erro
 			return handler(srv, ss)
 		}
 
-		s := &GRPCSegment{xray.NewSegment(service, traceID.(string), spanID.(string), connection())}
+		s := &GRPCSegment{xray.NewSegment(service, traceID.(string), spanID.(string), xray.BufferConn(ctx, connection()))}
 		defer s.Close()
 		s.RecordRequest(ctx, info.FullMethod, nil, "")
 		if parentID != nil {
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"goa.design/goa/v3/security"
+)
+
+// SetAuthenticateHeaders adds one WWW-Authenticate header per security scheme
+// accepted by the endpoint to w if err wraps a security.AuthError. The
+// challenges use the scheme name as realm and list the schemes in evaluation
+// order. Only the schemes that map to a registered HTTP authentication scheme
+// (Basic and Bearer) are listed: clients do not understand challenges for API
+// keys, request signatures or sessions and mutual TLS is negotiated by the TLS
+// handshake. The generated handlers of secured endpoints call
+// SetAuthenticateHeaders prior to encoding errors.
+func SetAuthenticateHeaders(w http.ResponseWriter, err error) {
+	var aerr *security.AuthError
+	if !errors.As(err, &aerr) {
+		return
+	}
+	for _, s := range aerr.Schemes {
+		if c := authChallenge(s); c != "" {
+			w.Header().Add("WWW-Authenticate", c)
+		}
+	}
+}
+
+// authChallenge returns the WWW-Authenticate challenge for the given scheme,
+// empty if the scheme does not use one.
+func authChallenge(s *security.SchemeRef) string {
+	var scheme string
+	switch s.Type {
+	case "Basic":
+		scheme = "Basic"
+	case "JWT", "OAuth2", "OpenIDConnect":
+		scheme = "Bearer"
+	default:
+		return ""
+	}
+	return scheme + " realm=" + strconv.Quote(s.Name)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+func TestSetAuthenticateHeaders(t *testing.T) {
+	aerr := &security.AuthError{
+		Schemes: []*security.SchemeRef{
+			{Name: "basic", Type: "Basic"},
+			{Name: "jwt", Type: "JWT"},
+			{Name: "mtls", Type: "MutualTLS"},
+			{Name: "api_key", Type: "APIKey"},
+			{Name: "webhook", Type: "Signature"},
+			{Name: "session", Type: "Session"},
+			{Name: "oauth2", Type: "OAuth2"},
+		},
+	}
+	w := httptest.NewRecorder()
+	SetAuthenticateHeaders(w, aerr)
+	assert.Equal(t, []string{`Basic realm="basic"`, `Bearer realm="jwt"`, `Bearer realm="oauth2"`}, w.Header().Values("WWW-Authenticate"))
+
+	w = httptest.NewRecorder()
+	SetAuthenticateHeaders(w, errors.New("boom"))
+	assert.Empty(t, w.Header().Values("WWW-Authenticate"))
+}
+
+func TestErrorEncoderAuthError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	fail := func(err error) error {
+		return &security.AuthError{
+			Schemes:  []*security.SchemeRef{{Name: "jwt", Type: "JWT"}},
+			Failures: []*security.SchemeError{{Scheme: "jwt", Type: "JWT", Err: err}},
+		}
+	}
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantName   string
+	}{
+		{"unauthorized", fail(errors.New("token expired")), http.StatusUnauthorized, goa.Unauthorized},
+		{"service error", fail(goa.PermissionDeniedError()), http.StatusForbidden, goa.PermissionDenied},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			err := ErrorEncoder(ResponseEncoder, nil)(ctx, w, c.err)
+
+			assert.Equal(t, c.wantStatus, w.Code)
+			require.NoError(t, err)
+			assert.Contains(t, w.Body.String(), `"name":"`+c.wantName+`"`)
+			assert.NotContains(t, w.Body.String(), "token expired")
+		})
+	}
+}
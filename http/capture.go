@@ -0,0 +1,422 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// Capture is a recorded HTTP request and response pair. Captures are
+	// written by the http/middleware Record middleware and replayed with
+	// Replay.
+	Capture struct {
+		// Started is the time the request was received.
+		Started time.Time `json:"started"`
+		// Duration is the time it took to handle the request.
+		Duration time.Duration `json:"duration"`
+		// RequestID is the ID of the request if any.
+		RequestID string `json:"request_id,omitempty"`
+		// Route is the route pattern that matched the request if any,
+		// e.g. "/accounts/{id}".
+		Route string `json:"route,omitempty"`
+		// Request is the recorded request.
+		Request *CaptureRequest `json:"request"`
+		// Response is the recorded response.
+		Response *CaptureResponse `json:"response"`
+	}
+
+	// CaptureRequest is a recorded HTTP request.
+	CaptureRequest struct {
+		// Method is the request HTTP method.
+		Method string `json:"method"`
+		// URL is the request URI including the query string, e.g.
+		// "/accounts?limit=10".
+		URL string `json:"url"`
+		// Header contains the request headers.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the request body.
+		Body string `json:"body,omitempty"`
+		// BodyEncoding is "base64" if Body is base64 encoded because the
+		// request body is not valid UTF-8.
+		BodyEncoding string `json:"body_encoding,omitempty"`
+		// BodyTruncated is true if the request body was not recorded because
+		// it was too large.
+		BodyTruncated bool `json:"body_truncated,omitempty"`
+	}
+
+	// CaptureResponse is a recorded HTTP response.
+	CaptureResponse struct {
+		// Status is the response status code.
+		Status int `json:"status"`
+		// Header contains the response headers.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the response body.
+		Body string `json:"body,omitempty"`
+		// BodyEncoding is "base64" if Body is base64 encoded because the
+		// response body is not valid UTF-8.
+		BodyEncoding string `json:"body_encoding,omitempty"`
+		// BodyTruncated is true if the response body was not recorded because
+		// it was too large.
+		BodyTruncated bool `json:"body_truncated,omitempty"`
+	}
+
+	// CaptureWriter writes captures to an underlying writer. Implementations
+	// must be safe for concurrent use. The capture writers returned by
+	// NewJSONLinesCaptureWriter and NewHARCaptureWriter stop writing after
+	// the first error and return it from Close.
+	CaptureWriter interface {
+		// Write writes the given capture.
+		Write(*Capture) error
+		// Close completes the output. It does not close the underlying
+		// writer.
+		Close() error
+	}
+
+	// jsonLinesWriter writes captures as JSON Lines.
+	jsonLinesWriter struct {
+		mu  sync.Mutex
+		enc *json.Encoder
+		err error
+	}
+
+	// harWriter writes captures as a HAR document.
+	harWriter struct {
+		mu      sync.Mutex
+		w       io.Writer
+		started bool
+		count   int
+		err     error
+	}
+
+	// harLog is the HAR document root.
+	harLog struct {
+		Log struct {
+			Entries []*harEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	// harEntry is a HAR log entry, see http://www.softwareishard.com/blog/har-12-spec/.
+	harEntry struct {
+		StartedDateTime time.Time      `json:"startedDateTime"`
+		Time            float64        `json:"time"`
+		Request         *harRequest    `json:"request"`
+		Response        *harResponse   `json:"response"`
+		Cache           struct{}       `json:"cache"`
+		Timings         map[string]any `json:"timings"`
+		RequestID       string         `json:"_requestId,omitempty"`
+		Route           string         `json:"_route,omitempty"`
+	}
+
+	// harRequest is a HAR request.
+	harRequest struct {
+		Method      string       `json:"method"`
+		URL         string       `json:"url"`
+		HTTPVersion string       `json:"httpVersion"`
+		Headers     []*harPair   `json:"headers"`
+		QueryString []*harPair   `json:"queryString"`
+		Cookies     []*harPair   `json:"cookies"`
+		HeadersSize int          `json:"headersSize"`
+		BodySize    int          `json:"bodySize"`
+		PostData    *harPostData `json:"postData,omitempty"`
+	}
+
+	// harResponse is a HAR response.
+	harResponse struct {
+		Status      int         `json:"status"`
+		StatusText  string      `json:"statusText"`
+		HTTPVersion string      `json:"httpVersion"`
+		Headers     []*harPair  `json:"headers"`
+		Cookies     []*harPair  `json:"cookies"`
+		Content     *harContent `json:"content"`
+		RedirectURL string      `json:"redirectURL"`
+		HeadersSize int         `json:"headersSize"`
+		BodySize    int         `json:"bodySize"`
+	}
+
+	// harPair is a HAR name/value pair.
+	harPair struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// harPostData is a HAR request body.
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+		Encoding string `json:"_encoding,omitempty"`
+	}
+
+	// harContent is a HAR response body.
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Encoding string `json:"encoding,omitempty"`
+	}
+)
+
+// NewCaptureBody returns the capture representation of the body b: b itself
+// if it is valid UTF-8, its base64 encoding and "base64" otherwise.
+func NewCaptureBody(b []byte) (body, encoding string) {
+	if utf8.Valid(b) {
+		return string(b), ""
+	}
+	return base64.StdEncoding.EncodeToString(b), "base64"
+}
+
+// NewCaptureHeader returns the capture representation of the given headers
+// where the values of the sensitive headers are masked by redactor.
+func NewCaptureHeader(h http.Header, redactor *Redactor) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	res := make(http.Header, len(h))
+	for k, v := range h {
+		res[k] = redactor.HeaderValues(k, v)
+	}
+	return res
+}
+
+// Bytes returns the decoded request body.
+func (r *CaptureRequest) Bytes() ([]byte, error) {
+	return decodeCaptureBody(r.Body, r.BodyEncoding)
+}
+
+// Bytes returns the decoded response body.
+func (r *CaptureResponse) Bytes() ([]byte, error) {
+	return decodeCaptureBody(r.Body, r.BodyEncoding)
+}
+
+// NewJSONLinesCaptureWriter returns a capture writer that writes each capture
+// to w as a single line JSON object.
+func NewJSONLinesCaptureWriter(w io.Writer) CaptureWriter {
+	return &jsonLinesWriter{enc: json.NewEncoder(w)}
+}
+
+// NewHARCaptureWriter returns a capture writer that writes the captures to w
+// as a HAR 1.2 document. The route and request ID are written in the custom
+// "_route" and "_requestId" entry fields. The document is streamed as the
+// captures are written and Close must be called to complete it.
+func NewHARCaptureWriter(w io.Writer) CaptureWriter {
+	return &harWriter{w: w}
+}
+
+// ReadCaptures reads the captures written by the JSON Lines or HAR capture
+// writers from r. The format is detected automatically.
+func ReadCaptures(r io.Reader) ([]*Capture, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var captures []*Capture
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return captures, nil
+			}
+			return nil, fmt.Errorf("failed to decode capture: %w", err)
+		}
+		var har harLog
+		if err := json.Unmarshal(raw, &har); err == nil && har.Log.Entries != nil {
+			for _, e := range har.Log.Entries {
+				captures = append(captures, e.capture())
+			}
+			continue
+		}
+		var c Capture
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return nil, fmt.Errorf("failed to decode capture: %w", err)
+		}
+		if c.Request == nil || c.Response == nil {
+			return nil, fmt.Errorf("invalid capture %d: missing request or response", len(captures)+1)
+		}
+		captures = append(captures, &c)
+	}
+}
+
+// Write writes c as a single JSON line.
+func (w *jsonLinesWriter) Write(c *Capture) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.enc.Encode(c)
+	return w.err
+}
+
+// Close returns the first error that occurred while writing the captures.
+func (w *jsonLinesWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Write writes c as a HAR entry.
+func (w *harWriter) Write(c *Capture) error {
+	b, err := json.Marshal(newHAREntry(c))
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.count > 0 {
+		b = append([]byte(",\n"), b...)
+	}
+	w.count++
+	_, w.err = w.w.Write(b)
+	return w.err
+}
+
+// Close writes the end of the HAR document. It returns the first error that
+// occurred while writing the captures if any.
+func (w *harWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.start(); err != nil {
+		return err
+	}
+	_, w.err = io.WriteString(w.w, "\n]}}\n")
+	return w.err
+}
+
+// start writes the beginning of the HAR document if not already done. It
+// returns the error of a previous write if any.
+func (w *harWriter) start() error {
+	if w.err != nil || w.started {
+		return w.err
+	}
+	w.started = true
+	_, w.err = io.WriteString(w.w, `{"log":{"version":"1.2","creator":{"name":"goa","version":"3"},"entries":[`+"\n")
+	return w.err
+}
+
+// newHAREntry returns the HAR entry corresponding to c.
+func newHAREntry(c *Capture) *harEntry {
+	ms := float64(c.Duration) / float64(time.Millisecond)
+	req := &harRequest{
+		Method:      c.Request.Method,
+		URL:         c.Request.URL,
+		HTTPVersion: "HTTP/1.1",
+		Headers:     harPairs(c.Request.Header),
+		QueryString: []*harPair{},
+		Cookies:     []*harPair{},
+		HeadersSize: -1,
+		BodySize:    len(c.Request.Body),
+	}
+	if c.Request.Body != "" {
+		req.PostData = &harPostData{
+			MimeType: c.Request.Header.Get("Content-Type"),
+			Text:     c.Request.Body,
+			Encoding: c.Request.BodyEncoding,
+		}
+	}
+	return &harEntry{
+		StartedDateTime: c.Started,
+		Time:            ms,
+		Request:         req,
+		Response: &harResponse{
+			Status:      c.Response.Status,
+			StatusText:  http.StatusText(c.Response.Status),
+			HTTPVersion: "HTTP/1.1",
+			Headers:     harPairs(c.Response.Header),
+			Cookies:     []*harPair{},
+			Content: &harContent{
+				Size:     len(c.Response.Body),
+				MimeType: c.Response.Header.Get("Content-Type"),
+				Text:     c.Response.Body,
+				Encoding: c.Response.BodyEncoding,
+			},
+			HeadersSize: -1,
+			BodySize:    len(c.Response.Body),
+		},
+		Timings:   map[string]any{"send": 0, "wait": ms, "receive": 0},
+		RequestID: c.RequestID,
+		Route:     c.Route,
+	}
+}
+
+// capture returns the capture corresponding to e.
+func (e *harEntry) capture() *Capture {
+	c := &Capture{
+		Started:   e.StartedDateTime,
+		Duration:  time.Duration(e.Time * float64(time.Millisecond)),
+		RequestID: e.RequestID,
+		Route:     e.Route,
+		Request:   &CaptureRequest{},
+		Response:  &CaptureResponse{},
+	}
+	if e.Request != nil {
+		c.Request.Method = e.Request.Method
+		c.Request.URL = e.Request.URL
+		c.Request.Header = harHeader(e.Request.Headers)
+		if e.Request.PostData != nil {
+			c.Request.Body = e.Request.PostData.Text
+			c.Request.BodyEncoding = e.Request.PostData.Encoding
+		}
+	}
+	if e.Response != nil {
+		c.Response.Status = e.Response.Status
+		c.Response.Header = harHeader(e.Response.Headers)
+		if e.Response.Content != nil {
+			c.Response.Body = e.Response.Content.Text
+			c.Response.BodyEncoding = e.Response.Content.Encoding
+		}
+	}
+	return c
+}
+
+// harPairs returns the HAR name/value pairs sorted by name for the given
+// headers, one pair per header value.
+func harPairs(h http.Header) []*harPair {
+	pairs := make([]*harPair, 0, len(h))
+	for k, vals := range h {
+		for _, v := range vals {
+			pairs = append(pairs, &harPair{Name: k, Value: v})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// harHeader returns the headers corresponding to the given HAR pairs.
+func harHeader(pairs []*harPair) http.Header {
+	if len(pairs) == 0 {
+		return nil
+	}
+	h := make(http.Header, len(pairs))
+	for _, p := range pairs {
+		h.Add(p.Name, p.Value)
+	}
+	return h
+}
+
+// decodeCaptureBody decodes a capture body given its encoding.
+func decodeCaptureBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding %q", encoding)
+	}
+}
+
+// bytesBody returns a request body reading b or http.NoBody if b is empty.
+func bytesBody(b []byte) io.ReadCloser {
+	if len(b) == 0 {
+		return http.NoBody
+	}
+	return io.NopCloser(bytes.NewReader(b))
+}
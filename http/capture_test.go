@@ -0,0 +1,132 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureWriters(t *testing.T) {
+	binary, encoding := NewCaptureBody([]byte{0xff, 0xfe})
+	captures := []*Capture{
+		{
+			Started:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Duration:  1500 * time.Microsecond,
+			RequestID: "reqid",
+			Route:     "/add/{a}/{b}",
+			Request: &CaptureRequest{
+				Method: "GET",
+				URL:    "/add/1/2?x=y",
+				Header: http.Header{"Accept": {"application/json"}},
+			},
+			Response: &CaptureResponse{
+				Status: 200,
+				Header: http.Header{"Content-Type": {"application/json"}, "Set-Cookie": {"a=1; Path=/", "b=2; Expires=Wed, 21 Oct 2026 07:28:00 GMT"}},
+				Body:   "3\n",
+			},
+		},
+		{
+			Started:  time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC),
+			Duration: 2 * time.Millisecond,
+			Request: &CaptureRequest{
+				Method:       "POST",
+				URL:          "/upload",
+				Header:       http.Header{"Content-Type": {"application/octet-stream"}},
+				Body:         binary,
+				BodyEncoding: encoding,
+			},
+			Response: &CaptureResponse{Status: 204},
+		},
+	}
+	cases := []struct {
+		Name      string
+		NewWriter func(*bytes.Buffer) CaptureWriter
+		Prefix    string
+	}{
+		{"json-lines", func(b *bytes.Buffer) CaptureWriter { return NewJSONLinesCaptureWriter(b) }, `{"started":`},
+		{"har", func(b *bytes.Buffer) CaptureWriter { return NewHARCaptureWriter(b) }, `{"log":{"version":"1.2"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := c.NewWriter(&buf)
+			for _, capture := range captures {
+				require.NoError(t, w.Write(capture))
+			}
+			require.NoError(t, w.Close())
+			assert.True(t, strings.HasPrefix(buf.String(), c.Prefix), buf.String())
+
+			got, err := ReadCaptures(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, captures, got)
+
+			b, err := got[1].Request.Bytes()
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0xff, 0xfe}, b)
+		})
+	}
+}
+
+func TestNewCaptureHeader(t *testing.T) {
+	h := http.Header{"Authorization": {"Bearer a", "Bearer b"}, "Link": {"</a>; rel=next", "</b>; rel=prev"}}
+	got := NewCaptureHeader(h, NewRedactor())
+	assert.Equal(t, http.Header{"Authorization": {"[REDACTED]"}, "Link": {"</a>; rel=next", "</b>; rel=prev"}}, got)
+	got["Link"][0] = "</c>"
+	assert.Equal(t, "</a>; rel=next", h["Link"][0], "capture header must not share values with h")
+}
+
+func TestCaptureWritersErrors(t *testing.T) {
+	capture := &Capture{Request: &CaptureRequest{Method: "GET", URL: "/"}, Response: &CaptureResponse{Status: 200}}
+	cases := []struct {
+		Name      string
+		NewWriter func(w *failingWriter) CaptureWriter
+	}{
+		{"json-lines", func(w *failingWriter) CaptureWriter { return NewJSONLinesCaptureWriter(w) }},
+		{"har", func(w *failingWriter) CaptureWriter { return NewHARCaptureWriter(w) }},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			fw := &failingWriter{}
+			w := c.NewWriter(fw)
+			require.NoError(t, w.Write(capture))
+			fw.err = errors.New("disk full")
+			assert.EqualError(t, w.Write(capture), "disk full")
+			fw.err = nil
+			assert.EqualError(t, w.Write(capture), "disk full")
+			assert.EqualError(t, w.Close(), "disk full")
+		})
+	}
+}
+
+func TestReadCapturesErrors(t *testing.T) {
+	_, err := ReadCaptures(strings.NewReader(`{"started":"2024-01-02T03:04:05Z"}`))
+	assert.ErrorContains(t, err, "missing request or response")
+	_, err = ReadCaptures(strings.NewReader(`{"started":`))
+	assert.ErrorContains(t, err, "failed to decode capture")
+}
+
+func TestEmptyHARCapture(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewHARCaptureWriter(&buf).Close())
+	got, err := ReadCaptures(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// failingWriter is an io.Writer that returns err if not nil.
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return len(b), nil
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -32,6 +33,9 @@ type (
 		Request *http.Request
 		// Response is the captured response.
 		Response *http.Response
+		// redactor masks sensitive values in printed requests and
+		// responses.
+		redactor *Redactor
 	}
 
 	// ClientError is an error returned by a HTTP service client.
@@ -52,6 +56,11 @@ type (
 		Fault bool
 		// The original error if any
 		Err error
+
+		// status and body are the status code and body of the
+		// unexpected response, see ErrorMapper.DecodeError.
+		status int
+		body   string
 	}
 )
 
@@ -60,9 +69,11 @@ func (c ClientError) Unwrap() error {
 }
 
 // NewDebugDoer wraps the given doer and captures the request and response so
-// they can be printed.
-func NewDebugDoer(d Doer) DebugDoer {
-	return &debugDoer{Doer: d}
+// they can be printed. The values of the headers listed in
+// DefaultSensitiveHeaders and of the headers, parameters and body fields
+// specified via the options are redacted when printed.
+func NewDebugDoer(d Doer, opts ...RedactOption) DebugDoer {
+	return &debugDoer{Doer: d, redactor: NewRedactor(opts...)}
 }
 
 // Do captures the request and response.
@@ -100,8 +111,12 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	if dd.Request == nil {
 		return
 	}
+	r := dd.redactor
+	if r == nil {
+		r = NewRedactor()
+	}
 	buf := &bytes.Buffer{}
-	buf.WriteString(fmt.Sprintf("> %s %s", dd.Request.Method, dd.Request.URL.String())) // nolint: errcheck
+	buf.WriteString(fmt.Sprintf("> %s %s", dd.Request.Method, r.URL(dd.Request.URL))) // nolint: errcheck
 
 	keys := make([]string, len(dd.Request.Header))
 	i := 0
@@ -111,14 +126,14 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		buf.WriteString(fmt.Sprintf("\n> %s: %s", k, strings.Join(dd.Request.Header[k], ", "))) // nolint: errcheck
+		buf.WriteString(fmt.Sprintf("\n> %s: %s", k, r.Header(k, dd.Request.Header[k]))) // nolint: errcheck
 	}
 
 	b, _ := io.ReadAll(dd.Request.Body)
 	if len(b) > 0 {
 		dd.Request.Body = io.NopCloser(bytes.NewBuffer(b)) // reset the request body
 		buf.WriteByte('\n')                                // nolint: errcheck
-		buf.Write(b)                                       // nolint: errcheck
+		buf.Write(r.Body(b))                               // nolint: errcheck
 	}
 
 	if dd.Response == nil {
@@ -135,14 +150,14 @@ func (dd *debugDoer) Fprint(w io.Writer) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		buf.WriteString(fmt.Sprintf("\n< %s: %s", k, strings.Join(dd.Response.Header[k], ", "))) // nolint: errcheck
+		buf.WriteString(fmt.Sprintf("\n< %s: %s", k, r.Header(k, dd.Response.Header[k]))) // nolint: errcheck
 	}
 
 	rb, _ := io.ReadAll(dd.Response.Body) // this is reading from a memory buffer so safe to ignore errors
 	if len(rb) > 0 {
 		dd.Response.Body = io.NopCloser(bytes.NewBuffer(rb)) // reset the response body
 		buf.WriteByte('\n')                                  // nolint: errcheck
-		buf.Write(rb)                                        // nolint: errcheck
+		buf.Write(r.Body(rb))                                // nolint: errcheck
 	}
 	w.Write(buf.Bytes())  // nolint: errcheck
 	w.Write([]byte{'\n'}) // nolint: errcheck
@@ -189,7 +204,8 @@ func ErrValidationError(svc, m string, err error) error {
 }
 
 // ErrInvalidResponse is the error returned when the service responded with an
-// unexpected response status code.
+// unexpected response status code. Use ErrorMapper.DecodeError to recover the
+// errors mapped by the service.
 func ErrInvalidResponse(svc, m string, code int, body string) error {
 	var b string
 	if body != "" {
@@ -210,7 +226,27 @@ func ErrInvalidResponse(svc, m string, code int, body string) error {
 		code == http.StatusBadGateway
 
 	return &ClientError{Name: "invalid_response", Message: msg, Service: svc, Method: m,
-		Temporary: temporary, Timeout: timeout, Fault: fault}
+		Temporary: temporary, Timeout: timeout, Fault: fault, status: code, body: body}
+}
+
+// mappedErrorBody returns the name and message of the error encoded in the
+// given response body if it is a JSON error response or problem details.
+func mappedErrorBody(body string) (name, message string, ok bool) {
+	if !strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return "", "", false
+	}
+	var resp struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil || resp.Name == "" {
+		return "", "", false
+	}
+	if resp.Message == "" {
+		resp.Message = resp.Detail
+	}
+	return resp.Name, resp.Message, true
 }
 
 // ErrRequestError is the error returned when the request fails to be sent.
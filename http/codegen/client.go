@@ -247,7 +247,7 @@ func isBearer(schemes []*service.SchemeData) bool {
 		if s.Name != "Authorization" {
 			continue
 		}
-		if s.Type == "JWT" || s.Type == "OAuth2" {
+		if s.Type == "JWT" || s.Type == "OAuth2" || s.Type == "OpenIDConnect" {
 			return true
 		}
 	}
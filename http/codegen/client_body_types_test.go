@@ -427,6 +427,8 @@ type ListSomethingWentWrongResponseBody struct {
 	Timeout *bool ` + "`" + `form:"timeout,omitempty" json:"timeout,omitempty" xml:"timeout,omitempty"` + "`" + `
 	// Is the error a server-side fault?
 	Fault *bool ` + "`" + `form:"fault,omitempty" json:"fault,omitempty" xml:"fault,omitempty"` + "`" + `
+	// Details contains additional machine-readable information about the error.
+	Details map[string]any ` + "`" + `form:"details,omitempty" json:"details,omitempty" xml:"details,omitempty"` + "`" + `
 }
 
 // NewListStreamingBody builds the HTTP request body from the payload of the
@@ -460,6 +462,14 @@ func NewListSomethingWentWrong(body *ListSomethingWentWrongResponseBody) *goa.Se
 		Timeout:   *body.Timeout,
 		Fault:     *body.Fault,
 	}
+	if body.Details != nil {
+		v.Details = make(map[string]any, len(body.Details))
+		for key, val := range body.Details {
+			tk := key
+			tv := val
+			v.Details[tk] = tv
+		}
+	}
 
 	return v
 }
@@ -528,6 +538,8 @@ type ListSomethingWentWrongResponseBody struct {
 	Timeout *bool ` + "`" + `form:"timeout,omitempty" json:"timeout,omitempty" xml:"timeout,omitempty"` + "`" + `
 	// Is the error a server-side fault?
 	Fault *bool ` + "`" + `form:"fault,omitempty" json:"fault,omitempty" xml:"fault,omitempty"` + "`" + `
+	// Details contains additional machine-readable information about the error.
+	Details map[string]any ` + "`" + `form:"details,omitempty" json:"details,omitempty" xml:"details,omitempty"` + "`" + `
 }
 
 // NewListStreamingBody builds the HTTP request body from the payload of the
@@ -561,6 +573,14 @@ func NewListSomethingWentWrong(body *ListSomethingWentWrongResponseBody) *goa.Se
 		Timeout:   *body.Timeout,
 		Fault:     *body.Fault,
 	}
+	if body.Details != nil {
+		v.Details = make(map[string]any, len(body.Details))
+		for key, val := range body.Details {
+			tk := key
+			tv := val
+			v.Details[tk] = tv
+		}
+	}
 
 	return v
 }
@@ -154,6 +154,21 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 	for _, cmd := range cliData {
 		sections = append(sections, cli.CommandUsage(cmd))
 	}
+	var svcs []*expr.HTTPServiceExpr
+	for _, name := range svr.Services {
+		if svc := root.API.HTTP.Service(name); svc != nil {
+			svcs = append(svcs, svc)
+		}
+	}
+	headers, fields := sensitiveNames(svcs)
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "redact-options",
+		Source: readTemplate("redact_options"),
+		Data: map[string]any{
+			"Headers": headers,
+			"Fields":  fields,
+		},
+	})
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -281,6 +296,86 @@ func streamFlag(svcn, en string) *cli.FlagData {
 	return cli.NewFlagData(svcn, en, "stream", "string", "path to file containing the streamed request body", true, "goa.png", nil)
 }
 
+// sensitiveNames returns the names of the headers, parameters and body fields
+// of the endpoints of the given services that are mapped to sensitive
+// attributes.
+func sensitiveNames(svcs []*expr.HTTPServiceExpr) (headers, fields []string) {
+	var (
+		seenHeaders = make(map[string]struct{})
+		seenFields  = make(map[string]struct{})
+		seenTypes   = make(map[string]struct{})
+		json        *expr.JSONPolicyExpr
+	)
+	addHeaders := func(ma *expr.MappedAttributeExpr) {
+		expr.WalkMappedAttr(ma, func(_, elem string, a *expr.AttributeExpr) error { // nolint: errcheck
+			if _, ok := seenHeaders[elem]; a.IsSensitive() && !ok {
+				seenHeaders[elem] = struct{}{}
+				headers = append(headers, elem)
+			}
+			return nil
+		})
+	}
+	addField := func(name string) {
+		if _, ok := seenFields[name]; !ok {
+			seenFields[name] = struct{}{}
+			fields = append(fields, name)
+		}
+	}
+	var addBody func(*expr.AttributeExpr)
+	addBody = func(att *expr.AttributeExpr) {
+		if att == nil {
+			return
+		}
+		switch dt := att.Type.(type) {
+		case expr.UserType:
+			if _, ok := seenTypes[dt.ID()]; ok {
+				return
+			}
+			seenTypes[dt.ID()] = struct{}{}
+			addBody(dt.Attribute())
+		case *expr.Object:
+			for _, nat := range *dt {
+				if nat.Attribute.IsSensitive() {
+					addField(codegen.JSONName(json, nat.Attribute, nat.Name))
+				}
+				addBody(nat.Attribute)
+			}
+		case *expr.Array:
+			addBody(dt.ElemType)
+		case *expr.Map:
+			addBody(dt.ElemType)
+		case *expr.Union:
+			for _, nat := range dt.Values {
+				addBody(nat.Attribute)
+			}
+		}
+	}
+	addResponse := func(resp *expr.HTTPResponseExpr) {
+		addHeaders(resp.Headers)
+		addBody(resp.Body)
+	}
+	for _, svc := range svcs {
+		json = expr.JSONPolicy(svc.ServiceExpr)
+		for _, e := range svc.HTTPEndpoints {
+			addHeaders(e.Headers)
+			expr.WalkMappedAttr(e.Params, func(_, elem string, a *expr.AttributeExpr) error { // nolint: errcheck
+				if a.IsSensitive() {
+					addField(elem)
+				}
+				return nil
+			})
+			addBody(e.Body)
+			for _, resp := range e.Responses {
+				addResponse(resp)
+			}
+			for _, herr := range e.HTTPErrors {
+				addResponse(herr.Response)
+			}
+		}
+	}
+	return
+}
+
 // streamingCmdExists returns true if at least one command in the list of commands
 // uses stream for sending payload/result.
 func streamingCmdExists(data []*commandData) bool {
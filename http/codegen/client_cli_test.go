@@ -50,6 +50,7 @@ func TestClientCLIFiles(t *testing.T) {
 		{"query-custom-name", testdata.PayloadQueryCustomNameDSL, testdata.PayloadQueryCustomNameBuildCode, 1, 1},
 		{"header-custom-name", testdata.PayloadHeaderCustomNameDSL, testdata.PayloadHeaderCustomNameBuildCode, 1, 1},
 		{"cookie-custom-name", testdata.PayloadCookieCustomNameDSL, testdata.PayloadCookieCustomNameBuildCode, 1, 1},
+		{"sensitive-redact-options", testdata.PayloadSensitiveDSL, testdata.PayloadSensitiveRedactOptionsCode, 0, 5},
 	}
 
 	for _, c := range cases {
@@ -21,6 +21,8 @@ func TestClientInit(t *testing.T) {
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.MultipleEndpointsClientInitCode, 2, 2},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingClientInitCode, 3, 2},
+		{"signature", testdata.ServerSignatureDSL, testdata.SignatureClientEndpointInitCode, 2, 3},
+		{"session", testdata.ServerSessionDSL, testdata.SessionClientEndpointInitCode, 2, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
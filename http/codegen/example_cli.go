@@ -61,6 +61,9 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{Path: genpkg + "/http/cli/" + svrdata.Dir, Name: "cli"},
 		{Path: rootPath, Name: apiPkg},
 	}
+	if svrdata.CookieJar {
+		specs = append(specs, &codegen.ImportSpec{Path: "net/http/cookiejar"})
+	}
 
 	var svcData []*ServiceData
 	for _, svc := range svr.Services {
@@ -73,6 +76,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 		{
 			Name:   "cli-http-start",
 			Source: readTemplate("cli_start"),
+			Data:   svrdata,
 		},
 		{
 			Name:   "cli-http-streaming",
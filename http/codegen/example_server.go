@@ -8,6 +8,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 )
 
@@ -41,7 +42,9 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		codegen.GoaNamedImport("http", "goahttp"),
 		{Path: "goa.design/clue/debug"},
 		{Path: "goa.design/clue/log"},
+		{Path: "log/slog"},
 		codegen.GoaImport("middleware"),
+		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
 		{Path: "github.com/gorilla/websocket"},
 	}
 
@@ -80,6 +83,7 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			svcdata = append(svcdata, data)
 		}
 	}
+	slog := service.ExampleSlog(root)
 
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
@@ -97,6 +101,9 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		{
 			Name:   "server-http-mux",
 			Source: readTemplate("server_mux"),
+			Data: map[string]any{
+				"Slog": slog,
+			},
 		},
 		{
 			Name:   "server-http-init",
@@ -110,17 +117,25 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		{
 			Name:   "server-http-middleware",
 			Source: readTemplate("server_middleware"),
+			Data: map[string]any{
+				"Services": svcdata,
+				"Slog":     slog,
+			},
 		},
 		{
 			Name:   "server-http-end",
 			Source: readTemplate("server_end"),
 			Data: map[string]any{
 				"Services": svcdata,
+				"Slog":     slog,
 			},
 		},
 		{
 			Name:   "server-http-errorhandler",
 			Source: readTemplate("server_error_handler"),
+			Data: map[string]any{
+				"Slog": slog,
+			},
 		},
 	}
 
@@ -90,6 +90,7 @@ func TestExampleServerFiles(t *testing.T) {
 			{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL},
 			{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL},
 			{"streaming", testdata.StreamingMultipleServicesDSL},
+			{"slog-logger", ctestdata.SlogLoggerDSL},
 		}
 		for _, c := range cases {
 			t.Run(c.Name, func(t *testing.T) {
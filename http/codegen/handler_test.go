@@ -27,6 +27,10 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
+		{"problem details", testdata.ServerProblemDetailsDSL, testdata.ServerProblemDetailsHandlerConstructorCode},
+		{"mutual tls", testdata.ServerMutualTLSDSL, testdata.ServerMutualTLSHandlerConstructorCode},
+		{"signature", testdata.ServerSignatureDSL, testdata.ServerSignatureHandlerConstructorCode},
+		{"session", testdata.ServerSessionDSL, testdata.ServerSessionHandlerConstructorCode},
 		{"skip response body encode decode", testdata.ServerSkipResponseBodyEncodeDecodeDSL, testdata.ServerSkipResponseBodyEncodeDecodeCode},
 	}
 	for _, c := range cases {
@@ -1,6 +1,11 @@
 package openapi
 
-import "goa.design/goa/v3/expr"
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
 
 // ExternalDocs represents an OpenAPI External Documentation object as defined in
 // https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.3.md#externalDocumentationObject
@@ -21,3 +26,19 @@ func DocsFromExpr(docs *expr.DocsExpr, meta expr.MetaExpr) *ExternalDocs {
 		Extensions:  ExtensionsFromExpr(meta),
 	}
 }
+
+// PolicyDescription appends the list of authorization policies of the given
+// method to description.
+func PolicyDescription(description string, m *expr.MethodExpr) string {
+	if len(m.Policies) == 0 {
+		return description
+	}
+	lines := make([]string, len(m.Policies))
+	for i, p := range m.Policies {
+		lines[i] = fmt.Sprintf("  * `%s`", p)
+	}
+	if description != "" {
+		description += "\n"
+	}
+	return description + fmt.Sprintf("\n**Authorization policies**:\n%s", strings.Join(lines, "\n"))
+}
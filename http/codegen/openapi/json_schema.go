@@ -28,6 +28,7 @@ type (
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
 		ReadOnly  bool    `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+		WriteOnly bool    `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
 		PathStart string  `json:"pathStart,omitempty" yaml:"pathStart,omitempty"`
 		Links     []*Link `json:"links,omitempty" yaml:"links,omitempty"`
 		Ref       string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
@@ -105,6 +106,10 @@ const SchemaRef = "http://json-schema.org/draft-04/hyper-schema"
 var (
 	// Definitions contains the generated JSON schema definitions
 	Definitions map[string]*Schema
+
+	// JSONPolicy is the JSON serialization policy of the service whose
+	// definitions are being generated. The API level policy applies if nil.
+	JSONPolicy *expr.JSONPolicyExpr
 )
 
 // Initialize the global variables
@@ -171,6 +176,8 @@ func GenerateServiceDefinition(api *expr.APIExpr, res *expr.HTTPServiceExpr) {
 	s.Type = Object
 	s.Title = res.Name()
 	Definitions[res.Name()] = s
+	JSONPolicy = expr.JSONPolicy(res.ServiceExpr)
+	defer func() { JSONPolicy = nil }()
 	for _, a := range res.HTTPEndpoints {
 		var requestSchema *Schema
 		if a.MethodExpr.Payload.Type != expr.Empty {
@@ -352,7 +359,15 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 			}
 			prop := NewSchema()
 			buildAttributeSchema(api, prop, nat.Attribute)
-			s.Properties[nat.Name] = prop
+			if prop.Ref == "" && jsonPolicy().EncodeAsString(nat.Attribute) {
+				// The Go ",string" tag option only applies to scalar
+				// fields, see expr.JSONPolicyExpr.EncodeAsString.
+				prop.Type = String
+				if prop.Example != nil {
+					prop.Example = fmt.Sprint(prop.Example)
+				}
+			}
+			s.Properties[jsonName(nat.Attribute, nat.Name)] = prop
 		}
 	case *expr.Map:
 		s.Type = Object
@@ -447,6 +462,7 @@ func (s *Schema) Dup() *Schema {
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
+		WriteOnly:            s.WriteOnly,
 		PathStart:            s.PathStart,
 		Links:                s.Links,
 		Ref:                  s.Ref,
@@ -484,8 +500,9 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	}
 	s.DefaultValue = ToStringMap(at.DefaultValue)
 	s.Description = at.Description
-	s.Example = at.Example(api.ExampleGenerator)
+	s.Example = codegen.JSONExample(jsonPolicy(), at, at.Example(api.ExampleGenerator))
 	s.Extensions = ExtensionsFromExpr(at.Meta)
+	s.ReadOnly = at.IsReadOnly()
 	initAttributeValidation(s, at)
 
 	return s
@@ -529,13 +546,34 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 		}
 	}
 	for _, v := range val.Required {
-		if a := at.Find(v); a != nil {
+		a := at.Find(v)
+		if a != nil {
 			if !MustGenerate(a.Meta) {
 				continue
 			}
 		}
-		s.Required = append(s.Required, v)
+		s.Required = append(s.Required, jsonName(a, v))
+	}
+}
+
+// jsonPolicy returns the JSON serialization policy that applies to the
+// definitions being generated.
+func jsonPolicy() *expr.JSONPolicyExpr {
+	if JSONPolicy != nil {
+		return JSONPolicy
 	}
+	return expr.JSONPolicy(nil)
+}
+
+// jsonName returns the name of the JSON schema property that corresponds to
+// the attribute with the given name according to the JSON serialization
+// policy in effect.
+func jsonName(att *expr.AttributeExpr, name string) string {
+	p := jsonPolicy()
+	if p.IsDefault() {
+		return name
+	}
+	return codegen.JSONName(p, att, name)
 }
 
 // toSchemaHrefs produces hrefs that replace the path wildcards with JSON
@@ -593,3 +631,41 @@ func MustGenerate(meta expr.MetaExpr) bool {
 	}
 	return true
 }
+
+// ProblemDetailsSchema returns the schema of the RFC 9457 problem details
+// written by goahttp.NewProblemDetails.
+func ProblemDetailsSchema() *Schema {
+	prop := func(t Type, format, desc string) *Schema {
+		s := NewSchema()
+		s.Type = t
+		s.Format = format
+		s.Description = desc
+		return s
+	}
+	violation := prop(Object, "", "Validation failure")
+	violation.Properties["path"] = prop(String, "", "JSON pointer to the offending field")
+	violation.Properties["code"] = prop(String, "", "Name of the validation rule that failed")
+	violation.Properties["message"] = prop(String, "", "Description of the failure")
+	violation.Properties["params"] = prop(Object, "", "Parameters of the validation rule")
+	violation.Properties["params"].AdditionalProperties = true
+	violation.Required = []string{"path", "code", "message"}
+	violations := prop(Array, "", "Validation failures that caused the error")
+	violations.Items = violation
+
+	s := prop(Object, "", "RFC 9457 problem details")
+	s.Properties["type"] = prop(String, "uri-reference", "URI reference that identifies the problem type")
+	s.Properties["title"] = prop(String, "", "Short summary of the problem type")
+	s.Properties["status"] = prop(Integer, "int32", "HTTP status code")
+	s.Properties["detail"] = prop(String, "", "Explanation specific to this occurrence of the problem")
+	s.Properties["instance"] = prop(String, "uri-reference", "URI reference that identifies this occurrence of the problem")
+	s.Properties["name"] = prop(String, "", "Name of the error")
+	s.Properties["temporary"] = prop(Boolean, "", "Is the error temporary?")
+	s.Properties["timeout"] = prop(Boolean, "", "Is the error a timeout?")
+	s.Properties["fault"] = prop(Boolean, "", "Is the error a server-side fault?")
+	s.Properties["violations"] = violations
+	s.Properties["details"] = prop(Object, "", "Additional machine-readable information about the error")
+	s.Properties["details"].AdditionalProperties = true
+	s.Required = []string{"type", "title", "status", "name", "temporary", "timeout", "fault"}
+	s.AdditionalProperties = true
+	return s
+}
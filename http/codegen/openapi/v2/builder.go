@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -66,6 +67,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 		if !openapi.MustGenerate(res.Meta) || !openapi.MustGenerate(res.ServiceExpr.Meta) {
 			continue
 		}
+		openapi.JSONPolicy = expr.JSONPolicy(res.ServiceExpr)
 		for k, v := range openapi.ExtensionsFromExpr(res.Meta) {
 			s.Paths[k] = v
 		}
@@ -84,6 +86,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 			}
 		}
 	}
+	openapi.JSONPolicy = nil
 	if len(openapi.Definitions) > 0 {
 		s.Definitions = make(map[string]*openapi.Schema)
 		for n, d := range openapi.Definitions {
@@ -135,6 +138,21 @@ func addScopeDescription(scopes []*expr.ScopeExpr, sd *SecurityDefinition) {
 	}
 }
 
+// addSignatureDescription adds the list of request components covered by the
+// signature of a request signature scheme to the description.
+func addSignatureDescription(components []string, sd *SecurityDefinition) {
+	lines := make([]string, len(components))
+	for i, c := range components {
+		lines[i] = fmt.Sprintf("  * `%s`", c)
+	}
+	if len(lines) > 0 {
+		if sd.Description != "" {
+			sd.Description += "\n"
+		}
+		sd.Description += fmt.Sprintf("\n**Signed Components**:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
 // securitySpecFromExpr generates the OpenAPI security definitions from the
 // security design.
 func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
@@ -143,6 +161,11 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 		for _, e := range svc.HTTPEndpoints {
 			for _, req := range e.Requirements {
 				for _, s := range req.Schemes {
+					if s.Kind == expr.MutualTLSKind || s.Kind == expr.SessionKind {
+						// OpenAPI V2 spec does not support mutual TLS
+						// nor API keys located in cookies.
+						continue
+					}
 					sd := SecurityDefinition{
 						Description: s.Description,
 						Extensions:  openapi.ExtensionsFromExpr(s.Meta),
@@ -157,10 +180,18 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 						sd.In = s.In
 						sd.Name = s.Name
 						addScopeDescription(s.Scopes, &sd)
-					case expr.JWTKind:
+					case expr.JWTKind, expr.OpenIDConnectKind:
+						sd.Type = "apiKey"
+						// OpenAPI V2 spec does not support JWT and OpenID Connect schemes.
+						// Hence we add the scheme information to the description.
+						addScopeDescription(s.Scopes, &sd)
+						sd.In = s.In
+						sd.Name = s.Name
+					case expr.SignatureKind:
 						sd.Type = "apiKey"
-						// OpenAPI V2 spec does not support JWT scheme. Hence we add the scheme
-						// information to the description.
+						// OpenAPI V2 spec does not support request signatures.
+						// Hence we add the signed components to the description.
+						addSignatureDescription(s.SignedComponents, &sd)
 						addScopeDescription(s.Scopes, &sd)
 						sd.In = s.In
 						sd.Name = s.Name
@@ -402,6 +433,29 @@ func responseSpecFromExpr(_ *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	}
 }
 
+// problemJSONContentType is the media type of RFC 9457 problem details.
+const problemJSONContentType = "application/problem+json"
+
+// problemResponseSpec returns the response spec of an error response written
+// as RFC 9457 problem details.
+func problemResponseSpec(r *expr.HTTPResponseExpr) *Response {
+	if _, ok := openapi.Definitions["ProblemDetails"]; !ok {
+		openapi.Definitions["ProblemDetails"] = openapi.ProblemDetailsSchema()
+	}
+	schema := openapi.NewSchema()
+	schema.Ref = "#/definitions/ProblemDetails"
+	desc := r.Description
+	if desc == "" {
+		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
+	}
+	return &Response{
+		Description: desc,
+		Schema:      schema,
+		Headers:     headersFromExpr(r.Headers),
+		Extensions:  openapi.ExtensionsFromExpr(r.Meta),
+	}
+}
+
 func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 	if headers == nil {
 		return nil
@@ -534,6 +588,13 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			}
 		}
 		for _, er := range endpoint.HTTPErrors {
+			if endpoint.Service.UsesProblemDetails() && er.Type == expr.ErrorResult {
+				responses[strconv.Itoa(er.Response.StatusCode)] = problemResponseSpec(er.Response)
+				if !slices.Contains(produces, problemJSONContentType) {
+					produces = append(produces, problemJSONContentType)
+				}
+				continue
+			}
 			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
@@ -595,17 +656,20 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		description := endpoint.Description()
 
-		requirements := make([]map[string][]string, len(endpoint.Requirements))
-		for i, req := range endpoint.Requirements {
+		requirements := make([]map[string][]string, 0, len(endpoint.Requirements))
+		for _, req := range endpoint.Requirements {
 			requirement := make(map[string][]string)
 			for _, s := range req.Schemes {
+				if s.Kind == expr.MutualTLSKind || s.Kind == expr.SessionKind {
+					continue
+				}
 				requirement[s.Hash()] = []string{}
 				switch s.Kind {
 				case expr.OAuth2Kind:
 					if len(req.Scopes) > 0 {
 						requirement[s.Hash()] = req.Scopes
 					}
-				case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind:
+				case expr.BasicAuthKind, expr.APIKeyKind, expr.JWTKind, expr.OpenIDConnectKind, expr.SignatureKind:
 					lines := make([]string, 0, len(req.Scopes))
 					for _, scope := range req.Scopes {
 						lines = append(lines, fmt.Sprintf("  * `%s`", scope))
@@ -619,8 +683,15 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 					}
 				}
 			}
-			requirements[i] = requirement
+			if len(requirement) == 0 {
+				// Requirement only lists schemes that cannot be
+				// described in OpenAPI V2 (e.g. mutual TLS or
+				// cookie sessions).
+				continue
+			}
+			requirements = append(requirements, requirement)
 		}
+		description = openapi.PolicyDescription(description, endpoint.MethodExpr)
 		_, deprecated := endpoint.MethodExpr.Meta.Last("openapi:deprecated")
 		operation := &Operation{
 			Tags:         tagNames,
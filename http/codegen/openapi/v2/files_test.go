@@ -38,6 +38,10 @@ func TestSections(t *testing.T) {
 		{"multiple-views", testdata.MultipleViewsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
+		{"security-openid-mutual-tls", testdata.OpenIDConnectMutualTLSSecurityDSL},
+		{"security-signature", testdata.SignatureSecurityDSL},
+		{"security-session", testdata.SessionSecurityDSL},
+		{"authorization-policy", testdata.PolicyDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
@@ -53,6 +57,7 @@ func TestSections(t *testing.T) {
 		{"json-prefix", testdata.JSONPrefixDSL},
 		{"json-indent", testdata.JSONIndentDSL},
 		{"json-prefix-indent", testdata.JSONPrefixIndentDSL},
+		{"json-policy", testdata.JSONPolicyDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -315,6 +315,13 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 					content.Example = nil
 				}
 			}
+			if svc.UsesProblemDetails() && er.Type == expr.ErrorResult {
+				content := make(map[string]*MediaType, len(resp.Content))
+				for ct, c := range resp.Content {
+					content[problemContentType(ct)] = c
+				}
+				resp.Content = content
+			}
 			responses[strconv.Itoa(er.Response.StatusCode)] = &ResponseRef{Value: resp}
 		}
 	}
@@ -344,7 +351,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	return &Operation{
 		Tags:         tagNames,
 		Summary:      summary,
-		Description:  e.Description(),
+		Description:  openapi.PolicyDescription(e.Description(), m),
 		OperationID:  parseOperationIDTemplate(operationIDFormat, svc.Name(), e.Name(), routeIndex),
 		Parameters:   params,
 		RequestBody:  requestBody,
@@ -552,7 +559,7 @@ func buildSecurityRequirements(reqs []*expr.SecurityExpr) []map[string][]string
 		for _, sch := range req.Schemes {
 			scopes := make([]string, 0)
 			switch sch.Kind {
-			case expr.OAuth2Kind, expr.JWTKind:
+			case expr.OAuth2Kind, expr.JWTKind, expr.OpenIDConnectKind:
 				if len(req.Scopes) > 0 {
 					scopes = req.Scopes
 				}
@@ -591,6 +598,62 @@ func buildSecurityScheme(se *expr.SchemeExpr) *SecurityScheme {
 			Description: se.Description,
 			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
 		}
+	case expr.OpenIDConnectKind:
+		scheme = &SecurityScheme{
+			Type:             "openIdConnect",
+			Description:      se.Description,
+			OpenIDConnectURL: se.DiscoveryURL,
+			Extensions:       openapi.ExtensionsFromExpr(se.Meta),
+		}
+	case expr.MutualTLSKind:
+		// The mutualTLS type was introduced in OpenAPI 3.1.
+		scheme = &SecurityScheme{
+			Type:        "mutualTLS",
+			Description: se.Description,
+			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
+		}
+	case expr.SignatureKind:
+		// OpenAPI does not support request signatures, describe the
+		// signature header as an API key and list the signed components.
+		desc := se.Description
+		if len(se.SignedComponents) > 0 {
+			lines := make([]string, len(se.SignedComponents))
+			for i, c := range se.SignedComponents {
+				lines[i] = fmt.Sprintf("  * `%s`", c)
+			}
+			if desc != "" {
+				desc += "\n"
+			}
+			desc += fmt.Sprintf("\n**Signed Components**:\n%s", strings.Join(lines, "\n"))
+		}
+		scheme = &SecurityScheme{
+			Type:        "apiKey",
+			Description: desc,
+			In:          se.In,
+			Name:        se.Name,
+			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
+		}
+	case expr.SessionKind:
+		// OpenAPI does not support CSRF protection, describe it in the
+		// scheme description.
+		desc := se.Description
+		if se.CSRF != "" {
+			if desc != "" {
+				desc += "\n"
+			}
+			desc += fmt.Sprintf("\n**CSRF Protection** (%s): requests made with unsafe methods must include the CSRF token in the `%s` header", se.CSRF, se.CSRFHeader)
+			if se.CSRF == expr.CSRFDoubleSubmit {
+				desc += fmt.Sprintf(" set to the value of the `%s` cookie", se.CSRFCookie)
+			}
+			desc += "."
+		}
+		scheme = &SecurityScheme{
+			Type:        "apiKey",
+			Description: desc,
+			In:          "cookie",
+			Name:        se.Name,
+			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
+		}
 	case expr.OAuth2Kind:
 		scopes := make(map[string]string, len(se.Scopes))
 		for _, scope := range se.Scopes {
@@ -680,3 +743,12 @@ func buildTags(api *expr.APIExpr) []*openapi.Tag {
 	}
 	return tags
 }
+
+// problemContentType returns the problem details media type that corresponds
+// to the given error response media type.
+func problemContentType(ct string) string {
+	if ct == "application/xml" || strings.HasSuffix(ct, "+xml") {
+		return "application/problem+xml"
+	}
+	return "application/problem+json"
+}
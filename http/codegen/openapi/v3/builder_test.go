@@ -460,3 +460,95 @@ func matchesHeader(t *testing.T, h *HeaderRef, types map[string]*openapi.Schema,
 	}}
 	matchesParameterHeader(t, par, types, expected, "header")
 }
+
+func TestBuildOperationProblemDetails(t *testing.T) {
+	const svcName = "test service"
+	api := codegen.RunDSL(t, dsls.ProblemDetailsErrors(svcName, "problem_details")).API
+	bds, types := buildBodyTypes(api)
+	e := api.HTTP.Services[0].HTTPEndpoints[0]
+
+	op := buildOperation("problem_details", e.Routes[0], bds[svcName]["problem_details"], expr.NewRandom("problem_details"))
+
+	bad := op.Responses["400"].Value
+	if _, ok := bad.Content["application/json"]; ok {
+		t.Error("got application/json content for bad_request, expected application/problem+json only")
+	}
+	ct, ok := bad.Content["application/problem+json"]
+	if !ok {
+		t.Fatal("missing application/problem+json content for bad_request")
+	}
+	if ct.Schema.Ref != toRef("ProblemDetails") {
+		t.Errorf("got schema ref %q for bad_request, expected %q", ct.Schema.Ref, toRef("ProblemDetails"))
+	}
+	pd, ok := types["ProblemDetails"]
+	if !ok {
+		t.Fatal("missing ProblemDetails schema")
+	}
+	for _, n := range []string{"type", "title", "status", "detail", "instance", "violations"} {
+		if _, ok := pd.Properties[n]; !ok {
+			t.Errorf("missing ProblemDetails property %q", n)
+		}
+	}
+	custom := op.Responses["409"].Value
+	if _, ok := custom.Content["application/json"]; !ok {
+		t.Error("missing application/json content for custom error")
+	}
+}
+
+func TestBuildOperationErrorDetails(t *testing.T) {
+	const svcName = "test service"
+	api := codegen.RunDSL(t, dsls.TypedErrorDetails(svcName, "error_details")).API
+	bds, _ := buildBodyTypes(api)
+	e := api.HTTP.Services[0].HTTPEndpoints[0]
+
+	op := buildOperation("error_details", e.Routes[0], bds[svcName]["error_details"], expr.NewRandom("error_details"))
+
+	bad := op.Responses["400"].Value.Content["application/vnd.goa.error"]
+	if bad == nil {
+		t.Fatal("missing application/vnd.goa.error content for bad_request")
+	}
+	if bad.Schema.Ref != toRef("Error") {
+		t.Errorf("got schema ref %q for bad_request, expected %q", bad.Schema.Ref, toRef("Error"))
+	}
+	quota := op.Responses["429"].Value.Content["application/vnd.goa.error"]
+	if quota == nil {
+		t.Fatal("missing application/vnd.goa.error content for quota_exceeded")
+	}
+	if quota.Schema.Ref != "" {
+		t.Fatalf("got schema ref %q for quota_exceeded, expected inline schema", quota.Schema.Ref)
+	}
+	details, ok := quota.Schema.Properties["details"]
+	if !ok {
+		t.Fatal("missing details property for quota_exceeded")
+	}
+	if details.Ref != toRef("QuotaDetails") {
+		t.Errorf("got details ref %q, expected %q", details.Ref, toRef("QuotaDetails"))
+	}
+}
+
+func TestBuildSecurityScheme(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Scheme   *expr.SchemeExpr
+		Expected *SecurityScheme
+	}{
+		{
+			Name:     "openid-connect",
+			Scheme:   &expr.SchemeExpr{Kind: expr.OpenIDConnectKind, Description: "oidc", DiscoveryURL: "https://goa.design/.well-known/openid-configuration"},
+			Expected: &SecurityScheme{Type: "openIdConnect", Description: "oidc", OpenIDConnectURL: "https://goa.design/.well-known/openid-configuration"},
+		},
+		{
+			Name:     "mutual-tls",
+			Scheme:   &expr.SchemeExpr{Kind: expr.MutualTLSKind, Description: "mtls"},
+			Expected: &SecurityScheme{Type: "mutualTLS", Description: "mtls"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			s := buildSecurityScheme(c.Scheme)
+			if s.Type != c.Expected.Type || s.Description != c.Expected.Description || s.OpenIDConnectURL != c.Expected.OpenIDConnectURL {
+				t.Errorf("got %+v, expected %+v", s, c.Expected)
+			}
+		})
+	}
+}
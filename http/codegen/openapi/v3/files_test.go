@@ -39,6 +39,9 @@ func TestFiles(t *testing.T) {
 		{"multiple-views", testdata.MultipleViewsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
+		{"security-signature", testdata.SignatureSecurityDSL},
+		{"security-session", testdata.SessionSecurityDSL},
+		{"authorization-policy", testdata.PolicyDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
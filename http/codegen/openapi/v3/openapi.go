@@ -217,14 +217,15 @@ type (
 	// SecurityScheme represents an OpenAPI SecurityScheme object as defined in
 	// https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.3.md#securitySchemeObject
 	SecurityScheme struct {
-		Type         string         `json:"type,omitempty" yaml:"type,omitempty"`
-		Description  string         `json:"description,omitempty" yaml:"description,omitempty"`
-		Name         string         `json:"name,omitempty" yaml:"name,omitempty"`
-		In           string         `json:"in,omitempty" yaml:"in,omitempty"`
-		Scheme       string         `json:"scheme,omitempty" yaml:"scheme,omitempty"`
-		BearerFormat string         `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
-		Flows        *OAuthFlows    `json:"flows,omitempty" yaml:"flows,omitempty"`
-		Extensions   map[string]any `json:"-" yaml:"-"`
+		Type             string         `json:"type,omitempty" yaml:"type,omitempty"`
+		Description      string         `json:"description,omitempty" yaml:"description,omitempty"`
+		Name             string         `json:"name,omitempty" yaml:"name,omitempty"`
+		In               string         `json:"in,omitempty" yaml:"in,omitempty"`
+		Scheme           string         `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+		BearerFormat     string         `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+		Flows            *OAuthFlows    `json:"flows,omitempty" yaml:"flows,omitempty"`
+		OpenIDConnectURL string         `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
+		Extensions       map[string]any `json:"-" yaml:"-"`
 	}
 
 	// OAuthFlows represents an OpenAPI OAuthFlows object as defined in
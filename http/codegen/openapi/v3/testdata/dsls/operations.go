@@ -274,3 +274,45 @@ var OperationIDMultipleRoutes = func(svc, met, tmpl string) func() {
 		})
 	}
 }
+
+var ProblemDetailsErrors = func(svc, met string) func() {
+	return func() {
+		var CustomError = Type("CustomError", func() {
+			Attribute("reason", String)
+		})
+		var _ = Service(svc, func() {
+			ProblemDetails()
+			Method(met, func() {
+				Error("bad_request")
+				Error("custom", CustomError)
+				HTTP(func() {
+					GET("/")
+					Response("bad_request", StatusBadRequest)
+					Response("custom", StatusConflict)
+				})
+			})
+		})
+	}
+}
+
+var TypedErrorDetails = func(svc, met string) func() {
+	return func() {
+		var QuotaDetails = Type("QuotaDetails", func() {
+			Attribute("limit", Int)
+			Attribute("retry_after", Int)
+		})
+		var _ = Service(svc, func() {
+			Method(met, func() {
+				Error("bad_request")
+				Error("quota_exceeded", func() {
+					Details(QuotaDetails)
+				})
+				HTTP(func() {
+					GET("/")
+					Response("bad_request", StatusBadRequest)
+					Response("quota_exceeded", StatusTooManyRequests)
+				})
+			})
+		})
+	}
+}
@@ -212,3 +212,70 @@ func ForcedResultTypeDSL(svcName, metName string) func() {
 		})
 	}
 }
+
+func ReadOnlyWriteOnlyDSL(svcName, metName string) func() {
+	return func() {
+		var Account = Type("Account", func() {
+			Attribute("id", String, func() {
+				ReadOnly()
+			})
+			Attribute("name", String)
+			Attribute("password", String, func() {
+				WriteOnly()
+			})
+		})
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(Account)
+				Result(Account)
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
+func JSONPolicyDSL(svcName, metName string) func() {
+	return func() {
+		var Account = Type("Account", func() {
+			Attribute("firstName", String)
+			Attribute("accountID", Int64)
+			Attribute("ids", ArrayOf(Int64))
+			Attribute("custom", String, func() {
+				Meta("struct:tag:json", "cust")
+			})
+			Required("firstName")
+		})
+		var _ = Service(svcName, func() {
+			JSONNaming(SnakeCase)
+			JSONInt64AsString(true)
+			Method(metName, func() {
+				Payload(Account)
+				Result(Account)
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
+func StrictDecodingDSL(svcName, metName string) func() {
+	return func() {
+		var Person = Type("Person", func() {
+			Attribute("name", String)
+			Attribute("age", Int)
+		})
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				StrictDecoding()
+				Payload(Person)
+				Result(Person)
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
@@ -35,6 +35,15 @@ type (
 		// type names indexed by hashes
 		hashes map[uint64][]string
 		rand   *expr.ExampleGenerator
+		// json is the JSON serialization policy of the bodies being
+		// schemafied.
+		json *expr.JSONPolicyExpr
+		// strict is true when schemafying request bodies of methods
+		// that use strict decoding.
+		strict bool
+		// problemRef is the reference to the problem details schema,
+		// empty until a service uses the problem details error format.
+		problemRef string
 	}
 )
 
@@ -44,6 +53,7 @@ func newSchemafier(rand *expr.ExampleGenerator) *schemafier {
 		schemas: make(map[string]*openapi.Schema),
 		hashes:  make(map[uint64][]string),
 		rand:    rand,
+		json:    expr.JSONPolicy(nil),
 	}
 }
 
@@ -82,12 +92,14 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 			continue
 		}
 
+		sf.json = expr.JSONPolicy(s.ServiceExpr)
 		sbodies := make(map[string]*EndpointBodies, len(s.HTTPEndpoints))
 		for _, e := range s.HTTPEndpoints {
 			if !openapi.MustGenerate(e.Meta) || !openapi.MustGenerate(e.MethodExpr.Meta) {
 				continue
 			}
 
+			sf.strict = e.MethodExpr.IsStrictDecoding()
 			req := sf.schemafy(e.Body)
 			if e.StreamingBody != nil {
 				sreq := sf.schemafy(e.StreamingBody)
@@ -110,12 +122,37 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 					req.Description += fmt.Sprintf("Streaming body: %s", note)
 				}
 			}
+			sf.strict = false
 			res := make(map[int][]*openapi.Schema)
 			resps := e.Responses
+			problems := make(map[*expr.HTTPResponseExpr]struct{})
+			details := make(map[*expr.HTTPResponseExpr]expr.UserType)
 			for _, er := range e.HTTPErrors {
 				resps = append(resps, er.Response)
+				if s.UsesProblemDetails() && er.Type == expr.ErrorResult {
+					problems[er.Response] = struct{}{}
+				}
+				if dt := er.ErrorExpr.DetailsType(); dt != nil {
+					details[er.Response] = dt
+				}
 			}
 			for _, resp := range resps {
+				if _, ok := problems[resp]; ok {
+					js := sf.problemDetails()
+					if dt, ok := details[resp]; ok {
+						js = sf.withDetails(openapi.ProblemDetailsSchema(), dt)
+					}
+					res[resp.StatusCode] = append(res[resp.StatusCode], js)
+					continue
+				}
+				if dt, ok := details[resp]; ok && resp.Body.Type != expr.Empty {
+					att := resp.Body
+					if ut, ok := att.Type.(expr.UserType); ok {
+						att = ut.Attribute()
+					}
+					res[resp.StatusCode] = append(res[resp.StatusCode], sf.withDetails(sf.schemafy(att, true), dt))
+					continue
+				}
 				var view string
 				if v, ok := resp.Body.Meta.Last(expr.ViewMetaKey); ok {
 					view = v
@@ -194,7 +231,19 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			if !openapi.MustGenerate(nat.Attribute.Meta) {
 				continue
 			}
-			s.Properties[nat.Name] = sf.schemafy(nat.Attribute)
+			ps := sf.schemafy(nat.Attribute)
+			if ps.Ref == "" && sf.json.EncodeAsString(nat.Attribute) {
+				// The Go ",string" tag option only applies to scalar
+				// fields, see expr.JSONPolicyExpr.EncodeAsString.
+				ps.Type = openapi.Type("string")
+				if ps.Example != nil {
+					ps.Example = fmt.Sprint(ps.Example)
+				}
+			}
+			s.Properties[sf.jsonName(nat.Attribute, nat.Name)] = ps
+		}
+		if sf.strict {
+			s.AdditionalProperties = false
 		}
 		if len(itemNotes) > 0 {
 			note = strings.Join(itemNotes, "\n")
@@ -218,6 +267,15 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			return sf.schemafy(t.Attribute())
 		}
 		h := sf.hashAttribute(attr, fnv.New64())
+		if !sf.json.IsDefault() {
+			// Types serialized with different JSON policies produce
+			// different schemas.
+			h = orderedHash(h, hashString(fmt.Sprintf("%+v", *sf.json), fnv.New64()), fnv.New64())
+		}
+		if sf.strict {
+			// Strict request bodies do not allow additional properties.
+			h = orderedHash(h, hashString("strict", fnv.New64()), fnv.New64())
+		}
 
 		var metaName string
 		if n, ok := t.Attribute().Meta["openapi:typename"]; ok {
@@ -260,8 +318,10 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 
 	// Default value, example, extensions
 	s.DefaultValue = toStringMap(attr.DefaultValue)
-	s.Example = attr.Example(sf.rand)
+	s.Example = codegen.JSONExample(sf.json, attr, attr.Example(sf.rand))
 	s.Extensions = openapi.ExtensionsFromExpr(attr.Meta)
+	s.ReadOnly = attr.IsReadOnly()
+	s.WriteOnly = attr.IsWriteOnly()
 
 	// Validations
 	val := attr.Validation
@@ -305,12 +365,22 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 				continue
 			}
 		}
-		s.Required = append(s.Required, v)
+		s.Required = append(s.Required, sf.jsonName(attr.Find(v), v))
 	}
 
 	return s
 }
 
+// jsonName returns the name of the JSON schema property that corresponds to
+// the attribute with the given name. The attribute names are used as is
+// unless a JSON serialization policy is defined.
+func (sf *schemafier) jsonName(att *expr.AttributeExpr, name string) string {
+	if sf.json.IsDefault() {
+		return name
+	}
+	return codegen.JSONName(sf.json, att, name)
+}
+
 // uniquify returns n if n is not a known type name. Otherwise uniquify appends
 // the smallest integer greater than 1 to n so the result is not a known type
 // name.
@@ -327,6 +397,29 @@ func (sf *schemafier) uniquify(n string) string {
 	return n
 }
 
+// problemDetails returns a reference to the RFC 9457 problem details schema.
+// The schema is added to the components the first time it is referenced.
+func (sf *schemafier) problemDetails() *openapi.Schema {
+	if sf.problemRef == "" {
+		name := sf.uniquify("ProblemDetails")
+		sf.problemRef = toRef(name)
+		sf.schemas[name] = openapi.ProblemDetailsSchema()
+	}
+	s := openapi.NewSchema()
+	s.Ref = sf.problemRef
+	return s
+}
+
+// withDetails sets the schema of the "details" property of the given inline
+// error schema to the schema of the given type. It is used to document the
+// details of errors defined with the Details DSL.
+func (sf *schemafier) withDetails(s *openapi.Schema, dt expr.UserType) *openapi.Schema {
+	if _, ok := s.Properties["details"]; ok {
+		s.Properties["details"] = sf.schemafy(&expr.AttributeExpr{Type: dt})
+	}
+	return s
+}
+
 // toRef creates a relative JSON Schema reference from a type name that points
 // to the corresponding definition in the OpenAPI "components" field.
 func toRef(n string) string {
@@ -17,6 +17,10 @@ type typ struct {
 	Format    string
 	Props     []attr
 	SkipProps bool
+	ReadOnly  bool
+	WriteOnly bool
+	Strict    bool
+	Items     *typ
 }
 
 type attr struct {
@@ -35,6 +39,11 @@ var (
 	tbinary = typ{Type: "string", Format: "binary"}
 	tint    = typ{Type: "integer"}
 	tarray  = typ{Type: "array"}
+
+	treadonly  = typ{Type: "string", ReadOnly: true}
+	twriteonly = typ{Type: "string", WriteOnly: true}
+	tint64str  = typ{Type: "string", Format: "int64"}
+	tint64arr  = typ{Type: "array", Items: &typ{Type: "integer", Format: "int64"}}
 )
 
 func tobj(attrs ...any) typ {
@@ -48,6 +57,11 @@ func tobj(attrs ...any) typ {
 	return res
 }
 
+func tstrict(tt typ) typ {
+	tt.Strict = true
+	return tt
+}
+
 func tmap() typ {
 	return typ{Type: "object", Props: []attr{{Name: "map", Val: typ{Type: "object"}}}}
 }
@@ -164,6 +178,25 @@ func TestBuildBodyTypes(t *testing.T) {
 		ExpectedType:          tempty,
 		ExpectedResponseTypes: rt{204: tempty},
 		ExpectedExtraTypes:    map[string]typ{"Forced": tobj("foo", tstring)},
+	}, {
+		Name: "read_only_write_only",
+		DSL:  dsls.ReadOnlyWriteOnlyDSL(svcName, "read_only_write_only"),
+
+		ExpectedType:          tobj("name", tstring, "password", twriteonly),
+		ExpectedResponseTypes: rt{200: tobj("id", treadonly, "name", tstring)},
+		ExpectedExtraTypes:    map[string]typ{"Account": tobj("id", treadonly, "name", tstring, "password", twriteonly)},
+	}, {
+		Name: "json_policy",
+		DSL:  dsls.JSONPolicyDSL(svcName, "json_policy"),
+
+		ExpectedType:          tobj("first_name", tstring, "account_id", tint64str, "ids", tint64arr, "cust", tstring),
+		ExpectedResponseTypes: rt{200: tobj("first_name", tstring, "account_id", tint64str, "ids", tint64arr, "cust", tstring)},
+	}, {
+		Name: "strict_decoding",
+		DSL:  dsls.StrictDecodingDSL(svcName, "strict_decoding"),
+
+		ExpectedType:          tstrict(tobj("name", tstring, "age", tint)),
+		ExpectedResponseTypes: rt{200: tobj("name", tstring, "age", tint)},
 	}}
 
 	for _, c := range cases {
@@ -240,6 +273,18 @@ func matchesSchemaWithPrefix(t *testing.T, ctx string, s *openapi.Schema, types
 			t.Errorf("%s: %sgot format %q, expected %q", ctx, prefix, s.Format, tt.Format)
 		}
 	}
+	if tt.ReadOnly != s.ReadOnly {
+		t.Errorf("%s: %sgot readOnly %t, expected %t", ctx, prefix, s.ReadOnly, tt.ReadOnly)
+	}
+	if tt.WriteOnly != s.WriteOnly {
+		t.Errorf("%s: %sgot writeOnly %t, expected %t", ctx, prefix, s.WriteOnly, tt.WriteOnly)
+	}
+	if tt.Strict && s.AdditionalProperties != false {
+		t.Errorf("%s: %sgot additionalProperties %v, expected false", ctx, prefix, s.AdditionalProperties)
+	}
+	if tt.Items != nil {
+		matchesSchemaWithPrefix(t, ctx, s.Items, types, *tt.Items, prefix+"items: ")
+	}
 	if tt.Type == "object" {
 		if tt.SkipProps {
 			return
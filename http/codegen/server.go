@@ -41,6 +41,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		"isWebSocketEndpoint": isWebSocketEndpoint,
 		"viewedServerBody":    viewedServerBody,
 		"mustDecodeRequest":   mustDecodeRequest,
+		"hooksTypeArgs":       hooksTypeArgs,
 		"addLeadingSlash":     addLeadingSlash,
 		"dir":                 path.Dir,
 	}
@@ -64,7 +65,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		codegen.Header(title, "server", imports),
 	}
 
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: readTemplate("server_struct"), Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: readTemplate("server_struct"), Data: data, FuncMap: funcs})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mountpoint", Source: readTemplate("mount_point_struct"), Data: data})
 
 	for _, e := range data.Endpoints {
@@ -82,6 +83,15 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: readTemplate("server_use"), Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-method-names", Source: readTemplate("server_method_names"), Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: readTemplate("server_mount"), Data: data, FuncMap: funcs})
+	headers, fields := sensitiveNames([]*expr.HTTPServiceExpr{svc})
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "redact-options",
+		Source: readTemplate("redact_options"),
+		Data: map[string]any{
+			"Headers": headers,
+			"Fields":  fields,
+		},
+	})
 
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: readTemplate("server_handler"), Data: e})
@@ -212,6 +222,26 @@ func mustDecodeRequest(e *EndpointData) bool {
 	return e.Payload.Ref != ""
 }
 
+// hooksTypeArgs returns the type arguments of the goahttp.ServerHooks invoked by
+// the handler of the given endpoint: the payload type and the type of the
+// value returned by the endpoint.
+func hooksTypeArgs(e *EndpointData) string {
+	payload, result := "any", "any"
+	if e.Payload.Ref != "" {
+		payload = e.Payload.Ref
+	}
+	switch {
+	case e.Redirect != nil || isWebSocketEndpoint(e):
+	case e.Method.SkipResponseBodyEncodeDecode:
+		result = "*" + e.ServicePkgName + "." + e.Method.ResponseStruct
+	case e.Method.ViewedResult != nil:
+		result = e.Method.ViewedResult.FullRef
+	case e.Result.Ref != "":
+		result = e.Result.Ref
+	}
+	return payload + ", " + result
+}
+
 // conversionData creates a template context suitable for executing the
 // "type_conversion" template.
 func conversionData(varName, name string, dt expr.DataType) map[string]any {
@@ -31,6 +31,7 @@ func TestServerMount(t *testing.T) {
 		{"multiple files mounter /w prefix path", testdata.ServerMultipleFilesWithPrefixPathDSL, testdata.ServerMultipleFilesWithPrefixPathMounterCode, 3, "server-files"},
 		{"multiple files with a redirect constructor", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesWithRedirectConstructorCode, 0, "server-mount"},
 		{"multiple files with a redirect mounter", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesMounterCode, 3, "server-files"},
+		{"sensitive redact options", testdata.PayloadSensitiveDSL, testdata.PayloadSensitiveRedactOptionsCode, 0, "redact-options"},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
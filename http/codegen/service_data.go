@@ -50,6 +50,12 @@ type (
 	ServiceData struct {
 		// Service contains the related service data.
 		Service *service.Data
+		// JSONPolicy is the JSON serialization policy that applies to
+		// the request and response bodies.
+		JSONPolicy *expr.JSONPolicyExpr
+		// ProblemDetails is true if the service errors are formatted as
+		// RFC 9457 problem details.
+		ProblemDetails bool
 		// Endpoints describes the endpoint data for this service.
 		Endpoints []*EndpointData
 		// FileServers lists the file servers for this service.
@@ -58,6 +64,9 @@ type (
 		ServerStruct string
 		// MountPointStruct is the name of the mount point struct.
 		MountPointStruct string
+		// HooksStruct is the name of the struct listing the server
+		// lifecycle hooks.
+		HooksStruct string
 		// ServerInit is the name of the constructor of the server
 		// struct.
 		ServerInit string
@@ -115,6 +124,10 @@ type (
 		Result *ResultData
 		// Errors describes the method HTTP errors.
 		Errors []*ErrorGroupData
+		// ProblemDetails is true if the errors that are not defined in
+		// the design are formatted as RFC 9457 problem details by
+		// default.
+		ProblemDetails bool
 		// Routes describes the possible routes for this endpoint.
 		Routes []*RouteData
 		// BasicScheme is the basic auth security scheme if any.
@@ -129,6 +142,19 @@ type (
 		// apply to the method and are encoded in the request query
 		// string.
 		QuerySchemes service.SchemesData
+		// MutualTLS is true if the method is secured with a mutual TLS
+		// scheme in which case the handler stores the verified client
+		// certificate chain in the request context.
+		MutualTLS bool
+		// SignatureScheme is the request signature security scheme if
+		// any. The handler reads the request signature and stores it in
+		// the request context and the client signs the requests.
+		SignatureScheme *service.SchemeData
+		// CSRFScheme is the cookie session security scheme that enables
+		// CSRF protection if any. The handler reads the CSRF token and
+		// stores it in the request context and the client sets the CSRF
+		// header.
+		CSRFScheme *service.SchemeData
 		// Requirements contains the security requirements for the
 		// method.
 		Requirements service.RequirementsData
@@ -268,6 +294,12 @@ type (
 		Ref string
 		// Response is the error response data.
 		Response *ResponseData
+		// ProblemDetails is true if the error uses the default error
+		// type and is written as a RFC 9457 problem details response.
+		ProblemDetails bool
+		// Localized is true if the error uses the default error type and
+		// its messages are localized prior to being encoded.
+		Localized bool
 	}
 
 	// RequestData describes a request.
@@ -309,6 +341,12 @@ type (
 		// Multipart if true indicates the request is a multipart
 		// request.
 		Multipart bool
+		// StrictDecoding is true if the server rejects request bodies
+		// that contain fields not defined in the design.
+		StrictDecoding bool
+		// StrictQuery is true if the server rejects requests whose query
+		// string contains parameters not defined in the design.
+		StrictQuery bool
 	}
 
 	// ResponseData describes a response.
@@ -597,8 +635,11 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 	scope.Unique("v") // 'v' is reserved as the request builder payload argument name.
 	rd := &ServiceData{
 		Service:          svc,
+		JSONPolicy:       expr.JSONPolicy(httpSvc.ServiceExpr),
+		ProblemDetails:   httpSvc.UsesProblemDetails(),
 		ServerStruct:     "Server",
 		MountPointStruct: "MountPoint",
+		HooksStruct:      "Hooks",
 		ServerInit:       "New",
 		MountServer:      "Mount",
 		ServerService:    "Service",
@@ -730,11 +771,14 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 		payload := buildPayloadData(httpEndpoint, rd)
 
 		var (
-			reqs  service.RequirementsData
-			hsch  service.SchemesData
-			bosch service.SchemesData
-			qsch  service.SchemesData
-			basch *service.SchemeData
+			reqs    service.RequirementsData
+			hsch    service.SchemesData
+			bosch   service.SchemesData
+			qsch    service.SchemesData
+			basch   *service.SchemeData
+			mtls    bool
+			sigsch  *service.SchemeData
+			csrfsch *service.SchemeData
 		)
 		for _, req := range httpEndpoint.Requirements {
 			var rs service.SchemesData
@@ -744,6 +788,16 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 				switch s.Type {
 				case "Basic":
 					basch = s
+				case "MutualTLS":
+					mtls = true
+				case "Signature":
+					if sigsch == nil {
+						sigsch = s
+					}
+				case "Session":
+					if csrfsch == nil && s.CSRF != "" {
+						csrfsch = s
+					}
 				default:
 					switch s.In {
 					case "query":
@@ -819,10 +873,14 @@ func (ServicesData) analyze(httpSvc *expr.HTTPServiceExpr) *ServiceData {
 			Payload:         payload,
 			Result:          buildResultData(httpEndpoint, rd),
 			Errors:          buildErrorsData(httpEndpoint, rd),
+			ProblemDetails:  rd.ProblemDetails,
 			HeaderSchemes:   hsch,
 			BodySchemes:     bosch,
 			QuerySchemes:    qsch,
 			BasicScheme:     basch,
+			MutualTLS:       mtls,
+			SignatureScheme: sigsch,
+			CSRFScheme:      csrfsch,
 			Routes:          routes,
 			MountHandler:    fmt.Sprintf("Mount%sHandler", method.VarName),
 			HandlerInit:     fmt.Sprintf("New%sHandler", method.VarName),
@@ -1097,6 +1155,10 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			MustValidate: mustValidate,
 			Multipart:    e.MultipartRequest,
 		}
+		if e.MethodExpr.IsStrictDecoding() {
+			request.StrictDecoding = serverBodyData != nil
+			request.StrictQuery = e.MapQueryParams == nil
+		}
 	}
 
 	var init *InitData
@@ -1143,7 +1205,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					TypeRef:  sd.Scope.GoTypeRef(e.Body),
 					Type:     body,
 					Required: true,
-					Example:  e.Body.Example(expr.Root.API.ExampleGenerator),
+					Example:  codegen.JSONExample(sd.JSONPolicy, e.Body, e.Body.Example(expr.Root.API.ExampleGenerator)),
 					Validate: svcode,
 				},
 			}}
@@ -1156,7 +1218,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					TypeRef:  sd.Scope.GoTypeRefWithDefaults(e.Body),
 					Type:     body,
 					Required: true,
-					Example:  e.Body.Example(expr.Root.API.ExampleGenerator),
+					Example:  codegen.JSONExample(sd.JSONPolicy, e.Body, e.Body.Example(expr.Root.API.ExampleGenerator)),
 					Validate: cvcode,
 				},
 			}}
@@ -1906,9 +1968,11 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 
 		ref := svc.Scope.GoFullTypeRef(v.ErrorExpr.AttributeExpr, pkg)
 		data[ref] = append(data[ref], &ErrorData{
-			Name:     v.Name,
-			Response: responseData,
-			Ref:      ref,
+			Name:           v.Name,
+			Response:       responseData,
+			Ref:            ref,
+			ProblemDetails: sd.ProblemDetails && v.ErrorExpr.Type == expr.ErrorResult,
+			Localized:      v.ErrorExpr.Type == expr.ErrorResult,
 		})
 	}
 	keys := make([]string, len(data))
@@ -1977,11 +2041,11 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 	name = body.Type.Name()
 	ref = sd.Scope.GoTypeRef(body)
 
-	AddMarshalTags(body, make(map[string]struct{}))
+	addMarshalTags(body, make(map[string]struct{}), sd.JSONPolicy)
 
 	if ut, ok := body.Type.(expr.UserType); ok {
 		varname = codegen.Goify(ut.Name(), true)
-		def = goTypeDef(sd.Scope, ut.Attribute(), svr, !svr)
+		def = goTypeDef(sd.Scope, ut.Attribute(), svr, !svr, sd.JSONPolicy)
 		desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP request body.",
 			varname, svc.Name, e.Name())
 		if svr {
@@ -2123,12 +2187,12 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 	ref = sd.Scope.GoTypeRef(body)
 	mustInit = att.Type != expr.Empty && needInit(body.Type)
 
-	AddMarshalTags(body, make(map[string]struct{}))
+	addMarshalTags(body, make(map[string]struct{}), sd.JSONPolicy)
 
 	if ut, ok := body.Type.(expr.UserType); ok {
 		// response body is a user type.
 		varname = codegen.Goify(ut.Name(), true)
-		def = goTypeDef(sd.Scope, ut.Attribute(), !svr, svr)
+		def = goTypeDef(sd.Scope, ut.Attribute(), !svr, svr, sd.JSONPolicy)
 		desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body.",
 			varname, svc.Name, e.Name())
 		if !svr && view == nil {
@@ -2149,7 +2213,7 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 		varname = name
 		desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body.",
 			varname, svc.Name, e.Name())
-		def = goTypeDef(sd.Scope, body, !svr, svr)
+		def = goTypeDef(sd.Scope, body, !svr, svr, sd.JSONPolicy)
 		validateRef = codegen.ValidationCode(body, nil, httpctx, true, expr.IsAlias(body.Type), false, "body")
 	} else {
 		// response body is a primitive type. They are used as non-pointers when
@@ -2598,7 +2662,7 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 		Name:        ut.Name(),
 		VarName:     name,
 		Description: desc,
-		Def:         goTypeDef(rd.Scope, ut.Attribute(), ptr, hctx.UseDefault),
+		Def:         goTypeDef(rd.Scope, ut.Attribute(), ptr, hctx.UseDefault, rd.JSONPolicy),
 		Ref:         rd.Scope.GoTypeRef(att),
 		ValidateDef: validate,
 		ValidateRef: validateRef,
@@ -2695,6 +2759,12 @@ func needConversion(dt expr.DataType) bool {
 
 // AddMarshalTags adds JSON, XML and Form tags to all inline object attributes recursively.
 func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
+	addMarshalTags(att, seen, nil)
+}
+
+// addMarshalTags adds JSON, XML and Form tags to all inline object attributes
+// recursively. The JSON tags follow the given JSON serialization policy.
+func addMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}, json *expr.JSONPolicyExpr) {
 	if ut, ok := att.Type.(expr.UserType); ok {
 		if _, ok := seen[ut.Hash()]; ok {
 			return // avoid infinite recursions
@@ -2702,18 +2772,18 @@ func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 		seen[ut.Hash()] = struct{}{}
 		if expr.IsObject(ut.Attribute().Type) {
 			for _, att := range *(expr.AsObject(att.Type)) {
-				AddMarshalTags(att.Attribute, seen)
+				addMarshalTags(att.Attribute, seen, json)
 			}
 		}
 		return
 	}
 	if expr.IsArray(att.Type) {
-		AddMarshalTags(expr.AsArray(att.Type).ElemType, seen)
+		addMarshalTags(expr.AsArray(att.Type).ElemType, seen, json)
 		return
 	}
 	if expr.IsMap(att.Type) {
-		AddMarshalTags(expr.AsMap(att.Type).KeyType, seen)
-		AddMarshalTags(expr.AsMap(att.Type).ElemType, seen)
+		addMarshalTags(expr.AsMap(att.Type).KeyType, seen, json)
+		addMarshalTags(expr.AsMap(att.Type).ElemType, seen, json)
 		return
 	}
 	if !expr.IsObject(att.Type) {
@@ -2725,8 +2795,15 @@ func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 			natt.Attribute.Meta = expr.MetaExpr{}
 		}
 		ns := []string{natt.Name}
+		js := ns
+		if !json.IsDefault() {
+			js = []string{codegen.JSONName(json, natt.Attribute, natt.Name)}
+			if json.EncodeAsString(natt.Attribute) {
+				js = append(js, "string")
+			}
+		}
 		natt.Attribute.Meta["struct:tag:form"] = ns
-		natt.Attribute.Meta["struct:tag:json"] = ns
+		natt.Attribute.Meta["struct:tag:json"] = js
 		natt.Attribute.Meta["struct:tag:xml"] = ns
 	}
 }
@@ -3,8 +3,28 @@ func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, any, er
 		doer goahttp.Doer
 	)
 	{
+	{{- if or .MutualTLS .CookieJar }}
+		c := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+		{{- if .MutualTLS }}
+		if clientTLSConfig != nil {
+			t := http.DefaultTransport.(*http.Transport).Clone()
+			t.TLSClientConfig = clientTLSConfig
+			c.Transport = t
+		}
+		{{- end }}
+		{{- if .CookieJar }}
+		// Keep the session cookies set by the server.
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Jar = jar
+		{{- end }}
+		doer = c
+	{{- else }}
 		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	{{- end }}
 		if debug {
-			doer = goahttp.NewDebugDoer(doer)
+			doer = goahttp.NewDebugDoer(doer, cli.RedactOptions()...)
 		}
 	}
@@ -21,6 +21,15 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			return nil, err
 		}
 	{{- end }}
+	{{- if .SignatureScheme }}
+		err = goahttp.SignRequest(req, []string{ {{- range .SignatureScheme.SignedComponents }}{{ printf "%q" . }}, {{ end }} })
+		if err != nil {
+			return nil, err
+		}
+	{{- end }}
+	{{- if .CSRFScheme }}
+		goahttp.SetCSRFHeader(req, {{ printf "%q" .CSRFScheme.CSRFHeader }}, {{ printf "%q" .CSRFScheme.CSRFCookie }}, c.{{ .Method.VarName }}Doer)
+	{{- end }}
 
 	{{- if isWebSocketEndpoint . }}
 		conn, resp, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
@@ -1,6 +1,6 @@
 {{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
-	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	encodeError := goahttp.{{ if .ProblemDetails }}ProblemErrorEncoder{{ else }}ErrorEncoder{{ end }}(encoder, formatter)
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
 		var en goa.GoaErrorNamer
 		if !errors.As(v, &en) {
@@ -10,8 +10,11 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 	{{- range $gerr := .Errors }}
 	{{- range $err := .Errors }}
 		case {{ printf "%q" .Name }}:
+		{{- if .ProblemDetails }}
+			return goahttp.ProblemDetailsEncoder(encoder, formatter, {{ .Response.StatusCode }})(ctx, w, v)
+		{{- else }}
 			var res {{ $err.Ref }}
-			errors.As(v, &res)
+			errors.As({{ if .Localized }}goa.LocalizeError(ctx, v){{ else }}v{{ end }}, &res)
 			{{- with .Response}}
 				{{- if .ContentType }}
 					ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
@@ -23,6 +26,7 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 				return nil
 				{{- end }}
 			{{- end }}
+		{{- end }}
 	{{- end }}
 	{{- end }}
 		default:
@@ -0,0 +1,12 @@
+// RedactOptions returns the options used to redact the values of the sensitive
+// headers, parameters and body fields when printing debug information.
+func RedactOptions() []goahttp.RedactOption {
+	return []goahttp.RedactOption{
+{{- if .Headers }}
+		goahttp.RedactHeaders({{ range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }}),
+{{- end }}
+{{- if .Fields }}
+		goahttp.RedactFields({{ range $i, $f := .Fields }}{{ if $i }}, {{ end }}{{ printf "%q" $f }}{{ end }}),
+{{- end }}
+	}
+}
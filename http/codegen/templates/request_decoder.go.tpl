@@ -1,6 +1,14 @@
 {{ printf "%s returns a decoder for requests sent to the %s %s endpoint." .RequestDecoder .ServiceName .Method.Name | comment }}
 func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+{{- if .Payload.Request.StrictDecoding }}
+	decoder = goahttp.StrictDecoder(decoder)
+{{- end }}
 	return func(r *http.Request) (any, error) {
+{{- if .Payload.Request.StrictQuery }}
+		if err := goahttp.CheckQueryParams(r{{ range .Payload.Request.QueryParams }}, {{ printf "%q" .HTTPName }}{{ end }}); err != nil {
+			return nil, err
+		}
+{{- end }}
 {{- if .MultipartRequestDecoder }}
 		var payload {{ .Payload.Ref }}
 		if err := decoder(r).Decode(&payload); err != nil {
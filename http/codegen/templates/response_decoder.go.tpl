@@ -76,6 +76,9 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 		switch en {
 			{{- range .Errors }}
 		case {{ printf "%q" .Name }}:
+				{{- if .ProblemDetails }}
+			return nil, goahttp.DecodeProblemDetails(decoder(resp))
+				{{- else }}
 				{{- with .Response }}
 					{{- template "partial_single_response" (buildResponseData . $.ServiceName $.Method) }}
 					{{- if .ResultInit }}
@@ -86,11 +89,14 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 			return nil, nil
 					{{- end }}
 				{{- end }}
+				{{- end }}
 			{{- end }}
 		default:
 			body, _ := io.ReadAll(resp.Body)
 			return nil, goahttp.ErrInvalidResponse({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, resp.StatusCode, string(body))
 		}
+		{{- else if (index .Errors 0).ProblemDetails }}
+			return nil, goahttp.DecodeProblemDetails(decoder(resp))
 		{{- else }}
 			{{- with (index .Errors 0).Response }}
 				{{- template "partial_single_response" (buildResponseData . $.ServiceName $.Method) }}
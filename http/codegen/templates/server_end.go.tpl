@@ -5,7 +5,11 @@
 
 	{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
+		{{- if $.Slog }}
+			slog.InfoContext(ctx, "HTTP endpoint mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
+		{{- else }}
 			log.Printf(ctx, "HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		{{- end }}
 		}
 	{{- end }}
 
@@ -15,12 +19,20 @@
 
 		{{ comment "Start HTTP server in a separate goroutine." }}
 		go func() {
+		{{- if .Slog }}
+			slog.InfoContext(ctx, "HTTP server listening", "host", u.Host)
+		{{- else }}
 			log.Printf(ctx, "HTTP server listening on %q", u.Host)
+		{{- end }}
 			errc <- srv.ListenAndServe()
 		}()
 
 		<-ctx.Done()
+	{{- if .Slog }}
+		slog.InfoContext(ctx, "shutting down HTTP server", "host", u.Host)
+	{{- else }}
 		log.Printf(ctx, "shutting down HTTP server at %q", u.Host)
+	{{- end }}
 
 		{{ comment "Shutdown gracefully with a 30s timeout." }}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -28,7 +40,11 @@
 
 		err := srv.Shutdown(ctx)
 		if err != nil {
+		{{- if .Slog }}
+			slog.ErrorContext(ctx, "failed to shutdown", "error", err)
+		{{- else }}
 			log.Printf(ctx, "failed to shutdown: %v", err)
+		{{- end }}
 		}
 	}()
 }
@@ -4,6 +4,10 @@
 // to correlate.
 func errorHandler(logCtx context.Context) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
+	{{- if .Slog }}
+		slog.ErrorContext(logCtx, err.Error())
+	{{- else }}
 		log.Printf(logCtx, "ERROR: %s", err.Error())
+	{{- end }}
 	}
 }
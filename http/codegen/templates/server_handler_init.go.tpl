@@ -10,6 +10,23 @@ func {{ .HandlerInit }}(
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
 	{{- end }}
+) http.Handler {
+	return {{ .HandlerInit }}WithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil{{ if isWebSocketEndpoint . }}, upgrader, configurer{{ end }})
+}
+
+{{ printf "%sWithHooks is like %s but also invokes the given lifecycle hooks. hooks may be nil." .HandlerInit .HandlerInit | comment }}
+func {{ .HandlerInit }}WithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[{{ hooksTypeArgs . }}],
+	{{- if isWebSocketEndpoint . }}
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
+	{{- end }}
 ) http.Handler {
 	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	var (
@@ -18,10 +35,10 @@ func {{ .HandlerInit }}(
 		decodeRequest  = {{ .RequestDecoder }}(mux, decoder)
 		{{- end }}
 		{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
-		encodeResponse = {{ .ResponseEncoder }}(encoder)
+		encodeResponse = hooks.ResponseEncoder({{ .ResponseEncoder }}(encoder))
 		{{- end }}
 		{{- if (or (mustDecodeRequest .) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
+		encodeError    = hooks.ErrorEncoder({{ if .Errors }}{{ .ErrorEncoder }}{{ else if .ProblemDetails }}goahttp.ProblemErrorEncoder{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter))
 		{{- end }}
 	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	)
@@ -30,15 +47,30 @@ func {{ .HandlerInit }}(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .MutualTLS }}
+		ctx = goahttp.WithClientCertificates(ctx, r)
+	{{- end }}
+	{{- if .SignatureScheme }}
+		ctx = goahttp.WithSignature(ctx, r)
+	{{- end }}
+	{{- if .CSRFScheme }}
+		ctx = goahttp.WithCSRF(ctx, r, {{ printf "%q" .CSRFScheme.CSRFHeader }}, {{ printf "%q" .CSRFScheme.CSRFCookie }})
+	{{- end }}
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 
 	{{- if mustDecodeRequest . }}
 		{{ if .Redirect }}_{{ else }}payload{{ end }}, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		{{- if not .Redirect }}
+		hooks.RequestDecoded(ctx, payload)
+		{{- end }}
 	{{- else if not .Redirect }}
 		var err error
 	{{- end }}
@@ -58,13 +90,16 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, {{ if mustDecodeRequest . }}payload{{ else }}nil{{ end }}, nil, err)
 	{{- else if .Method.SkipRequestBodyEncodeDecode }}
 		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body }
 		res, err := endpoint(ctx, data)
+		hooks.EndpointResult(ctx, {{ if mustDecodeRequest . }}payload{{ else }}nil{{ end }}, res, err)
 	{{- else if .Redirect }}
 		http.Redirect(w, r, "{{ .Redirect.URL }}", {{ .Redirect.StatusCode }})
 	{{- else }}
 		res, err := endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
+		hooks.EndpointResult(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }}, res, err)
 	{{- end }}
 	{{- if not .Redirect }}
 		if err != nil {
@@ -75,6 +110,9 @@ func {{ .HandlerInit }}(
 				return
 			}
 			{{- end }}
+			{{- if .Requirements }}
+			goahttp.SetAuthenticateHeaders(w, err)
+			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
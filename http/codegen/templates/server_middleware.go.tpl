@@ -1,7 +1,22 @@
-
 	var handler http.Handler = mux
+{{- if not .Slog }}
 	if dbg {
-		// Log query and response bodies if debug logs are enabled.
-		handler = debug.HTTP()(handler)
+		// Log query and response bodies if debug logs are enabled, the
+		// values of the sensitive headers, parameters and body fields are
+		// redacted.
+		var redact []goahttp.RedactOption
+	{{- range .Services }}
+		redact = append(redact, {{ .Service.PkgName }}svr.RedactOptions()...)
+	{{- end }}
+		handler = httpmdlwr.Debug(mux, os.Stdout, redact...)(handler)
 	}
+{{- end }}
+	if metrics != nil {
+		// Record the request metrics if enabled.
+		handler = httpmdlwr.Metrics(metrics, mux)(handler)
+	}
+{{- if .Slog }}
+	handler = httpmdlwr.Slog(slog.Default(), mux)(handler)
+{{- else }}
 	handler = log.HTTP(ctx)(handler)
+{{- end }}
@@ -1,13 +1,21 @@
 
-	// Build the service HTTP request multiplexer and mount debug and profiler
-	// endpoints in debug mode.
-	var mux goahttp.Muxer
+	// Build the service HTTP request multiplexer, mount the metrics endpoint
+	// if metrics are enabled and mount debug and profiler endpoints in debug
+	// mode.
+	var mux goahttp.ResolverMuxer
 	{
 		mux = goahttp.NewMuxer()
+		if metrics != nil {
+			// Mount /metrics endpoint to expose the metrics in the
+			// Prometheus text format.
+			mux.Handle("GET", "/metrics", httpmdlwr.MetricsHandler(metrics).ServeHTTP)
+		}
 		if dbg {
 			// Mount pprof handlers for memory profiling under /debug/pprof.
 			debug.MountPprofHandlers(debug.Adapt(mux))
+		{{- if not .Slog }}
 			// Mount /debug endpoint to enable or disable debug logs at runtime.
 			debug.MountDebugLogEnabler(debug.Adapt(mux))
+		{{- end }}
 		}
 	}
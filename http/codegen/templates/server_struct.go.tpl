@@ -7,4 +7,12 @@ type {{ .ServerStruct }} struct {
 	{{- range .FileServers }}
 	{{ .VarName }} http.Handler
 	{{- end }}
+	hooks *{{ .HooksStruct }}
+}
+
+{{ printf "%s lists the lifecycle hooks of the %s service endpoint HTTP handlers, see goahttp.ServerHooks." .HooksStruct .Service.Name | comment }}
+type {{ .HooksStruct }} struct {
+	{{- range .Endpoints }}
+	{{ .Method.VarName }} goahttp.ServerHooks[{{ hooksTypeArgs . }}]
+	{{- end }}
 }
@@ -4,3 +4,8 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
 {{- end }}
 }
+
+{{ printf "SetHooks sets the lifecycle hooks invoked by the %s service handlers. It must be called before the server starts handling requests." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) SetHooks(hooks {{ .HooksStruct }}) {
+	*s.hooks = hooks
+}
@@ -29,6 +29,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	{{- end }}
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	{{- if .Payload.ValidateRef }}
 		{{- if not .RecvTypeIsPointer }}
 	body := *msg
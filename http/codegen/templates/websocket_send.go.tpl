@@ -1,6 +1,7 @@
 {{ comment .SendDesc }}
 func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 {{- if eq .Type "server" }}
+	{{- $msg := "res" }}
 	{{- if eq .SendName "Send" }}
 		var err error
 		{{- template "partial_websocket_upgrade" (upgradeParams .Endpoint .SendName) }}
@@ -36,13 +37,14 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 			{{- else }}
 				body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
 			{{- end }}
-			return s.conn.WriteJSON(body)
-		{{- else }}
-			return s.conn.WriteJSON(res)
+			{{- $msg = "body" }}
 		{{- end }}
-	{{- else }}
-		return s.conn.WriteJSON(res)
 	{{- end }}
+	if err {{ if eq .SendName "Send" }}={{ else }}:={{ end }} s.conn.WriteJSON({{ $msg }}); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 {{- else }}
 	{{- if .Payload.Init }}
 		body := {{ .Payload.Init.Name }}(v)
@@ -51,3 +51,58 @@ func NewClient(
 }
 `
 )
+
+var SignatureClientEndpointInitCode = `// ServerSignature returns an endpoint that makes HTTP requests to the
+// ServiceSignatureServer service server-signature server.
+func (c *Client) ServerSignature() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeServerSignatureRequest(c.encoder)
+		decodeResponse = DecodeServerSignatureResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildServerSignatureRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		err = goahttp.SignRequest(req, []string{"@method", "@path", "content-digest"})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ServerSignatureDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceSignatureServer", "server-signature", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+var SessionClientEndpointInitCode = `// ServerSession returns an endpoint that makes HTTP requests to the
+// ServiceSessionServer service server-session server.
+func (c *Client) ServerSession() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeServerSessionRequest(c.encoder)
+		decodeResponse = DecodeServerSessionResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildServerSessionRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.SetCSRFHeader(req, "X-CSRF-Token", "csrf_token", c.ServerSessionDoer)
+		resp, err := c.ServerSessionDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceSessionServer", "server-session", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -87,7 +87,7 @@ func EncodeMethodAPIPrimitiveErrorResponseError(encoder func(context.Context, ht
 		switch en.GoaErrorName() {
 		case "internal_error":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			enc := encoder(ctx, w)
 			var body any
 			if formatter != nil {
@@ -125,7 +125,7 @@ func EncodeMethodDefaultErrorResponseError(encoder func(context.Context, http.Re
 		switch en.GoaErrorName() {
 		case "bad_request":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			enc := encoder(ctx, w)
 			var body any
 			if formatter != nil {
@@ -155,7 +155,7 @@ func EncodeMethodDefaultErrorResponseError(encoder func(context.Context, http.Re
 		switch en.GoaErrorName() {
 		case "bad_request":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "application/xml")
 			enc := encoder(ctx, w)
 			var body any
@@ -186,7 +186,7 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 		switch en.GoaErrorName() {
 		case "internal_error":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			enc := encoder(ctx, w)
 			var body any
 			if formatter != nil {
@@ -199,7 +199,7 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 			return enc.Encode(body)
 		case "bad_request":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			enc := encoder(ctx, w)
 			var body any
 			if formatter != nil {
@@ -229,7 +229,7 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 		switch en.GoaErrorName() {
 		case "internal_error":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			enc := encoder(ctx, w)
 			var body any
 			if formatter != nil {
@@ -242,7 +242,7 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 			return enc.Encode(body)
 		case "bad_request":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "application/xml")
 			enc := encoder(ctx, w)
 			var body any
@@ -327,7 +327,7 @@ func EncodeMethodEmptyErrorResponseBodyError(encoder func(context.Context, http.
 		switch en.GoaErrorName() {
 		case "internal_error":
 			var res *goa.ServiceError
-			errors.As(v, &res)
+			errors.As(goa.LocalizeError(ctx, v), &res)
 			w.Header().Set("Error-Name", res.Name)
 			w.Header().Set("Goa-Attribute-Id", res.ID)
 			w.Header().Set("Goa-Attribute-Message", res.Message)
@@ -390,3 +390,36 @@ func EncodeMethodEmptyCustomErrorResponseBodyError(encoder func(context.Context,
 	}
 }
 `
+
+var ProblemDetailsErrorResponseEncoderCode = `// EncodeMethodProblemDetailsErrorResponseError returns an encoder for errors
+// returned by the MethodProblemDetailsErrorResponse
+// ServiceProblemDetailsErrorResponse endpoint.
+func EncodeMethodProblemDetailsErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ProblemErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "bad_request":
+			return goahttp.ProblemDetailsEncoder(encoder, formatter, http.StatusBadRequest)(ctx, w, v)
+		case "custom":
+			var res *serviceproblemdetailserrorresponse.CustomError
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewMethodProblemDetailsErrorResponseCustomResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
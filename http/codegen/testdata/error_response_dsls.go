@@ -16,6 +16,24 @@ var DefaultErrorResponseDSL = func() {
 	})
 }
 
+var ProblemDetailsErrorResponseDSL = func() {
+	var CustomError = Type("CustomError", func() {
+		Attribute("reason", String)
+	})
+	Service("ServiceProblemDetailsErrorResponse", func() {
+		ProblemDetails()
+		Method("MethodProblemDetailsErrorResponse", func() {
+			Error("bad_request")
+			Error("custom", CustomError)
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+				Response("custom", StatusConflict)
+			})
+		})
+	})
+}
+
 var DefaultErrorResponseWithContentTypeDSL = func() {
 	Service("ServiceDefaultErrorResponse", func() {
 		Method("MethodDefaultErrorResponse", func() {
@@ -10,17 +10,35 @@ func NewMethodNoPayloadNoResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodNoPayloadNoResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodNoPayloadNoResultHandlerWithHooks is like
+// NewMethodNoPayloadNoResultHandler but also invokes the given lifecycle
+// hooks. hooks may be nil.
+func NewMethodNoPayloadNoResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
 ) http.Handler {
 	var (
-		encodeResponse = EncodeMethodNoPayloadNoResultResponse(encoder)
-		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodNoPayloadNoResultResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadNoResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		res, err := endpoint(ctx, nil)
+		hooks.EndpointResult(ctx, nil, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -44,11 +62,28 @@ func NewMethodNoPayloadNoResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodNoPayloadNoResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodNoPayloadNoResultHandlerWithHooks is like
+// NewMethodNoPayloadNoResultHandler but also invokes the given lifecycle
+// hooks. hooks may be nil.
+func NewMethodNoPayloadNoResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
 ) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadNoResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		http.Redirect(w, r, "/redirect/dest", http.StatusMovedPermanently)
 	})
 }
@@ -64,24 +99,44 @@ func NewMethodPayloadNoResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodPayloadNoResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodPayloadNoResultHandlerWithHooks is like
+// NewMethodPayloadNoResultHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewMethodPayloadNoResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*servicepayloadnoresult.MethodPayloadNoResultPayload, any],
 ) http.Handler {
 	var (
 		decodeRequest  = DecodeMethodPayloadNoResultRequest(mux, decoder)
-		encodeResponse = EncodeMethodPayloadNoResultResponse(encoder)
-		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodPayloadNoResultResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadNoResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		res, err := endpoint(ctx, payload)
+		hooks.EndpointResult(ctx, payload, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -105,17 +160,35 @@ func NewMethodPayloadNoResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodPayloadNoResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodPayloadNoResultHandlerWithHooks is like
+// NewMethodPayloadNoResultHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewMethodPayloadNoResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*servicepayloadnoresult.MethodPayloadNoResultPayload, any],
 ) http.Handler {
 	var (
 		decodeRequest = DecodeMethodPayloadNoResultRequest(mux, decoder)
-		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError   = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadNoResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		_, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
@@ -136,17 +209,35 @@ func NewMethodNoPayloadResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodNoPayloadResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodNoPayloadResultHandlerWithHooks is like
+// NewMethodNoPayloadResultHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewMethodNoPayloadResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, *servicenopayloadresult.MethodNoPayloadResultResult],
 ) http.Handler {
 	var (
-		encodeResponse = EncodeMethodNoPayloadResultResponse(encoder)
-		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodNoPayloadResultResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		res, err := endpoint(ctx, nil)
+		hooks.EndpointResult(ctx, nil, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -170,24 +261,43 @@ func NewMethodPayloadResultHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodPayloadResultHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodPayloadResultHandlerWithHooks is like NewMethodPayloadResultHandler
+// but also invokes the given lifecycle hooks. hooks may be nil.
+func NewMethodPayloadResultHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*servicepayloadresult.MethodPayloadResultPayload, *servicepayloadresult.MethodPayloadResultResult],
 ) http.Handler {
 	var (
 		decodeRequest  = DecodeMethodPayloadResultRequest(mux, decoder)
-		encodeResponse = EncodeMethodPayloadResultResponse(encoder)
-		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodPayloadResultResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResult")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		res, err := endpoint(ctx, payload)
+		hooks.EndpointResult(ctx, payload, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -211,24 +321,44 @@ func NewMethodPayloadResultErrorHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodPayloadResultErrorHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodPayloadResultErrorHandlerWithHooks is like
+// NewMethodPayloadResultErrorHandler but also invokes the given lifecycle
+// hooks. hooks may be nil.
+func NewMethodPayloadResultErrorHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*servicepayloadresulterror.MethodPayloadResultErrorPayload, *servicepayloadresulterror.MethodPayloadResultErrorResult],
 ) http.Handler {
 	var (
 		decodeRequest  = DecodeMethodPayloadResultErrorRequest(mux, decoder)
-		encodeResponse = EncodeMethodPayloadResultErrorResponse(encoder)
-		encodeError    = EncodeMethodPayloadResultErrorError(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodPayloadResultErrorResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(EncodeMethodPayloadResultErrorError(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResultError")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResultError")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		res, err := endpoint(ctx, payload)
+		hooks.EndpointResult(ctx, payload, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -252,17 +382,35 @@ func NewMethodSkipResponseBodyEncodeDecodeHandler(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewMethodSkipResponseBodyEncodeDecodeHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewMethodSkipResponseBodyEncodeDecodeHandlerWithHooks is like
+// NewMethodSkipResponseBodyEncodeDecodeHandler but also invokes the given
+// lifecycle hooks. hooks may be nil.
+func NewMethodSkipResponseBodyEncodeDecodeHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, *serviceskipresponsebodyencodedecode.MethodSkipResponseBodyEncodeDecodeResponseData],
 ) http.Handler {
 	var (
-		encodeResponse = EncodeMethodSkipResponseBodyEncodeDecodeResponse(encoder)
-		encodeError    = EncodeMethodSkipResponseBodyEncodeDecodeError(encoder, formatter)
+		encodeResponse = hooks.ResponseEncoder(EncodeMethodSkipResponseBodyEncodeDecodeResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(EncodeMethodSkipResponseBodyEncodeDecodeError(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSkipResponseBodyEncodeDecode")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSkipResponseBodyEncodeDecode")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		res, err := endpoint(ctx, nil)
+		hooks.EndpointResult(ctx, nil, res, err)
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
@@ -312,3 +460,231 @@ func NewMethodSkipResponseBodyEncodeDecodeHandler(
 	})
 }
 `
+
+var ServerProblemDetailsHandlerConstructorCode = `// NewServerProblemDetailsHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceProblemDetailsServer" service
+// "server-problem-details" endpoint.
+func NewServerProblemDetailsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewServerProblemDetailsHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewServerProblemDetailsHandlerWithHooks is like
+// NewServerProblemDetailsHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewServerProblemDetailsHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
+) http.Handler {
+	var (
+		encodeResponse = hooks.ResponseEncoder(EncodeServerProblemDetailsResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ProblemErrorEncoder(encoder, formatter))
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "server-problem-details")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceProblemDetailsServer")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
+		var err error
+		res, err := endpoint(ctx, nil)
+		hooks.EndpointResult(ctx, nil, res, err)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerMutualTLSHandlerConstructorCode = `// NewServerMutualTLSHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceMutualTLSServer" service "server-mutual-tls"
+// endpoint.
+func NewServerMutualTLSHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewServerMutualTLSHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewServerMutualTLSHandlerWithHooks is like NewServerMutualTLSHandler but
+// also invokes the given lifecycle hooks. hooks may be nil.
+func NewServerMutualTLSHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
+) http.Handler {
+	var (
+		encodeResponse = hooks.ResponseEncoder(EncodeServerMutualTLSResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "server-mutual-tls")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceMutualTLSServer")
+		ctx = goahttp.WithClientCertificates(ctx, r)
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
+		var err error
+		res, err := endpoint(ctx, nil)
+		hooks.EndpointResult(ctx, nil, res, err)
+		if err != nil {
+			goahttp.SetAuthenticateHeaders(w, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerSignatureHandlerConstructorCode = `// NewServerSignatureHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceSignatureServer" service "server-signature"
+// endpoint.
+func NewServerSignatureHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewServerSignatureHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewServerSignatureHandlerWithHooks is like NewServerSignatureHandler but
+// also invokes the given lifecycle hooks. hooks may be nil.
+func NewServerSignatureHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[string, any],
+) http.Handler {
+	var (
+		decodeRequest  = DecodeServerSignatureRequest(mux, decoder)
+		encodeResponse = hooks.ResponseEncoder(EncodeServerSignatureResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "server-signature")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSignatureServer")
+		ctx = goahttp.WithSignature(ctx, r)
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			hooks.DecodeError(ctx, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		hooks.RequestDecoded(ctx, payload)
+		res, err := endpoint(ctx, payload)
+		hooks.EndpointResult(ctx, payload, res, err)
+		if err != nil {
+			goahttp.SetAuthenticateHeaders(w, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerSessionHandlerConstructorCode = `// NewServerSessionHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceSessionServer" service "server-session" endpoint.
+func NewServerSessionHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	return NewServerSessionHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil)
+}
+
+// NewServerSessionHandlerWithHooks is like NewServerSessionHandler but also
+// invokes the given lifecycle hooks. hooks may be nil.
+func NewServerSessionHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*servicesessionserver.ServerSessionPayload, any],
+) http.Handler {
+	var (
+		decodeRequest  = DecodeServerSessionRequest(mux, decoder)
+		encodeResponse = hooks.ResponseEncoder(EncodeServerSessionResponse(encoder))
+		encodeError    = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "server-session")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSessionServer")
+		ctx = goahttp.WithCSRF(ctx, r, "X-CSRF-Token", "csrf_token")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			hooks.DecodeError(ctx, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		hooks.RequestDecoded(ctx, payload)
+		res, err := endpoint(ctx, payload)
+		hooks.EndpointResult(ctx, payload, res, err)
+		if err != nil {
+			goahttp.SetAuthenticateHeaders(w, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
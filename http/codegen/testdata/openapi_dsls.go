@@ -426,6 +426,38 @@ var SecurityDSL = func() {
 	})
 }
 
+var OpenIDConnectMutualTLSSecurityDSL = func() {
+	var OIDCAuth = OpenIDConnectSecurity("oidc", "https://goa.design/.well-known/openid-configuration", func() {
+		Description("Secures endpoint by requiring a token issued by the OpenID Connect provider.")
+		Scope("api:read", "Read-only access")
+	})
+
+	var MTLSAuth = MutualTLSSecurity("mtls", func() {
+		Description("Secures endpoint by requiring a client certificate.")
+	})
+
+	Service("testService", func() {
+		Method("testEndpointA", func() {
+			Security(OIDCAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				Required("token")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("testEndpointB", func() {
+			Security(MTLSAuth)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var ServerHostWithVariablesDSL = func() {
 	var _ = API("test", func() {
 		Server("test", func() {
@@ -945,3 +977,79 @@ var JSONPrefixIndentDSL = func() {
 		})
 	})
 }
+
+var JSONPolicyDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("firstName", String)
+		Attribute("accountID", Int64)
+		Attribute("ids", ArrayOf(Int64))
+		Required("firstName")
+	})
+	var _ = Service("testService", func() {
+		JSONNaming(SnakeCase)
+		JSONInt64AsString(true)
+		Method("testEndpoint", func() {
+			Payload(Account)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SignatureSecurityDSL = func() {
+	var Signed = SignatureSecurity("signed", func() {
+		Description("Secures endpoint by requiring a request signature.")
+		SignedComponents("@method", "@path", "content-digest")
+	})
+
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Security(Signed)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var SessionSecurityDSL = func() {
+	var Session = SessionSecurity("session", func() {
+		Description("Secures endpoint by requiring a browser session.")
+		SessionCookie("sid")
+		CSRF(DoubleSubmitCSRF)
+	})
+
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Security(Session)
+			Payload(func() {
+				SessionID("session_id", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PolicyDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		Description("Secures endpoint by requiring a valid JWT token.")
+	})
+
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Description("Updates the account.")
+			Security(JWT)
+			Authorize("role:admin or (role:owner and payload.account_id == auth.account_id)")
+			Payload(func() {
+				Token("token", String)
+				Attribute("account_id", String)
+			})
+			HTTP(func() {
+				PUT("/{account_id}")
+			})
+		})
+	})
+}
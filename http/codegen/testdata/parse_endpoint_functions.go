@@ -1543,3 +1543,13 @@ func BuildMethodCookieCustomNamePayload(serviceCookieCustomNameMethodCookieCusto
 	return v, nil
 }
 `
+
+var PayloadSensitiveRedactOptionsCode = `// RedactOptions returns the options used to redact the values of the sensitive
+// headers, parameters and body fields when printing debug information.
+func RedactOptions() []goahttp.RedactOption {
+	return []goahttp.RedactOption{
+		goahttp.RedactHeaders("X-Api-Key"),
+		goahttp.RedactFields("token", "password", "session_id"),
+	}
+}
+`
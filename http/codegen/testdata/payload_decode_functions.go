@@ -6198,3 +6198,40 @@ func DecodeMethodCookieCustomNameRequest(mux goahttp.Muxer, decoder func(*http.R
 	}
 }
 `
+var PayloadBodyQueryStrictDecodeCode = `// DecodeMethodBodyQueryStrictRequest returns a decoder for requests sent to
+// the ServiceBodyQueryStrict MethodBodyQueryStrict endpoint.
+func DecodeMethodBodyQueryStrictRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	decoder = goahttp.StrictDecoder(decoder)
+	return func(r *http.Request) (any, error) {
+		if err := goahttp.CheckQueryParams(r, "b"); err != nil {
+			return nil, err
+		}
+		var (
+			body MethodBodyQueryStrictRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			b *string
+		)
+		bRaw := r.URL.Query().Get("b")
+		if bRaw != "" {
+			b = &bRaw
+		}
+		payload := NewMethodBodyQueryStrictPayload(&body, b)
+
+		return payload, nil
+	}
+}
+`
@@ -2492,6 +2492,22 @@ var PayloadBodyQueryObjectDSL = func() {
 	})
 }
 
+var PayloadBodyQueryStrictDSL = func() {
+	Service("ServiceBodyQueryStrict", func() {
+		StrictDecoding()
+		Method("MethodBodyQueryStrict", func() {
+			Payload(func() {
+				Attribute("a", String)
+				Attribute("b", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Param("b")
+			})
+		})
+	})
+}
+
 var PayloadBodyQueryObjectValidateDSL = func() {
 	Service("ServiceBodyQueryObjectValidate", func() {
 		Method("MethodBodyQueryObjectValidate", func() {
@@ -3599,6 +3615,39 @@ var PayloadHeaderCustomNameDSL = func() {
 	})
 }
 
+var PayloadSensitiveDSL = func() {
+	var Credentials = Type("Credentials", func() {
+		Attribute("login", String)
+		Attribute("password", String, func() {
+			Sensitive()
+		})
+	})
+	Service("ServiceSensitive", func() {
+		Method("MethodSensitive", func() {
+			Payload(func() {
+				Attribute("key", String, func() {
+					Sensitive()
+				})
+				Attribute("token", String, func() {
+					Sensitive()
+				})
+				Attribute("creds", Credentials)
+			})
+			Result(func() {
+				Attribute("session", String, func() {
+					Sensitive()
+					Meta("struct:tag:json", "session_id,omitempty")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Header("key:X-Api-Key")
+				Param("token")
+			})
+		})
+	})
+}
+
 var PayloadCookieCustomNameDSL = func() {
 	Service("ServiceCookieCustomName", func() {
 		Method("MethodCookieCustomName", func() {
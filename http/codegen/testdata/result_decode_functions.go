@@ -934,3 +934,49 @@ func DecodeMethodEmptyErrorResponseBodyResponse(decoder func(*http.Response) goa
 	}
 }
 `
+
+var ProblemDetailsErrorResponseDecodeCode = `// DecodeMethodProblemDetailsErrorResponseResponse returns a decoder for
+// responses returned by the ServiceProblemDetailsErrorResponse
+// MethodProblemDetailsErrorResponse endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeMethodProblemDetailsErrorResponseResponse may return the following
+// errors:
+//   - "bad_request" (type *goa.ServiceError): http.StatusBadRequest
+//   - "custom" (type *serviceproblemdetailserrorresponse.CustomError): http.StatusConflict
+//   - error: internal error
+func DecodeMethodProblemDetailsErrorResponseResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusBadRequest:
+			return nil, goahttp.DecodeProblemDetails(decoder(resp))
+		case http.StatusConflict:
+			var (
+				body MethodProblemDetailsErrorResponseCustomResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", err)
+			}
+			return nil, NewMethodProblemDetailsErrorResponseCustom(&body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceProblemDetailsErrorResponse", "MethodProblemDetailsErrorResponse", resp.StatusCode, string(body))
+		}
+	}
+}
+`
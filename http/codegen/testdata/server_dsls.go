@@ -248,6 +248,63 @@ var ServerSimpleRoutingDSL = func() {
 	})
 }
 
+var ServerProblemDetailsDSL = func() {
+	API("ProblemDetailsAPI", func() {
+		ProblemDetails()
+	})
+	Service("ServiceProblemDetailsServer", func() {
+		Method("server-problem-details", func() {
+			HTTP(func() {
+				GET("/problem/details")
+			})
+		})
+	})
+}
+
+var ServerMutualTLSDSL = func() {
+	var MTLS = MutualTLSSecurity("mtls")
+	Service("ServiceMutualTLSServer", func() {
+		Method("server-mutual-tls", func() {
+			Security(MTLS)
+			HTTP(func() {
+				GET("/mutual/tls")
+			})
+		})
+	})
+}
+
+var ServerSignatureDSL = func() {
+	var Signed = SignatureSecurity("signed", func() {
+		SignedComponents("@method", "@path", "content-digest")
+	})
+	Service("ServiceSignatureServer", func() {
+		Method("server-signature", func() {
+			Security(Signed)
+			Payload(String)
+			HTTP(func() {
+				POST("/signature")
+			})
+		})
+	})
+}
+
+var ServerSessionDSL = func() {
+	var Session = SessionSecurity("session", func() {
+		CSRF(DoubleSubmitCSRF)
+	})
+	Service("ServiceSessionServer", func() {
+		Method("server-session", func() {
+			Security(Session)
+			Payload(func() {
+				SessionID("session_id", String)
+			})
+			HTTP(func() {
+				DELETE("/session")
+			})
+		})
+	})
+}
+
 var ServerTrailingSlashRoutingDSL = func() {
 	Service("ServiceTrailingSlashRoutingServer", func() {
 		Method("server-trailing-slash-routing", func() {
@@ -5,7 +5,8 @@ var ServerMultiEndpointsConstructorCode = `// New instantiates HTTP handlers for
 // on the given mux using the HTTP verb and path defined in the design.
 // errhandler is called whenever a response fails to be encoded. formatter is
 // used to format errors returned by the service methods prior to encoding.
-// Both errhandler and formatter are optional and can be nil.
+// Both errhandler and formatter are optional and can be nil. Use SetHooks to
+// register lifecycle hooks.
 func New(
 	e *servicemultiendpoints.Endpoints,
 	mux goahttp.Muxer,
@@ -14,13 +15,15 @@ func New(
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) *Server {
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"MethodMultiEndpoints1", "GET", "/server_multi_endpoints/{id}"},
 			{"MethodMultiEndpoints2", "POST", "/server_multi_endpoints"},
 		},
-		MethodMultiEndpoints1: NewMethodMultiEndpoints1Handler(e.MethodMultiEndpoints1, mux, decoder, encoder, errhandler, formatter),
-		MethodMultiEndpoints2: NewMethodMultiEndpoints2Handler(e.MethodMultiEndpoints2, mux, decoder, encoder, errhandler, formatter),
+		MethodMultiEndpoints1: NewMethodMultiEndpoints1HandlerWithHooks(e.MethodMultiEndpoints1, mux, decoder, encoder, errhandler, formatter, &hooks.MethodMultiEndpoints1),
+		MethodMultiEndpoints2: NewMethodMultiEndpoints2HandlerWithHooks(e.MethodMultiEndpoints2, mux, decoder, encoder, errhandler, formatter, &hooks.MethodMultiEndpoints2),
+		hooks:                 hooks,
 	}
 }
 `
@@ -30,7 +33,8 @@ var ServerMultiBasesConstructorCode = `// New instantiates HTTP handlers for all
 // on the given mux using the HTTP verb and path defined in the design.
 // errhandler is called whenever a response fails to be encoded. formatter is
 // used to format errors returned by the service methods prior to encoding.
-// Both errhandler and formatter are optional and can be nil.
+// Both errhandler and formatter are optional and can be nil. Use SetHooks to
+// register lifecycle hooks.
 func New(
 	e *servicemultibases.Endpoints,
 	mux goahttp.Muxer,
@@ -39,12 +43,14 @@ func New(
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 ) *Server {
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"MethodMultiBases", "GET", "/base_1/{id}"},
 			{"MethodMultiBases", "GET", "/base_2/{id}"},
 		},
-		MethodMultiBases: NewMethodMultiBasesHandler(e.MethodMultiBases, mux, decoder, encoder, errhandler, formatter),
+		MethodMultiBases: NewMethodMultiBasesHandlerWithHooks(e.MethodMultiBases, mux, decoder, encoder, errhandler, formatter, &hooks.MethodMultiBases),
+		hooks:            hooks,
 	}
 }
 `
@@ -54,7 +60,8 @@ var ServerFileServerConstructorCode = `// New instantiates HTTP handlers for all
 // on the given mux using the HTTP verb and path defined in the design.
 // errhandler is called whenever a response fails to be encoded. formatter is
 // used to format errors returned by the service methods prior to encoding.
-// Both errhandler and formatter are optional and can be nil.
+// Both errhandler and formatter are optional and can be nil. Use SetHooks to
+// register lifecycle hooks.
 func New(
 	e *servicefileserver.Endpoints,
 	mux goahttp.Muxer,
@@ -78,6 +85,7 @@ func New(
 		fileSystemPathToFile3JSON = http.Dir(".")
 	}
 	fileSystemPathToFile3JSON = appendPrefix(fileSystemPathToFile3JSON, "/path/to")
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"Serve /path/to/file1.json", "GET", "/server_file_server/file1.json"},
@@ -87,6 +95,7 @@ func New(
 		PathToFile1JSON: http.FileServer(fileSystemPathToFile1JSON),
 		PathToFile2JSON: http.FileServer(fileSystemPathToFile2JSON),
 		PathToFile3JSON: http.FileServer(fileSystemPathToFile3JSON),
+		hooks:           hooks,
 	}
 }
 `
@@ -96,7 +105,8 @@ var ServerMixedConstructorCode = `// New instantiates HTTP handlers for all the
 // given mux using the HTTP verb and path defined in the design. errhandler is
 // called whenever a response fails to be encoded. formatter is used to format
 // errors returned by the service methods prior to encoding. Both errhandler
-// and formatter are optional and can be nil.
+// and formatter are optional and can be nil. Use SetHooks to register
+// lifecycle hooks.
 func New(
 	e *servermixed.Endpoints,
 	mux goahttp.Muxer,
@@ -115,6 +125,7 @@ func New(
 		fileSystemPathToFile2JSON = http.Dir(".")
 	}
 	fileSystemPathToFile2JSON = appendPrefix(fileSystemPathToFile2JSON, "/path/to")
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"MethodMixed1", "GET", "/resources1/{id}"},
@@ -122,10 +133,11 @@ func New(
 			{"Serve /path/to/file1.json", "GET", "/file1.json"},
 			{"Serve /path/to/file2.json", "GET", "/file2.json"},
 		},
-		MethodMixed1:    NewMethodMixed1Handler(e.MethodMixed1, mux, decoder, encoder, errhandler, formatter),
-		MethodMixed2:    NewMethodMixed2Handler(e.MethodMixed2, mux, decoder, encoder, errhandler, formatter),
+		MethodMixed1:    NewMethodMixed1HandlerWithHooks(e.MethodMixed1, mux, decoder, encoder, errhandler, formatter, &hooks.MethodMixed1),
+		MethodMixed2:    NewMethodMixed2HandlerWithHooks(e.MethodMixed2, mux, decoder, encoder, errhandler, formatter, &hooks.MethodMixed2),
 		PathToFile1JSON: http.FileServer(fileSystemPathToFile1JSON),
 		PathToFile2JSON: http.FileServer(fileSystemPathToFile2JSON),
+		hooks:           hooks,
 	}
 }
 `
@@ -135,7 +147,8 @@ var ServerMultipartConstructorCode = `// New instantiates HTTP handlers for all
 // on the given mux using the HTTP verb and path defined in the design.
 // errhandler is called whenever a response fails to be encoded. formatter is
 // used to format errors returned by the service methods prior to encoding.
-// Both errhandler and formatter are optional and can be nil.
+// Both errhandler and formatter are optional and can be nil. Use SetHooks to
+// register lifecycle hooks.
 func New(
 	e *servicemultipart.Endpoints,
 	mux goahttp.Muxer,
@@ -145,11 +158,13 @@ func New(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	serviceMultipartMethodMultiBasesDecoderFn ServiceMultipartMethodMultiBasesDecoderFunc,
 ) *Server {
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"MethodMultiBases", "GET", "/"},
 		},
-		MethodMultiBases: NewMethodMultiBasesHandler(e.MethodMultiBases, mux, NewServiceMultipartMethodMultiBasesDecoder(mux, serviceMultipartMethodMultiBasesDecoderFn), encoder, errhandler, formatter),
+		MethodMultiBases: NewMethodMultiBasesHandlerWithHooks(e.MethodMultiBases, mux, NewServiceMultipartMethodMultiBasesDecoder(mux, serviceMultipartMethodMultiBasesDecoderFn), encoder, errhandler, formatter, &hooks.MethodMultiBases),
+		hooks:            hooks,
 	}
 }
 `
@@ -159,7 +174,8 @@ var ServerStreamingConstructorCode = `// New instantiates HTTP handlers for all
 // on the given mux using the HTTP verb and path defined in the design.
 // errhandler is called whenever a response fails to be encoded. formatter is
 // used to format errors returned by the service methods prior to encoding.
-// Both errhandler and formatter are optional and can be nil.
+// Both errhandler and formatter are optional and can be nil. Use SetHooks to
+// register lifecycle hooks.
 func New(
 	e *streamingresultservice.Endpoints,
 	mux goahttp.Muxer,
@@ -173,11 +189,13 @@ func New(
 	if configurer == nil {
 		configurer = &ConnConfigurer{}
 	}
+	hooks := &Hooks{}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"StreamingResultMethod", "GET", "/{x}"},
 		},
-		StreamingResultMethod: NewStreamingResultMethodHandler(e.StreamingResultMethod, mux, decoder, encoder, errhandler, formatter, upgrader, configurer.StreamingResultMethodFn),
+		StreamingResultMethod: NewStreamingResultMethodHandlerWithHooks(e.StreamingResultMethod, mux, decoder, encoder, errhandler, formatter, &hooks.StreamingResultMethod, upgrader, configurer.StreamingResultMethodFn),
+		hooks:                 hooks,
 	}
 }
 `
@@ -28,22 +28,43 @@ func NewStreamingResultMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewStreamingResultMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewStreamingResultMethodHandlerWithHooks is like
+// NewStreamingResultMethodHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewStreamingResultMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*streamingresultservice.Request, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
 		decodeRequest = DecodeStreamingResultMethodRequest(mux, decoder)
-		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError   = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
 		v := &streamingresultservice.StreamingResultMethodEndpointInput{
@@ -57,6 +78,7 @@ func NewStreamingResultMethodHandler(
 			Payload: payload.(*streamingresultservice.Request),
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, payload, nil, err)
 		if err != nil {
 			if v.Stream.(*StreamingResultMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -95,7 +117,11 @@ func (s *StreamingResultMethodServerStream) Send(v *streamingresultservice.UserT
 	}
 	res := v
 	body := NewStreamingResultMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -149,7 +175,11 @@ func (s *StreamingResultWithViewsMethodServerStream) Send(v *streamingresultwith
 	case "default", "":
 		body = NewStreamingResultWithViewsMethodResponseBody(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -173,14 +203,33 @@ func NewStreamingResultNoPayloadMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewStreamingResultNoPayloadMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewStreamingResultNoPayloadMethodHandlerWithHooks is like
+// NewStreamingResultNoPayloadMethodHandler but also invokes the given
+// lifecycle hooks. hooks may be nil.
+func NewStreamingResultNoPayloadMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
-		encodeError = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNoPayloadService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
@@ -194,6 +243,7 @@ func NewStreamingResultNoPayloadMethodHandler(
 			},
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, nil, nil, err)
 		if err != nil {
 			if v.Stream.(*StreamingResultNoPayloadMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -447,7 +497,11 @@ func (s *StreamingResultWithExplicitViewMethodServerStream) Send(v *streamingres
 	}
 	res := streamingresultwithexplicitviewservice.NewViewedUsertype(v, "extended")
 	body := NewStreamingResultWithExplicitViewMethodResponseBodyExtended(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -485,7 +539,11 @@ func (s *StreamingResultCollectionWithViewsMethodServerStream) Send(v streamingr
 	case "default", "":
 		body = NewUsertypeResponseCollection(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -556,7 +614,11 @@ func (s *StreamingResultCollectionWithExplicitViewMethodServerStream) Send(v str
 	}
 	res := streamingresultcollectionwithexplicitviewservice.NewViewedUsertypeCollection(v, "tiny")
 	body := NewUsertypeResponseTinyCollection(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -648,7 +710,11 @@ func (s *StreamingResultPrimitiveMethodServerStream) Send(v string) error {
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -694,7 +760,11 @@ func (s *StreamingResultPrimitiveArrayMethodServerStream) Send(v []int32) error
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -740,7 +810,11 @@ func (s *StreamingResultPrimitiveMapMethodServerStream) Send(v map[int32]string)
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -787,7 +861,11 @@ func (s *StreamingResultUserTypeArrayMethodServerStream) Send(v []*streamingresu
 	}
 	res := v
 	body := NewStreamingResultUserTypeArrayMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -836,7 +914,11 @@ func (s *StreamingResultUserTypeMapMethodServerStream) Send(v map[string]*stream
 	}
 	res := v
 	body := NewStreamingResultUserTypeMapMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -913,22 +995,43 @@ func NewStreamingPayloadMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewStreamingPayloadMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewStreamingPayloadMethodHandlerWithHooks is like
+// NewStreamingPayloadMethodHandler but also invokes the given lifecycle hooks.
+// hooks may be nil.
+func NewStreamingPayloadMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*streamingpayloadservice.Payload, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
 		decodeRequest = DecodeStreamingPayloadMethodRequest(mux, decoder)
-		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError   = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
 		v := &streamingpayloadservice.StreamingPayloadMethodEndpointInput{
@@ -942,6 +1045,7 @@ func NewStreamingPayloadMethodHandler(
 			Payload: payload.(*streamingpayloadservice.Payload),
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, payload, nil, err)
 		if err != nil {
 			if v.Stream.(*StreamingPayloadMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -964,7 +1068,11 @@ func (s *StreamingPayloadMethodServerStream) SendAndClose(v *streamingpayloadser
 	defer s.conn.Close()
 	res := v
 	body := NewStreamingPayloadMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err := s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -999,6 +1107,7 @@ func (s *StreamingPayloadMethodServerStream) Recv() (*streamingpayloadservice.Re
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewStreamingPayloadMethodStreamingBody(msg), nil
 }
 `
@@ -1082,14 +1191,33 @@ func NewStreamingPayloadNoPayloadMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewStreamingPayloadNoPayloadMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewStreamingPayloadNoPayloadMethodHandlerWithHooks is like
+// NewStreamingPayloadNoPayloadMethodHandler but also invokes the given
+// lifecycle hooks. hooks may be nil.
+func NewStreamingPayloadNoPayloadMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
-		encodeError = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingPayloadNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingPayloadNoPayloadService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
@@ -1103,6 +1231,7 @@ func NewStreamingPayloadNoPayloadMethodHandler(
 			},
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, nil, nil, err)
 		if err != nil {
 			if v.Stream.(*StreamingPayloadNoPayloadMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -1212,6 +1341,7 @@ func (s *StreamingPayloadNoResultMethodServerStream) Recv() (string, error) {
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1269,7 +1399,11 @@ func (s *StreamingPayloadResultWithViewsMethodServerStream) SendAndClose(v *stre
 	case "default", "":
 		body = NewStreamingPayloadResultWithViewsMethodResponseBody(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err := s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1304,6 +1438,7 @@ func (s *StreamingPayloadResultWithViewsMethodServerStream) Recv() (float32, err
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1370,7 +1505,11 @@ func (s *StreamingPayloadResultWithExplicitViewMethodServerStream) SendAndClose(
 	defer s.conn.Close()
 	res := streamingpayloadresultwithexplicitviewservice.NewViewedUsertype(v, "extended")
 	body := NewStreamingPayloadResultWithExplicitViewMethodResponseBodyExtended(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err := s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1405,6 +1544,7 @@ func (s *StreamingPayloadResultWithExplicitViewMethodServerStream) Recv() (float
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1464,7 +1604,11 @@ func (s *StreamingPayloadResultCollectionWithViewsMethodServerStream) SendAndClo
 	case "default", "":
 		body = NewUsertypeResponseCollection(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err := s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1500,6 +1644,7 @@ func (s *StreamingPayloadResultCollectionWithViewsMethodServerStream) Recv() (an
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1570,7 +1715,11 @@ func (s *StreamingPayloadResultCollectionWithExplicitViewMethodServerStream) Sen
 	defer s.conn.Close()
 	res := streamingpayloadresultcollectionwithexplicitviewservice.NewViewedUsertypeCollection(v, "tiny")
 	body := NewUsertypeResponseTinyCollection(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err := s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1606,6 +1755,7 @@ func (s *StreamingPayloadResultCollectionWithExplicitViewMethodServerStream) Rec
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1657,7 +1807,11 @@ var StreamingPayloadPrimitiveServerStreamSendCode = `// SendAndClose streams ins
 func (s *StreamingPayloadPrimitiveMethodServerStream) SendAndClose(v string) error {
 	defer s.conn.Close()
 	res := v
-	return s.conn.WriteJSON(res)
+	if err := s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1692,6 +1846,7 @@ func (s *StreamingPayloadPrimitiveMethodServerStream) Recv() (string, error) {
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -1735,7 +1890,11 @@ var StreamingPayloadPrimitiveArrayServerStreamSendCode = `// SendAndClose stream
 func (s *StreamingPayloadPrimitiveArrayMethodServerStream) SendAndClose(v []string) error {
 	defer s.conn.Close()
 	res := v
-	return s.conn.WriteJSON(res)
+	if err := s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1770,6 +1929,7 @@ func (s *StreamingPayloadPrimitiveArrayMethodServerStream) Recv() ([]int32, erro
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return body, nil
 }
 `
@@ -1813,7 +1973,11 @@ var StreamingPayloadPrimitiveMapServerStreamSendCode = `// SendAndClose streams
 func (s *StreamingPayloadPrimitiveMapMethodServerStream) SendAndClose(v map[int]int) error {
 	defer s.conn.Close()
 	res := v
-	return s.conn.WriteJSON(res)
+	if err := s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1848,6 +2012,7 @@ func (s *StreamingPayloadPrimitiveMapMethodServerStream) Recv() (map[string]int3
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return body, nil
 }
 `
@@ -1891,7 +2056,11 @@ var StreamingPayloadUserTypeArrayServerStreamSendCode = `// SendAndClose streams
 func (s *StreamingPayloadUserTypeArrayMethodServerStream) SendAndClose(v string) error {
 	defer s.conn.Close()
 	res := v
-	return s.conn.WriteJSON(res)
+	if err := s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -1927,6 +2096,7 @@ func (s *StreamingPayloadUserTypeArrayMethodServerStream) Recv() ([]*streamingpa
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewStreamingPayloadUserTypeArrayMethodArray(body), nil
 }
 `
@@ -1972,7 +2142,11 @@ var StreamingPayloadUserTypeMapServerStreamSendCode = `// SendAndClose streams i
 func (s *StreamingPayloadUserTypeMapMethodServerStream) SendAndClose(v []string) error {
 	defer s.conn.Close()
 	res := v
-	return s.conn.WriteJSON(res)
+	if err := s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2008,6 +2182,7 @@ func (s *StreamingPayloadUserTypeMapMethodServerStream) Recv() (map[string]*stre
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewStreamingPayloadUserTypeMapMethodMap(body), nil
 }
 `
@@ -2059,22 +2234,43 @@ func NewBidirectionalStreamingMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewBidirectionalStreamingMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewBidirectionalStreamingMethodHandlerWithHooks is like
+// NewBidirectionalStreamingMethodHandler but also invokes the given lifecycle
+// hooks. hooks may be nil.
+func NewBidirectionalStreamingMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[*bidirectionalstreamingservice.Payload, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
 		decodeRequest = DecodeBidirectionalStreamingMethodRequest(mux, decoder)
-		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError   = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		payload, err := decodeRequest(r)
 		if err != nil {
+			hooks.DecodeError(ctx, err)
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
+		hooks.RequestDecoded(ctx, payload)
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
 		v := &bidirectionalstreamingservice.BidirectionalStreamingMethodEndpointInput{
@@ -2088,6 +2284,7 @@ func NewBidirectionalStreamingMethodHandler(
 			Payload: payload.(*bidirectionalstreamingservice.Payload),
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, payload, nil, err)
 		if err != nil {
 			if v.Stream.(*BidirectionalStreamingMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -2126,7 +2323,11 @@ func (s *BidirectionalStreamingMethodServerStream) Send(v *bidirectionalstreamin
 	}
 	res := v
 	body := NewBidirectionalStreamingMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2161,6 +2362,7 @@ func (s *BidirectionalStreamingMethodServerStream) Recv() (*bidirectionalstreami
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewBidirectionalStreamingMethodStreamingBody(msg), nil
 }
 `
@@ -2269,14 +2471,33 @@ func NewBidirectionalStreamingNoPayloadMethodHandler(
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
 	upgrader goahttp.Upgrader,
 	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	return NewBidirectionalStreamingNoPayloadMethodHandlerWithHooks(endpoint, mux, decoder, encoder, errhandler, formatter, nil, upgrader, configurer)
+}
+
+// NewBidirectionalStreamingNoPayloadMethodHandlerWithHooks is like
+// NewBidirectionalStreamingNoPayloadMethodHandler but also invokes the given
+// lifecycle hooks. hooks may be nil.
+func NewBidirectionalStreamingNoPayloadMethodHandlerWithHooks(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	hooks *goahttp.ServerHooks[any, any],
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
 ) http.Handler {
 	var (
-		encodeError = goahttp.ErrorEncoder(encoder, formatter)
+		encodeError = hooks.ErrorEncoder(goahttp.ErrorEncoder(encoder, formatter))
 	)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "BidirectionalStreamingNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "BidirectionalStreamingNoPayloadService")
+		w = hooks.ResponseWriter(w)
+		defer hooks.ResponseWritten(ctx, w)
 		var err error
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
@@ -2290,6 +2511,7 @@ func NewBidirectionalStreamingNoPayloadMethodHandler(
 			},
 		}
 		_, err = endpoint(ctx, v)
+		hooks.EndpointResult(ctx, nil, nil, err)
 		if err != nil {
 			if v.Stream.(*BidirectionalStreamingNoPayloadMethodServerStream).conn != nil {
 				// Response writer has been hijacked, do not encode the error
@@ -2426,7 +2648,11 @@ func (s *BidirectionalStreamingResultWithViewsMethodServerStream) Send(v *bidire
 	case "default", "":
 		body = NewBidirectionalStreamingResultWithViewsMethodResponseBody(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2461,6 +2687,7 @@ func (s *BidirectionalStreamingResultWithViewsMethodServerStream) Recv() (float3
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -2568,7 +2795,11 @@ func (s *BidirectionalStreamingResultWithExplicitViewMethodServerStream) Send(v
 	}
 	res := bidirectionalstreamingresultwithexplicitviewservice.NewViewedUsertype(v, "extended")
 	body := NewBidirectionalStreamingResultWithExplicitViewMethodResponseBodyExtended(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2604,6 +2835,7 @@ func (s *BidirectionalStreamingResultWithExplicitViewMethodServerStream) Recv()
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -2677,7 +2909,11 @@ func (s *BidirectionalStreamingResultCollectionWithViewsMethodServerStream) Send
 	case "default", "":
 		body = NewUsertypeResponseCollection(res.Projected)
 	}
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2713,6 +2949,7 @@ func (s *BidirectionalStreamingResultCollectionWithViewsMethodServerStream) Recv
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -2794,7 +3031,11 @@ func (s *BidirectionalStreamingResultCollectionWithExplicitViewMethodServerStrea
 	}
 	res := bidirectionalstreamingresultcollectionwithexplicitviewservice.NewViewedUsertypeCollection(v, "tiny")
 	body := NewUsertypeResponseTinyCollection(res.Projected)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2830,6 +3071,7 @@ func (s *BidirectionalStreamingResultCollectionWithExplicitViewMethodServerStrea
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -2890,7 +3132,11 @@ func (s *BidirectionalStreamingPrimitiveMethodServerStream) Send(v string) error
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -2925,6 +3171,7 @@ func (s *BidirectionalStreamingPrimitiveMethodServerStream) Recv() (string, erro
 	if msg == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return *msg, nil
 }
 `
@@ -2977,7 +3224,11 @@ func (s *BidirectionalStreamingPrimitiveArrayMethodServerStream) Send(v []string
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -3012,6 +3263,7 @@ func (s *BidirectionalStreamingPrimitiveArrayMethodServerStream) Recv() ([]int32
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return body, nil
 }
 `
@@ -3064,7 +3316,11 @@ func (s *BidirectionalStreamingPrimitiveMapMethodServerStream) Send(v map[int]in
 		return err
 	}
 	res := v
-	return s.conn.WriteJSON(res)
+	if err = s.conn.WriteJSON(res); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -3099,6 +3355,7 @@ func (s *BidirectionalStreamingPrimitiveMapMethodServerStream) Recv() (map[strin
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return body, nil
 }
 `
@@ -3153,7 +3410,11 @@ func (s *BidirectionalStreamingUserTypeArrayMethodServerStream) Send(v []*bidire
 	}
 	res := v
 	body := NewBidirectionalStreamingUserTypeArrayMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -3189,6 +3450,7 @@ func (s *BidirectionalStreamingUserTypeArrayMethodServerStream) Recv() ([]*bidir
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewBidirectionalStreamingUserTypeArrayMethodArray(body), nil
 }
 `
@@ -3247,7 +3509,11 @@ func (s *BidirectionalStreamingUserTypeMapMethodServerStream) Send(v map[string]
 	}
 	res := v
 	body := NewBidirectionalStreamingUserTypeMapMethodResponseBody(res)
-	return s.conn.WriteJSON(body)
+	if err = s.conn.WriteJSON(body); err != nil {
+		return err
+	}
+	goahttp.StreamMessageSent(s.r.Context())
+	return nil
 }
 `
 
@@ -3283,6 +3549,7 @@ func (s *BidirectionalStreamingUserTypeMapMethodServerStream) Recv() (map[string
 	if body == nil {
 		return rv, io.EOF
 	}
+	goahttp.StreamMessageReceived(s.r.Context())
 	return NewBidirectionalStreamingUserTypeMapMethodMap(body), nil
 }
 `
@@ -23,7 +23,9 @@ import (
 // values even when not required (to account for the fact that they have a
 // default value so cannot be nil) otherwise the fields are values only when
 // required.
-func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefault bool) string {
+//
+// json is the JSON serialization policy used to compute the JSON tags.
+func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefault bool, json *expr.JSONPolicyExpr) string {
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
 		if t, _ := codegen.GetMetaType(att); t != "" {
@@ -31,17 +33,17 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 		}
 		return codegen.GoNativeTypeName(actual)
 	case *expr.Array:
-		d := goTypeDef(scope, actual.ElemType, ptr, useDefault)
+		d := goTypeDef(scope, actual.ElemType, ptr, useDefault, json)
 		if expr.IsObject(actual.ElemType.Type) {
 			d = "*" + d
 		}
 		return "[]" + d
 	case *expr.Map:
-		keyDef := goTypeDef(scope, actual.KeyType, ptr, useDefault)
+		keyDef := goTypeDef(scope, actual.KeyType, ptr, useDefault, json)
 		if expr.IsObject(actual.KeyType.Type) {
 			keyDef = "*" + keyDef
 		}
-		elemDef := goTypeDef(scope, actual.ElemType, ptr, useDefault)
+		elemDef := goTypeDef(scope, actual.ElemType, ptr, useDefault, json)
 		if expr.IsObject(actual.ElemType.Type) {
 			elemDef = "*" + elemDef
 		}
@@ -60,7 +62,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 			)
 			{
 				fn = codegen.GoifyAtt(at, name, true)
-				tdef = goTypeDef(scope, at, ptr, useDefault)
+				tdef = goTypeDef(scope, at, ptr, useDefault, json)
 				if expr.IsPrimitive(at.Type) {
 					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any {
 						tdef = "*" + tdef
@@ -82,7 +84,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 						optional = !ma.IsRequired(name)
 					}
 				}
-				tags = attributeTags(mat, at, elem, optional)
+				tags = attributeTags(mat, at, elem, optional, json)
 			}
 			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, tags))
 			return nil
@@ -96,8 +98,9 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 	}
 }
 
-// attributeTags computes the struct field tags.
-func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) string {
+// attributeTags computes the struct field tags. The JSON tag follows the given
+// JSON serialization policy unless the attribute defines its own tags.
+func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool, json *expr.JSONPolicyExpr) string {
 	if tags := codegen.AttributeTags(parent, att); tags != "" {
 		return tags
 	}
@@ -105,5 +108,15 @@ func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) str
 	if optional {
 		o = ",omitempty"
 	}
-	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	if json.IsDefault() {
+		return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	}
+	jo := o
+	if json.EmitZeroValues {
+		jo = ""
+	}
+	if mt, _ := codegen.GetMetaType(att); mt == "" && json.EncodeAsString(att) {
+		jo = ",string" + jo
+	}
+	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, codegen.JSONName(json, att, t), jo, t, o)
 }
@@ -63,6 +63,26 @@ func TestGoTypeDef(t *testing.T) {
 				Required: []string{"required", "required_bytes", "required_any"},
 			},
 		}
+		withJSON = &expr.AttributeExpr{
+			Type: &expr.Object{
+				&expr.NamedAttributeExpr{
+					Name:      "first_name",
+					Attribute: &expr.AttributeExpr{Type: expr.String},
+				},
+				&expr.NamedAttributeExpr{
+					Name:      "account_id",
+					Attribute: &expr.AttributeExpr{Type: expr.Int64},
+				},
+				&expr.NamedAttributeExpr{
+					Name:      "custom_json",
+					Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:json": []string{"custom"}}},
+				},
+			},
+			Validation: &expr.ValidationExpr{
+				Required: []string{"first_name"},
+			},
+		}
+		policy = &expr.JSONPolicyExpr{Naming: expr.JSONCamelCase, EmitZeroValues: true, Int64AsString: true}
 	)
 
 	cases := []struct {
@@ -70,15 +90,17 @@ func TestGoTypeDef(t *testing.T) {
 		Attr       *expr.AttributeExpr
 		UsePtr     bool
 		UseDefault bool
+		JSON       *expr.JSONPolicyExpr
 		Def        string
 	}{
-		{"no-default", mixed, false, false, mixedNoDefault},
-		{"use-default", mixed, false, true, mixedUseDefault},
-		{"use-pointer", mixed, true, true, mixedUsePointer},
+		{"no-default", mixed, false, false, nil, mixedNoDefault},
+		{"use-default", mixed, false, true, nil, mixedUseDefault},
+		{"use-pointer", mixed, true, true, nil, mixedUsePointer},
+		{"json-policy", withJSON, false, false, policy, withJSONPolicy},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
-			def := goTypeDef(codegen.NewNameScope(), c.Attr, c.UsePtr, c.UseDefault)
+			def := goTypeDef(codegen.NewNameScope(), c.Attr, c.UsePtr, c.UseDefault, c.JSON)
 			assert.Equal(t, c.Def, def)
 		})
 	}
@@ -126,4 +148,10 @@ var (
 	CustomType *pkg.String ` + "`" + `form:"custom_type,omitempty" json:"custom_type,omitempty" xml:"custom_type,omitempty"` + "`" + `
 	CustomTag *string ` + "`" + `foo:"bar"` + "`" + `
 }`
+
+	withJSONPolicy = `struct {
+	FirstName string ` + "`" + `form:"first_name" json:"firstName" xml:"first_name"` + "`" + `
+	AccountID *int64 ` + "`" + `form:"account_id,omitempty" json:"accountId,string" xml:"account_id,omitempty"` + "`" + `
+	CustomJSON *string ` + "`" + `json:"custom"` + "`" + `
+}`
 )
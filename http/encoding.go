@@ -6,6 +6,7 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
@@ -13,6 +14,7 @@ import (
 	"strings"
 
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
 )
 
 const (
@@ -215,20 +217,48 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // struct and if so uses the error temporary and timeout fields to infer a
 // proper HTTP status code and marshals the error struct to the body using the
 // provided encoder. If the error is not a goa ServiceError struct then it is
-// encoded as a permanent internal server error. This behavior as well as the
-// shape of the response can be overridden by providing a non-nil formatter.
+// encoded as a permanent internal server error, see ErrorMapper to encode
+// such errors with other status codes. Errors that wrap a
+// security.AuthError and no goa ServiceError are encoded as "unauthorized"
+// errors with status code 401 and a generic message, the failure of each
+// security scheme is not disclosed to the client, see
+// security.HandleAuthErrors to log it. This behavior as well as the shape of
+// the response can be overridden by providing a non-nil formatter.
+// The response Content-Type header is set to the problem details media type
+// if the formatter returns a ProblemDetails, see NewProblemDetails.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
 		enc := encoder(ctx, w)
 		if formatter == nil {
 			formatter = NewErrorResponse
 		}
+		ctx = context.WithValue(ctx, responseWriterKey{}, w)
+		var (
+			gerr *goa.ServiceError
+			aerr *security.AuthError
+		)
+		if errors.As(err, &aerr) && !errors.As(err, &gerr) {
+			err = goa.NewServiceError(err, goa.Unauthorized, false, false, false)
+		}
 		resp := formatter(ctx, err)
+		if _, ok := resp.(*ProblemDetails); ok {
+			SetProblemContentType(w)
+		}
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
 }
 
+// ProblemErrorEncoder returns an encoder that encodes errors returned by
+// service methods as RFC 9457 problem details unless a non-nil formatter is
+// provided, see ErrorEncoder and NewProblemDetails.
+func ProblemErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
+	if formatter == nil {
+		formatter = NewProblemDetails
+	}
+	return ErrorEncoder(encoder, formatter)
+}
+
 // Decode implements the Decoder interface. It simply calls f(v).
 func (f EncodingFunc) Decode(v any) error { return f(v) }
 
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ErrorMapper maps errors that are not defined in the design to HTTP
+	// status codes and error names. Servers use the formatter returned by
+	// Formatter to encode the errors that would otherwise be encoded as
+	// faults with status code 500 and clients use DecodeError to recover
+	// the mapped errors. An ErrorMapper must be configured before it is
+	// used, it is then safe for concurrent use.
+	ErrorMapper struct {
+		// mappings lists the mappings in registration order.
+		mappings []*ErrorMapping
+	}
+
+	// ErrorMapping maps errors that are not defined in the design to a HTTP
+	// status code and error name, see ErrorMapper.
+	ErrorMapping struct {
+		// Name is the name of the error written in the response.
+		Name string
+		// StatusCode is the HTTP response status code.
+		StatusCode int
+		// Target is the error matched using errors.Is, nil if the
+		// mapping was registered with MapFunc or MapErrorAs. The
+		// client returns errors that wrap Target when receiving a
+		// response for a mapped error so that errors.Is may be used to
+		// test for it.
+		Target error
+
+		// match returns true if the mapping applies to the given error.
+		match func(error) bool
+	}
+
+	// responseWriterKey is the context key used by the error encoders to
+	// give the response writer to the error formatters.
+	responseWriterKey struct{}
+
+	// mappedErrorResponse is the response created by the ErrorMapper
+	// formatter for mapped errors formatted as ErrorResponse.
+	mappedErrorResponse struct {
+		*ErrorResponse
+		// status is the mapping status code.
+		status int
+	}
+)
+
+// NewErrorMapper returns an error mapper with no mapping.
+func NewErrorMapper() *ErrorMapper {
+	return &ErrorMapper{}
+}
+
+// Map adds a mapping that encodes errors matching target (as tested with
+// errors.Is) with the given status code and error name and returns the mapper
+// so that calls can be chained, e.g.:
+//
+//	mapper := goahttp.NewErrorMapper().
+//	    Map(sql.ErrNoRows, http.StatusNotFound, "not_found").
+//	    Map(context.DeadlineExceeded, http.StatusGatewayTimeout, "timeout")
+//
+// Mappings are consulted in registration order and only apply to errors
+// that are not goa service errors. Servers and clients should use mappers
+// configured with the same mappings.
+func (m *ErrorMapper) Map(target error, status int, name string) *ErrorMapper {
+	m.mappings = append(m.mappings, &ErrorMapping{
+		Name:       name,
+		StatusCode: status,
+		Target:     target,
+		match:      func(err error) bool { return errors.Is(err, target) },
+	})
+	return m
+}
+
+// MapFunc adds a mapping that encodes errors for which match returns true
+// with the given status code and error name. See Map.
+func (m *ErrorMapper) MapFunc(match func(error) bool, status int, name string) *ErrorMapper {
+	m.mappings = append(m.mappings, &ErrorMapping{Name: name, StatusCode: status, match: match})
+	return m
+}
+
+// MapErrorAs adds a mapping to m that encodes errors that have an error of
+// type T in their chain (as tested with errors.As) with the given status code
+// and error name. See ErrorMapper.Map.
+func MapErrorAs[T error](m *ErrorMapper, status int, name string) *ErrorMapper {
+	return m.MapFunc(func(err error) bool {
+		var t T
+		return errors.As(err, &t)
+	}, status, name)
+}
+
+// Lookup returns the first mapping that applies to err, nil if there isn't
+// one or if err is a goa service error.
+func (m *ErrorMapper) Lookup(err error) *ErrorMapping {
+	var gerr *goa.ServiceError
+	if errors.As(err, &gerr) {
+		return nil
+	}
+	for _, em := range m.mappings {
+		if em.match(err) {
+			return em
+		}
+	}
+	return nil
+}
+
+// Formatter returns an error formatter that formats mapped errors as service
+// errors named after the mapping using formatter and sets the response status
+// code to the mapping status code. Errors that are not mapped are given to
+// formatter as is. formatter defaults to NewErrorResponse if nil. The status
+// code of the responses created by formatters that return neither an
+// ErrorResponse nor a ProblemDetails is left to the formatter.
+//
+// errhandler is called with the service error named after the mapping, which
+// wraps the original error, each time an error is mapped. It should be the
+// error handler given to the generated server constructors so that mapped
+// errors get logged like the other errors, e.g.:
+//
+//	srv := svcsvr.New(endpoints, mux, dec, enc, eh, mapper.Formatter(nil, eh))
+//
+// errhandler is called before the response is written and thus must not write
+// to it. The response writer given to errhandler is nil when the formatter is
+// not called by one of the goa error encoders. errhandler may be nil.
+func (m *ErrorMapper) Formatter(formatter func(ctx context.Context, err error) Statuser, errhandler func(context.Context, http.ResponseWriter, error)) func(ctx context.Context, err error) Statuser {
+	if formatter == nil {
+		formatter = NewErrorResponse
+	}
+	return func(ctx context.Context, err error) Statuser {
+		em := m.Lookup(err)
+		if em == nil {
+			return formatter(ctx, err)
+		}
+		merr := goa.NewServiceError(err, em.Name, false, false, false)
+		if errhandler != nil {
+			w, _ := ctx.Value(responseWriterKey{}).(http.ResponseWriter)
+			errhandler(ctx, w, merr)
+		}
+		resp := formatter(ctx, merr)
+		switch r := resp.(type) {
+		case *ProblemDetails:
+			r.Status = em.StatusCode
+			r.Title = http.StatusText(em.StatusCode)
+		case *ErrorResponse:
+			return &mappedErrorResponse{ErrorResponse: r, status: em.StatusCode}
+		}
+		return resp
+	}
+}
+
+// DecodeError returns a ClientError that wraps the target of the mapping
+// whose status code and error name match the response described by err if
+// err is the error returned by the client for an unexpected response, see
+// ErrInvalidResponse. It returns err unchanged otherwise.
+func (m *ErrorMapper) DecodeError(err error) error {
+	var cerr *ClientError
+	if !errors.As(err, &cerr) || cerr.status == 0 {
+		return err
+	}
+	name, message, ok := mappedErrorBody(cerr.body)
+	if !ok {
+		return err
+	}
+	for _, em := range m.mappings {
+		if em.Name == name && em.StatusCode == cerr.status {
+			return &ClientError{Name: name, Message: message, Service: cerr.Service, Method: cerr.Method,
+				Temporary: cerr.Temporary, Timeout: cerr.Timeout, Fault: cerr.Fault, Err: em.Target}
+		}
+	}
+	return err
+}
+
+// StatusCode returns the mapping status code.
+func (r *mappedErrorResponse) StatusCode() int { return r.status }
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestErrorMapperFormatter(t *testing.T) {
+	var (
+		mapped []string
+		errs   []error
+	)
+	mapper := NewErrorMapper().Map(sql.ErrNoRows, http.StatusNotFound, "not_found")
+	MapErrorAs[*fs.PathError](mapper, http.StatusConflict, "path_error")
+	errhandler := func(_ context.Context, w http.ResponseWriter, err error) {
+		assert.NotNil(t, w)
+		var gerr *goa.ServiceError
+		require.ErrorAs(t, err, &gerr)
+		mapped = append(mapped, gerr.Name)
+		errs = append(errs, err)
+	}
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+
+	cases := []struct {
+		name       string
+		formatter  func(context.Context, error) Statuser
+		err        error
+		wantStatus int
+		wantName   string
+	}{
+		{"is", nil, errors.Join(errors.New("lookup"), sql.ErrNoRows), http.StatusNotFound, "not_found"},
+		{"as", nil, &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}, http.StatusConflict, "path_error"},
+		{"problem", NewProblemDetails, sql.ErrNoRows, http.StatusNotFound, "not_found"},
+		{"not mapped", nil, errors.New("boom"), http.StatusInternalServerError, ""},
+		{"service error", nil, goa.PermanentError("bad", "bad"), http.StatusBadRequest, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mapped, errs = nil, nil
+			w := httptest.NewRecorder()
+
+			err := ErrorEncoder(ResponseEncoder, mapper.Formatter(c.formatter, errhandler))(ctx, w, c.err)
+
+			require.NoError(t, err)
+			assert.Equal(t, c.wantStatus, w.Code)
+			if c.wantName == "" {
+				assert.Empty(t, mapped)
+				return
+			}
+			assert.Equal(t, []string{c.wantName}, mapped)
+			assert.ErrorIs(t, errs[0], c.err)
+			assert.Contains(t, w.Body.String(), `"name":"`+c.wantName+`"`)
+		})
+	}
+}
+
+func TestErrorMapperDecodeError(t *testing.T) {
+	mapper := NewErrorMapper().Map(sql.ErrNoRows, http.StatusNotFound, "not_found")
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	require.NoError(t, ErrorEncoder(ResponseEncoder, mapper.Formatter(nil, nil))(ctx, w, sql.ErrNoRows))
+
+	err := mapper.DecodeError(ErrInvalidResponse("svc", "method", w.Code, w.Body.String()))
+
+	var cerr *ClientError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "not_found", cerr.Name)
+	assert.Equal(t, sql.ErrNoRows.Error(), cerr.Message)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = mapper.DecodeError(ErrInvalidResponse("svc", "method", http.StatusTeapot, w.Body.String()))
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "invalid_response", cerr.Name)
+
+	err = NewErrorMapper().DecodeError(ErrInvalidResponse("svc", "method", w.Code, w.Body.String()))
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, "invalid_response", cerr.Name)
+}
@@ -26,6 +26,12 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Violations lists the validation failures that caused the
+		// error, if any.
+		Violations []*goa.Violation `json:"violations,omitempty" xml:"violations>violation,omitempty" form:"violations,omitempty"`
+		// Details contains additional machine-readable information about
+		// the error, if any. Details are not encoded in XML responses.
+		Details map[string]any `json:"details,omitempty" xml:"-" form:"details,omitempty"`
 	}
 
 	// Statuser is implemented by error response object to provide the response
@@ -42,39 +48,52 @@ var (
 	ErrorResponseXMLName = xml.Name{Local: "error"}
 )
 
-// NewErrorResponse creates a HTTP response from the given error.
+// NewErrorResponse creates a HTTP response from the given error. The error
+// messages are localized if the context holds a locale, see goa.WithLocale.
 func NewErrorResponse(ctx context.Context, err error) Statuser {
+	err = goa.LocalizeError(ctx, err)
 	var gerr *goa.ServiceError
 	if errors.As(err, &gerr) {
 		return &ErrorResponse{
-			Name:      gerr.Name,
-			ID:        gerr.ID,
-			Message:   gerr.Message,
-			Timeout:   gerr.Timeout,
-			Temporary: gerr.Temporary,
-			Fault:     gerr.Fault,
+			Name:       gerr.Name,
+			ID:         gerr.ID,
+			Message:    gerr.Message,
+			Timeout:    gerr.Timeout,
+			Temporary:  gerr.Temporary,
+			Fault:      gerr.Fault,
+			Violations: gerr.Violations(),
+			Details:    gerr.Details,
 		}
 	}
 	return NewErrorResponse(ctx, goa.Fault("%s", err.Error()))
 }
 
 func (resp *ErrorResponse) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	type violations struct {
+		Violation []*goa.Violation `xml:"violation"`
+	}
+	var vs *violations
+	if len(resp.Violations) > 0 {
+		vs = &violations{Violation: resp.Violations}
+	}
 	return e.Encode(struct {
-		XMLName   xml.Name
-		Name      string `xml:"name"`
-		ID        string `xml:"id"`
-		Message   string `xml:"message"`
-		Temporary bool   `xml:"temporary"`
-		Timeout   bool   `xml:"timeout"`
-		Fault     bool   `xml:"fault"`
+		XMLName    xml.Name
+		Name       string      `xml:"name"`
+		ID         string      `xml:"id"`
+		Message    string      `xml:"message"`
+		Temporary  bool        `xml:"temporary"`
+		Timeout    bool        `xml:"timeout"`
+		Fault      bool        `xml:"fault"`
+		Violations *violations `xml:"violations,omitempty"`
 	}{
-		XMLName:   ErrorResponseXMLName,
-		Name:      resp.Name,
-		ID:        resp.ID,
-		Message:   resp.Message,
-		Temporary: resp.Temporary,
-		Timeout:   resp.Timeout,
-		Fault:     resp.Fault,
+		XMLName:    ErrorResponseXMLName,
+		Name:       resp.Name,
+		ID:         resp.ID,
+		Message:    resp.Message,
+		Temporary:  resp.Temporary,
+		Timeout:    resp.Timeout,
+		Fault:      resp.Fault,
+		Violations: vs,
 	})
 }
 
@@ -86,6 +105,12 @@ func (resp *ErrorResponse) StatusCode() int {
 	if resp.Name == goa.UnsupportedMediaType {
 		return http.StatusUnsupportedMediaType
 	}
+	if resp.Name == goa.PermissionDenied {
+		return http.StatusForbidden
+	}
+	if resp.Name == goa.Unauthorized {
+		return http.StatusUnauthorized
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewErrorResponseViolations(t *testing.T) {
+	err := goa.MergeErrors(
+		goa.MissingFieldError("name", "body"),
+		goa.ValidatePattern("body.items[0].code", "x", "^[a-z]{2}$"),
+	)
+
+	resp, ok := NewErrorResponse(context.Background(), err).(*ErrorResponse)
+	require.True(t, ok)
+	require.Len(t, resp.Violations, 2)
+
+	b, jerr := json.Marshal(resp.Violations)
+	require.NoError(t, jerr)
+	assert.JSONEq(t, `[
+		{"path": "/name", "code": "missing_field", "message": "\"name\" is missing from body"},
+		{"path": "/items/0/code", "code": "invalid_pattern", "message": "body.items[0].code must match the regexp \"^[a-z]{2}$\" but got value \"x\"", "params": {"pattern": "^[a-z]{2}$"}}
+	]`, string(b))
+}
+
+func TestNewErrorResponseNoViolations(t *testing.T) {
+	resp, ok := NewErrorResponse(context.Background(), goa.Fault("boom")).(*ErrorResponse)
+	require.True(t, ok)
+	assert.Nil(t, resp.Violations)
+}
+
+func TestNewErrorResponseDetails(t *testing.T) {
+	err := goa.PermanentError("quota", "quota exceeded").WithDetails(map[string]any{"limit": 10})
+
+	resp, ok := NewErrorResponse(context.Background(), err).(*ErrorResponse)
+	require.True(t, ok)
+
+	b, jerr := json.Marshal(resp)
+	require.NoError(t, jerr)
+	var got ErrorResponse
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, map[string]any{"limit": float64(10)}, got.Details)
+}
+
+func TestErrorResponseMarshalXMLViolations(t *testing.T) {
+	resp := &ErrorResponse{
+		Name:       goa.MissingField,
+		ID:         "id",
+		Message:    "msg",
+		Violations: []*goa.Violation{{Path: "/name", Code: goa.MissingField, Message: "msg"}},
+	}
+
+	b, err := xml.Marshal(resp)
+	require.NoError(t, err)
+	assert.Equal(t, `<`+ErrorResponseXMLName.Local+`><name>missing_field</name><id>id</id><message>msg</message><temporary>false</temporary><timeout>false</timeout><fault>false</fault><violations><violation><path>/name</path><code>missing_field</code><message>msg</message></violation></violations></`+ErrorResponseXMLName.Local+`>`, string(b))
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ServerHooks lists callbacks invoked by a generated HTTP handler at the
+	// different stages of the request lifecycle. Payload and Result are the
+	// types of the method payload and of the value returned by the method
+	// endpoint, they are any if the method has no payload or no result
+	// (e.g. for streaming methods). All the callbacks are optional and
+	// receive the names of the service and method handling the request.
+	// The payload and result values must not be modified. Use the SetHooks
+	// method of the generated servers to set the hooks.
+	ServerHooks[Payload, Result any] struct {
+		// OnRequestDecoded is called with the decoded payload once the
+		// request is decoded.
+		OnRequestDecoded func(ctx context.Context, service, method string, payload Payload)
+		// OnDecodeError is called when the request fails to be decoded.
+		OnDecodeError func(ctx context.Context, service, method string, err error)
+		// OnEndpointResult is called with the endpoint payload, result
+		// and error once the endpoint returns.
+		OnEndpointResult func(ctx context.Context, service, method string, payload Payload, result Result, err error)
+		// OnEncodeError is called when the response or the error returned
+		// by the endpoint fails to be encoded, before the server error
+		// handler is invoked.
+		OnEncodeError func(ctx context.Context, service, method string, err error)
+		// OnResponseWritten is called with the response status code once
+		// the handler returns.
+		OnResponseWritten func(ctx context.Context, service, method string, status int)
+	}
+)
+
+// RequestDecoded calls the OnRequestDecoded hook if set. payload must be nil
+// or of type Payload. h may be nil.
+func (h *ServerHooks[Payload, Result]) RequestDecoded(ctx context.Context, payload any) {
+	if h == nil || h.OnRequestDecoded == nil {
+		return
+	}
+	p, _ := payload.(Payload)
+	svc, m := hookNames(ctx)
+	h.OnRequestDecoded(ctx, svc, m, p)
+}
+
+// DecodeError calls the OnDecodeError hook if set. h may be nil.
+func (h *ServerHooks[Payload, Result]) DecodeError(ctx context.Context, err error) {
+	if h == nil || h.OnDecodeError == nil {
+		return
+	}
+	svc, m := hookNames(ctx)
+	h.OnDecodeError(ctx, svc, m, err)
+}
+
+// EndpointResult calls the OnEndpointResult hook if set. payload and result
+// must be nil or of type Payload and Result respectively. h may be nil.
+func (h *ServerHooks[Payload, Result]) EndpointResult(ctx context.Context, payload, result any, err error) {
+	if h == nil || h.OnEndpointResult == nil {
+		return
+	}
+	p, _ := payload.(Payload)
+	r, _ := result.(Result)
+	svc, m := hookNames(ctx)
+	h.OnEndpointResult(ctx, svc, m, p, r, err)
+}
+
+// ResponseEncoder returns a response encoder that calls the OnEncodeError hook
+// if set when encode fails. h may be nil.
+func (h *ServerHooks[Payload, Result]) ResponseEncoder(encode func(context.Context, http.ResponseWriter, any) error) func(context.Context, http.ResponseWriter, any) error {
+	if h == nil {
+		return encode
+	}
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		err := encode(ctx, w, v)
+		if err != nil {
+			h.encodeError(ctx, err)
+		}
+		return err
+	}
+}
+
+// ErrorEncoder returns an error encoder that calls the OnEncodeError hook if
+// set when encode fails. h may be nil.
+func (h *ServerHooks[Payload, Result]) ErrorEncoder(encode func(context.Context, http.ResponseWriter, error) error) func(context.Context, http.ResponseWriter, error) error {
+	if h == nil {
+		return encode
+	}
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		err := encode(ctx, w, v)
+		if err != nil {
+			h.encodeError(ctx, err)
+		}
+		return err
+	}
+}
+
+// ResponseWriter returns a response writer that records the response status
+// code if the OnResponseWritten hook is set, w otherwise. h may be nil.
+func (h *ServerHooks[Payload, Result]) ResponseWriter(w http.ResponseWriter) http.ResponseWriter {
+	if h == nil || h.OnResponseWritten == nil {
+		return w
+	}
+	return NewRecordingResponseWriter(w)
+}
+
+// ResponseWritten calls the OnResponseWritten hook if set with the status code
+// recorded by w. w must be the response writer returned by ResponseWriter. h
+// may be nil.
+func (h *ServerHooks[Payload, Result]) ResponseWritten(ctx context.Context, w http.ResponseWriter) {
+	if h == nil || h.OnResponseWritten == nil {
+		return
+	}
+	status := http.StatusOK
+	if rw, ok := w.(*RecordingResponseWriter); ok {
+		status = rw.StatusCode
+	}
+	svc, m := hookNames(ctx)
+	h.OnResponseWritten(ctx, svc, m, status)
+}
+
+// encodeError calls the OnEncodeError hook if set.
+func (h *ServerHooks[Payload, Result]) encodeError(ctx context.Context, err error) {
+	if h.OnEncodeError == nil {
+		return
+	}
+	svc, m := hookNames(ctx)
+	h.OnEncodeError(ctx, svc, m, err)
+}
+
+// hookNames returns the service and method names stored in ctx.
+func hookNames(ctx context.Context) (service, method string) {
+	service, _ = ctx.Value(goa.ServiceKey).(string)
+	method, _ = ctx.Value(goa.MethodKey).(string)
+	return
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestServerHooks(t *testing.T) {
+	var calls []string
+	hooks := &ServerHooks[string, int]{
+		OnRequestDecoded: func(_ context.Context, service, method string, payload string) {
+			calls = append(calls, "decoded "+service+"."+method+" "+payload)
+		},
+		OnDecodeError: func(_ context.Context, service, method string, err error) {
+			calls = append(calls, "decode error "+service+"."+method+" "+err.Error())
+		},
+		OnEndpointResult: func(_ context.Context, service, method string, payload string, result int, err error) {
+			calls = append(calls, "result "+service+"."+method+" "+payload+" "+strconv.Itoa(result))
+		},
+		OnEncodeError: func(_ context.Context, service, method string, err error) {
+			calls = append(calls, "encode error "+service+"."+method+" "+err.Error())
+		},
+		OnResponseWritten: func(_ context.Context, service, method string, status int) {
+			calls = append(calls, "written "+service+"."+method+" "+http.StatusText(status))
+		},
+	}
+	ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+	var (
+		encodeResponse = hooks.ResponseEncoder(func(_ context.Context, _ http.ResponseWriter, v any) error {
+			if v == nil {
+				return errors.New("encode response")
+			}
+			return nil
+		})
+		encodeError = hooks.ErrorEncoder(func(_ context.Context, _ http.ResponseWriter, err error) error {
+			return errors.New("encode " + err.Error())
+		})
+	)
+
+	rec := httptest.NewRecorder()
+	w := hooks.ResponseWriter(rec)
+	hooks.RequestDecoded(ctx, "p")
+	hooks.DecodeError(ctx, errors.New("bad"))
+	hooks.EndpointResult(ctx, "p", 42, nil)
+	hooks.EndpointResult(ctx, "p", nil, errors.New("failed"))
+	assert.NoError(t, encodeResponse(ctx, w, 42))
+	assert.Error(t, encodeResponse(ctx, w, nil))
+	assert.Error(t, encodeError(ctx, w, errors.New("error")))
+	w.WriteHeader(http.StatusTeapot)
+	hooks.ResponseWritten(ctx, w)
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, []string{
+		"decoded svc.meth p",
+		"decode error svc.meth bad",
+		"result svc.meth p 42",
+		"result svc.meth p 0",
+		"encode error svc.meth encode response",
+		"encode error svc.meth encode error",
+		"written svc.meth I'm a teapot",
+	}, calls)
+}
+
+func TestServerHooksNil(t *testing.T) {
+	var hooks *ServerHooks[any, any]
+	ctx := context.Background()
+	rec := httptest.NewRecorder()
+	w := hooks.ResponseWriter(rec)
+	assert.Same(t, rec, w)
+	hooks.RequestDecoded(ctx, nil)
+	hooks.DecodeError(ctx, errors.New("bad"))
+	hooks.EndpointResult(ctx, nil, nil, nil)
+	hooks.ResponseWritten(ctx, w)
+	assert.Nil(t, hooks.ResponseEncoder(nil))
+	assert.Nil(t, hooks.ErrorEncoder(nil))
+
+	hooks = &ServerHooks[any, any]{}
+	assert.Same(t, rec, hooks.ResponseWriter(rec))
+	encodeError := hooks.ErrorEncoder(func(context.Context, http.ResponseWriter, error) error { return errors.New("encode") })
+	assert.NotPanics(t, func() { encodeError(ctx, rec, errors.New("error")) }) // nolint: errcheck
+}
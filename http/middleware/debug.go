@@ -25,8 +25,14 @@ type responseDupper struct {
 
 // Debug returns a debug middleware which prints detailed information about
 // incoming requests and outgoing responses including all headers, parameters
-// and bodies.
-func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
+// and bodies. The values of the headers listed in
+// goahttp.DefaultSensitiveHeaders and of the headers, parameters and body
+// fields specified via the options are redacted. The RedactOptions function of
+// the generated server packages returns the options that redact the attributes
+// marked as sensitive in the design. Use Record to capture the traffic in a
+// format that can be replayed with goahttp.Replay.
+func Debug(mux goahttp.Muxer, w io.Writer, opts ...goahttp.RedactOption) func(http.Handler) http.Handler {
+	redactor := goahttp.NewRedactor(opts...)
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 			buf := &bytes.Buffer{}
@@ -37,7 +43,7 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 
 			// Request URL
-			buf.WriteString(fmt.Sprintf("> [%s] %s %s", reqID, r.Method, r.URL.String()))
+			buf.WriteString(fmt.Sprintf("> [%s] %s %s", reqID, r.Method, redactor.URL(r.URL)))
 
 			// Request Headers
 			keys := make([]string, len(r.Header))
@@ -48,7 +54,7 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, strings.Join(r.Header[k], ", ")))
+				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, redactor.Header(k, r.Header[k])))
 			}
 
 			// Request parameters
@@ -61,7 +67,7 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, params[k]))
+				buf.WriteString(fmt.Sprintf("\n> [%s] %s: %s", reqID, k, redactor.Param(k, params[k])))
 			}
 
 			// Request body
@@ -71,7 +77,7 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			if len(b) > 0 {
 				buf.WriteByte('\n')
-				lines := strings.Split(string(b), "\n")
+				lines := strings.Split(string(redactor.Body(b)), "\n")
 				for _, line := range lines {
 					buf.WriteString(fmt.Sprintf("[%s] %s\n", reqID, line))
 				}
@@ -90,11 +96,11 @@ func Debug(mux goahttp.Muxer, w io.Writer) func(http.Handler) http.Handler {
 			}
 			sort.Strings(keys)
 			for _, k := range keys {
-				buf.WriteString(fmt.Sprintf("\n< [%s] %s: %s", reqID, k, strings.Join(dupper.Header()[k], ", ")))
+				buf.WriteString(fmt.Sprintf("\n< [%s] %s: %s", reqID, k, redactor.Header(k, dupper.Header()[k])))
 			}
 			if dupper.Buffer.Len() > 0 {
 				buf.WriteByte('\n')
-				lines := strings.Split(dupper.Buffer.String(), "\n")
+				lines := strings.Split(string(redactor.Body(dupper.Buffer.Bytes())), "\n")
 				for _, line := range lines {
 					buf.WriteString(fmt.Sprintf("[%s] %s\n", reqID, line))
 				}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Locale returns a middleware that selects the language used to localize the
+// error messages written to the client. The language is negotiated from the
+// request "Accept-Language" header against the languages defined in the given
+// catalog and defaults to English. The goa.DefaultMessageCatalog is used if
+// catalog is nil.
+//
+// example of use:
+//
+//	catalog := goa.NewMessageCatalog()
+//	catalog.Register("fr", goa.Messages{
+//	    goa.MissingField: `le champ {{ printf "%q" .Field }} est requis`,
+//	})
+//	handler = middleware.Locale(catalog)(handler)
+func Locale(catalog *goa.MessageCatalog) func(http.Handler) http.Handler {
+	if catalog == nil {
+		catalog = goa.DefaultMessageCatalog
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := catalog.Negotiate(r.Header.Get("Accept-Language"))
+			ctx := goa.WithLocale(r.Context(), catalog, lang)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
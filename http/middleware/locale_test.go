@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLocale(t *testing.T) {
+	catalog := goa.NewMessageCatalog()
+	if err := catalog.Register("es", goa.Messages{goa.MissingField: `falta el campo {{ printf "%q" .Field }}`}); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"no header", "", `"name" is missing from body`},
+		{"supported", "es-MX, en;q=0.5", `falta el campo "name"`},
+		{"unsupported", "it", `"name" is missing from body`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got string
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				resp := goahttp.NewErrorResponse(r.Context(), goa.MissingFieldError("name", "body"))
+				got = resp.(*goahttp.ErrorResponse).Message
+			})
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.accept != "" {
+				req.Header.Set("Accept-Language", c.accept)
+			}
+
+			httpm.Locale(catalog)(h).ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != c.want {
+				t.Errorf("got message %q, want %q", got, c.want)
+			}
+		})
+	}
+}
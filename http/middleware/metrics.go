@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// metricsBody is a request body that counts the bytes read.
+	metricsBody struct {
+		io.ReadCloser
+		size *int64
+	}
+)
+
+// Metrics returns a middleware that records the RED metrics of the HTTP
+// requests in m. The requests are labelled with the route pattern resolved by
+// mux (which may be nil) and the response status code. The service and method
+// labels as well as the error names are recorded by the m.Endpoint endpoint
+// middleware which must also be mounted. Errors that occur before the endpoint
+// is called (e.g. request decoding errors) are labelled with the value of the
+// "goa-error" response header if any.
+//
+// The messages sent and received by streaming methods implemented with
+// websockets are counted as well.
+//
+// Example:
+//
+//	metrics := middleware.NewMetrics()
+//	endpoints.Use(metrics.Endpoint)
+//	handler = httpmdlwr.Metrics(metrics, mux)(handler)
+func Metrics(m *middleware.Metrics, mux goahttp.ResolverMuxer) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var route string
+			if mux != nil {
+				route = mux.ResolvePattern(r)
+			}
+			ctx, mr := m.StartRequest(r.Context(), route)
+			ctx = goahttp.WithStreamObserver(ctx, mr)
+			if r.ContentLength > 0 {
+				mr.RequestSize = r.ContentLength
+			} else if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &metricsBody{ReadCloser: r.Body, size: &mr.RequestSize}
+			}
+			rw := goahttp.NewRecordingResponseWriter(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			if mr.Error == "" {
+				mr.Error = rw.Header().Get("goa-error")
+			}
+			mr.ResponseSize = rw.BodySize
+			mr.End(strconv.Itoa(rw.StatusCode), rw.StatusCode >= http.StatusBadRequest)
+		})
+	}
+}
+
+// MetricsHandler returns a HTTP handler that writes the metrics collected by m
+// in the Prometheus text exposition format. Mount it on the "/metrics" path to
+// have the metrics scraped by Prometheus:
+//
+//	mux.Handle("GET", "/metrics", httpmdlwr.MetricsHandler(metrics).ServeHTTP)
+func MetricsHandler(m *middleware.Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WriteTo(w) // nolint: errcheck
+	})
+}
+
+// Read counts the bytes read from the request body.
+func (b *metricsBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	*b.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestMetrics(t *testing.T) {
+	var (
+		m   = middleware.NewMetrics()
+		mux = goahttp.NewMuxer()
+	)
+	endpoint := m.Endpoint(func(context.Context, any) (any, error) { return "hello", nil })
+	mux.Use(httpm.Metrics(m, mux))
+	mux.Handle("POST", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body) // nolint: errcheck
+		ctx := context.WithValue(context.WithValue(r.Context(), goa.ServiceKey, "users"), goa.MethodKey, "update")
+		res, _ := endpoint(ctx, nil)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(res.(string))) // nolint: errcheck
+	})
+	mux.Handle("GET", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("goa-error", "not_found")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.Handle("GET", "/chat", func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(context.WithValue(r.Context(), goa.ServiceKey, "chat"), goa.MethodKey, "listen")
+		endpoint(ctx, nil) // nolint: errcheck
+		goahttp.StreamMessageReceived(r.Context())
+		goahttp.StreamMessageSent(r.Context())
+		goahttp.StreamMessageSent(r.Context())
+	})
+	mux.Handle("GET", "/metrics", httpm.MetricsHandler(m).ServeHTTP)
+
+	req := httptest.NewRequest("POST", "/users/42", strings.NewReader("payload"))
+	req.ContentLength = -1
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/42", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/chat", nil))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("got content type %q, expected the Prometheus text format", ct)
+	}
+	out := w.Body.String()
+	for _, line := range []string{
+		`goa_requests_total{service="users",method="update",route="/users/{id}",code="201"} 1`,
+		`goa_requests_total{service="",method="",route="/users/{id}",code="404"} 1`,
+		`goa_request_errors_total{service="",method="",route="/users/{id}",code="404",error="not_found"} 1`,
+		`goa_request_size_bytes_sum{service="users",method="update",route="/users/{id}",code="201"} 7`,
+		`goa_response_size_bytes_sum{service="users",method="update",route="/users/{id}",code="201"} 5`,
+		`goa_stream_messages_received_total{service="chat",method="listen",route="/chat"} 1`,
+		`goa_stream_messages_sent_total{service="chat",method="listen",route="/chat"} 2`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("got metrics:\n%s\nexpected line %q", out, line)
+		}
+	}
+	var buf bytes.Buffer
+	m.WriteTo(&buf) // nolint: errcheck
+	if !strings.Contains(buf.String(), `route="/metrics"`) {
+		t.Error("got no metrics for the metrics handler request")
+	}
+}
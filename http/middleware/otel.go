@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// otelDoer is a client Doer that creates OpenTelemetry client spans and
+	// injects the trace context in the headers of each request it makes.
+	otelDoer struct {
+		Doer
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+		duration   metric.Float64Histogram
+	}
+)
+
+// OTel returns a middleware that traces the HTTP requests using OpenTelemetry
+// and records their duration in the "http.server.request.duration" histogram.
+// The middleware extracts the trace context (W3C traceparent and tracestate
+// headers with the default propagator) from the request headers and creates a
+// server span named after the request method and the route pattern resolved
+// by mux, e.g. "GET /users/{id}". mux may be nil in which case the span is
+// named after the request method only. The span is stored in the request
+// context so that the endpoints and the clients they use create child spans,
+// see middleware.OTelEndpoint and WrapOTelDoer.
+//
+// The tracer provider, meter provider and propagator default to the global
+// ones, see middleware.OTelTracerProvider, middleware.OTelMeterProvider and
+// middleware.OTelPropagator to override them.
+func OTel(mux goahttp.ResolverMuxer, opts ...middleware.OTelOption) func(http.Handler) http.Handler {
+	o := middleware.NewOTelOptions(opts...)
+	tracer := o.Tracer()
+	propagator := o.Propagator()
+	duration, _ := o.Meter().Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests."))
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(r.Method)}
+			name := r.Method
+			if mux != nil {
+				if route := mux.ResolvePattern(r); route != "" {
+					attrs = append(attrs, semconv.HTTPRoute(route))
+					name += " " + route
+				}
+			}
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(semconv.URLPath(r.URL.Path), semconv.URLScheme(scheme(r))))
+			defer span.End()
+
+			rw := goahttp.NewRecordingResponseWriter(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(rw.StatusCode))
+			span.SetAttributes(semconv.HTTPResponseStatusCode(rw.StatusCode))
+			if rw.StatusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rw.StatusCode))
+			}
+			duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		})
+	}
+}
+
+// WrapOTelDoer wraps a goa client Doer so that it creates a OpenTelemetry
+// client span for each request, injects the trace context in the request
+// headers and records the request duration in the
+// "http.client.request.duration" histogram. The span is a child of the span
+// stored in the request context if any. Use it with the generated clients:
+//
+//	doer := middleware.WrapOTelDoer(http.DefaultClient)
+//	c := calcsvc.NewClient(scheme, host, doer, enc, dec, false)
+func WrapOTelDoer(doer Doer, opts ...middleware.OTelOption) Doer {
+	o := middleware.NewOTelOptions(opts...)
+	duration, _ := o.Meter().Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP client requests."))
+	return &otelDoer{
+		Doer:       doer,
+		tracer:     o.Tracer(),
+		propagator: o.Propagator(),
+		duration:   duration,
+	}
+}
+
+// Do creates the client span and injects the trace context in the request
+// headers before making the request.
+func (d *otelDoer) Do(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(r.Method),
+		semconv.ServerAddress(r.URL.Hostname()),
+	}
+	ctx, span := d.tracer.Start(r.Context(), r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(semconv.URLFull(r.URL.String())))
+	defer span.End()
+
+	r = r.WithContext(ctx)
+	d.propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	resp, err := d.Doer.Do(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	d.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	return resp, err
+}
+
+// scheme returns the scheme of the request URL.
+func scheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
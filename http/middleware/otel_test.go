@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestOTel(t *testing.T) {
+	var (
+		exporter = tracetest.NewInMemoryExporter()
+		reader   = sdkmetric.NewManualReader()
+		opts     = []middleware.OTelOption{
+			middleware.OTelTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+			middleware.OTelMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			middleware.OTelPropagator(propagation.TraceContext{}),
+		}
+		mux   = goahttp.NewMuxer()
+		inner trace.SpanContext
+	)
+	mux.Use(httpm.OTel(mux, opts...))
+	mux.Handle("GET", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		inner = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	})
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "GET /users/{id}" {
+		t.Errorf("got span name %q, expected %q", span.Name, "GET /users/{id}")
+	}
+	if got := span.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("got trace ID %q, expected the trace ID of the traceparent header", got)
+	}
+	if got := span.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("got parent span ID %q, expected the span ID of the traceparent header", got)
+	}
+	if inner.SpanID() != span.SpanContext.SpanID() {
+		t.Error("got a request context without the server span")
+	}
+	if !hasAttribute(span.Attributes, "http.response.status_code", "404") {
+		t.Errorf("got attributes %v, expected status code 404", span.Attributes)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 || rm.ScopeMetrics[0].Metrics[0].Name != "http.server.request.duration" {
+		t.Errorf("got metrics %+v, expected http.server.request.duration", rm.ScopeMetrics)
+	}
+}
+
+func TestWrapOTelDoer(t *testing.T) {
+	var (
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		doer     = &headerDoer{}
+		d        = httpm.WrapOTelDoer(doer, middleware.OTelTracerProvider(tp), middleware.OTelPropagator(propagation.TraceContext{}))
+	)
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	req, _ := http.NewRequestWithContext(ctx, "POST", "http://example.com/users", nil)
+	if _, err := d.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, expected 2", len(spans))
+	}
+	client := spans[0]
+	if client.Name != "POST" || client.SpanKind != trace.SpanKindClient {
+		t.Errorf("got span %q of kind %s, expected client span %q", client.Name, client.SpanKind, "POST")
+	}
+	if client.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("got client span without the context span as parent")
+	}
+	expected := "00-" + client.SpanContext.TraceID().String() + "-" + client.SpanContext.SpanID().String() + "-01"
+	if got := doer.header.Get("traceparent"); got != expected {
+		t.Errorf("got traceparent %q, expected %q", got, expected)
+	}
+}
+
+type headerDoer struct {
+	header http.Header
+}
+
+func (d *headerDoer) Do(req *http.Request) (*http.Response, error) {
+	d.header = req.Header
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func hasAttribute(attrs []attribute.KeyValue, key, value string) bool {
+	for _, a := range attrs {
+		if string(a.Key) == key && a.Value.Emit() == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+// recordWriter buffers the response body written to a response writer up to
+// MaxRecordedBodySize bytes.
+type recordWriter struct {
+	*goahttp.RecordingResponseWriter
+	buf       bytes.Buffer
+	truncated bool
+}
+
+// MaxRecordedBodySize is the maximum size in bytes of the request and response
+// bodies recorded by Record. Larger bodies are not recorded and the captures
+// are flagged with BodyTruncated.
+var MaxRecordedBodySize = 1 << 20
+
+// Record returns a middleware that records the incoming requests and outgoing
+// responses with w, see goahttp.NewJSONLinesCaptureWriter and
+// goahttp.NewHARCaptureWriter. Each capture includes the request timing, the
+// request ID if any and the route pattern resolved by mux (which may be nil).
+// The values of the headers listed in goahttp.DefaultSensitiveHeaders and of
+// the headers, query string parameters and body fields specified via the
+// options are redacted. Bodies larger than MaxRecordedBodySize are not
+// recorded.
+//
+// Record is the recording counterpart of Debug: the captures can be replayed
+// against a server and the responses compared with goahttp.Replay. Recording
+// stops at the first error returned by w, the capture writers provided by the
+// goahttp package return that error from Close.
+//
+// Example:
+//
+//	f, _ := os.Create("traffic.jsonl")
+//	w := goahttp.NewJSONLinesCaptureWriter(f)
+//	defer func() {
+//		if err := w.Close(); err != nil {
+//			log.Printf("failed to record traffic: %v", err)
+//		}
+//	}()
+//	handler = httpmdlwr.Record(mux, w, goahttp.RedactFields("password"))(handler)
+func Record(mux goahttp.ResolverMuxer, w goahttp.CaptureWriter, opts ...goahttp.RedactOption) func(http.Handler) http.Handler {
+	var (
+		redactor = goahttp.NewRedactor(opts...)
+		failed   atomic.Bool
+	)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if failed.Load() {
+				h.ServeHTTP(rw, r)
+				return
+			}
+			started := time.Now()
+			var route string
+			if mux != nil {
+				route = mux.ResolvePattern(r)
+			}
+			var (
+				reqb      []byte
+				truncated bool
+			)
+			if r.Body != nil {
+				reqb, _ = io.ReadAll(io.LimitReader(r.Body, int64(MaxRecordedBodySize)+1))
+				r.Body = readCloser{io.MultiReader(bytes.NewReader(reqb), r.Body), r.Body}
+				if len(reqb) > MaxRecordedBodySize {
+					reqb, truncated = nil, true
+				}
+			}
+
+			recorder := &recordWriter{RecordingResponseWriter: goahttp.NewRecordingResponseWriter(rw)}
+			h.ServeHTTP(recorder, r)
+
+			c := &goahttp.Capture{
+				Started:  started,
+				Duration: time.Since(started),
+				Route:    route,
+				Request: &goahttp.CaptureRequest{
+					Method:        r.Method,
+					URL:           redactor.URL(r.URL),
+					Header:        goahttp.NewCaptureHeader(r.Header, redactor),
+					BodyTruncated: truncated,
+				},
+				Response: &goahttp.CaptureResponse{
+					Status:        recorder.StatusCode,
+					Header:        goahttp.NewCaptureHeader(recorder.Header(), redactor),
+					BodyTruncated: recorder.truncated,
+				},
+			}
+			if reqID, ok := r.Context().Value(middleware.RequestIDKey).(string); ok {
+				c.RequestID = reqID
+			}
+			c.Request.Body, c.Request.BodyEncoding = goahttp.NewCaptureBody(redactor.Body(reqb))
+			c.Response.Body, c.Response.BodyEncoding = goahttp.NewCaptureBody(redactor.Body(recorder.buf.Bytes()))
+			if err := w.Write(c); err != nil {
+				failed.Store(true)
+			}
+		})
+	}
+}
+
+// Write buffers b before writing it to the underlying response writer. The
+// buffer is discarded once the body exceeds MaxRecordedBodySize.
+func (w *recordWriter) Write(b []byte) (int, error) {
+	if !w.truncated {
+		if w.buf.Len()+len(b) > MaxRecordedBodySize {
+			w.buf, w.truncated = bytes.Buffer{}, true
+		} else {
+			w.buf.Write(b)
+		}
+	}
+	return w.RecordingResponseWriter.Write(b)
+}
+
+// readCloser reads from a reader and closes a closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
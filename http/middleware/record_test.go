@@ -0,0 +1,143 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestRecord(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		w   = goahttp.NewJSONLinesCaptureWriter(&buf)
+		mux = goahttp.NewMuxer()
+	)
+	mux.Handle("POST", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"` + mux.Vars(r)["id"] + `","token":"secret"}`)) // nolint: errcheck
+	})
+	h := httpm.Record(mux, w, goahttp.RedactFields("password", "token"))(mux)
+
+	req := httptest.NewRequest("POST", "/users/42?token=abc", strings.NewReader(`{"name":"a","password":"p"}`))
+	req.Header.Set("Authorization", "Bearer xyz")
+	req = req.WithContext(context.WithValue(req.Context(), middleware.RequestIDKey, "reqid")) // nolint: staticcheck
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusCreated || rw.Body.String() != `{"id":"42","token":"secret"}` {
+		t.Errorf("got response %d %q, expected the original response", rw.Code, rw.Body.String())
+	}
+	captures, err := goahttp.ReadCaptures(&buf)
+	if err != nil {
+		t.Fatalf("failed to read captures: %s", err)
+	}
+	if len(captures) != 1 {
+		t.Fatalf("got %d captures, expected 1", len(captures))
+	}
+	c := captures[0]
+	if c.Route != "/users/{id}" {
+		t.Errorf("got route %q, expected %q", c.Route, "/users/{id}")
+	}
+	if c.RequestID != "reqid" {
+		t.Errorf("got request ID %q, expected %q", c.RequestID, "reqid")
+	}
+	if c.Duration <= 0 {
+		t.Errorf("got duration %s, expected positive duration", c.Duration)
+	}
+	if c.Request.Method != "POST" || c.Request.URL != "/users/42?token="+url.QueryEscape(goa.Redacted) {
+		t.Errorf("got request %s %s, expected redacted URL", c.Request.Method, c.Request.URL)
+	}
+	if got := c.Request.Header.Get("Authorization"); got != goa.Redacted {
+		t.Errorf("got Authorization header %q, expected %q", got, goa.Redacted)
+	}
+	if c.Request.Body != `{"name":"a","password":"`+goa.Redacted+`"}` {
+		t.Errorf("got request body %q, expected redacted password", c.Request.Body)
+	}
+	if c.Response.Status != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", c.Response.Status, http.StatusCreated)
+	}
+	if c.Response.Body != `{"id":"42","token":"`+goa.Redacted+`"}` {
+		t.Errorf("got response body %q, expected redacted token", c.Response.Body)
+	}
+	if c.Response.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("got response headers %v, expected Content-Type", c.Response.Header)
+	}
+}
+
+func TestRecordMaxBodySize(t *testing.T) {
+	defer func(max int) { httpm.MaxRecordedBodySize = max }(httpm.MaxRecordedBodySize)
+	httpm.MaxRecordedBodySize = 4
+	var (
+		buf bytes.Buffer
+		w   = goahttp.NewJSONLinesCaptureWriter(&buf)
+	)
+	h := httpm.Record(nil, w)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ab")) // nolint: errcheck
+		io.Copy(w, r.Body)    // nolint: errcheck
+	}))
+	cases := map[string]struct {
+		Body                       string
+		ReqTruncated, ResTruncated bool
+	}{
+		"small":     {"cd", false, false},
+		"response":  {"cde", false, true},
+		"truncated": {"cdefg", true, true},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			buf.Reset()
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest("POST", "/", strings.NewReader(c.Body)))
+
+			if rw.Body.String() != "ab"+c.Body {
+				t.Errorf("got response %q, expected %q", rw.Body.String(), "ab"+c.Body)
+			}
+			captures, err := goahttp.ReadCaptures(&buf)
+			if err != nil {
+				t.Fatalf("failed to read captures: %s", err)
+			}
+			req, res := captures[0].Request, captures[0].Response
+			if req.BodyTruncated != c.ReqTruncated || req.BodyTruncated != (req.Body == "") {
+				t.Errorf("got request body %q (truncated: %v), expected truncated %v", req.Body, req.BodyTruncated, c.ReqTruncated)
+			}
+			if res.BodyTruncated != c.ResTruncated || res.BodyTruncated != (res.Body == "") {
+				t.Errorf("got response body %q (truncated: %v), expected truncated %v", res.Body, res.BodyTruncated, c.ResTruncated)
+			}
+		})
+	}
+}
+
+func TestRecordWriteError(t *testing.T) {
+	w := &failingCaptureWriter{}
+	h := httpm.Record(nil, w)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}
+	if w.calls != 1 {
+		t.Errorf("got %d writes, expected recording to stop after the first error", w.calls)
+	}
+}
+
+// failingCaptureWriter is a capture writer whose Write method always fails.
+type failingCaptureWriter struct {
+	calls int
+}
+
+func (w *failingCaptureWriter) Write(*goahttp.Capture) error {
+	w.calls++
+	return errors.New("failed")
+}
+
+func (w *failingCaptureWriter) Close() error { return nil }
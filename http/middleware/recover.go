@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+// Recover returns a middleware that recovers from panics raised by the HTTP
+// handlers, including the generated request decoders and response encoders.
+// The panic value and stack trace are logged with l (which may be nil) and the
+// panic is converted into a goa fault error written using the default goa error
+// encoder with the response encoder returned by encoder, or
+// goahttp.ResponseEncoder if nil. The response is left untouched if the handler
+// already started writing it. http.ErrAbortHandler panics are re-raised so that
+// the HTTP server aborts the response.
+//
+// Use the endpoint middleware middleware.Recover to have the panics raised by
+// the service methods encoded by the method error encoder.
+//
+// Example:
+//
+//	handler = httpmdlwr.Recover(middleware.NewSlogLogger(slog.Default()), nil)(handler)
+func Recover(l middleware.Logger, encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(http.Handler) http.Handler {
+	if encoder == nil {
+		encoder = goahttp.ResponseEncoder
+	}
+	encodeError := goahttp.ErrorEncoder(encoder, nil)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := goahttp.NewRecordingResponseWriter(w)
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				err := middleware.LogPanic(r.Context(), l, v)
+				if !rw.Written {
+					encodeError(r.Context(), w, err) // nolint: errcheck
+				}
+			}()
+			h.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+type testLogger struct {
+	keyvals []any
+}
+
+func (l *testLogger) Log(keyvals ...any) error {
+	l.keyvals = append(l.keyvals, keyvals...)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("panic", func(t *testing.T) {
+		l := &testLogger{}
+		h := httpm.Recover(l, nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("got invalid body %q: %s", w.Body.String(), err)
+		}
+		if body["name"] != "fault" || body["id"] == "" || body["fault"] != true {
+			t.Errorf("got body %v, expected a fault", body)
+		}
+		if len(l.keyvals) < 4 || l.keyvals[3] != body["id"] {
+			t.Errorf("got log %v, expected the error ID %v", l.keyvals, body["id"])
+		}
+	})
+
+	t.Run("response started", func(t *testing.T) {
+		h := httpm.Recover(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("boom")
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf("got status %d, expected %d", w.Code, http.StatusAccepted)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("got body %q, expected none", w.Body.String())
+		}
+	})
+
+	t.Run("abort handler", func(t *testing.T) {
+		h := httpm.Recover(nil, nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+		defer func() {
+			if v := recover(); v != http.ErrAbortHandler {
+				t.Errorf("got panic %v, expected http.ErrAbortHandler", v)
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	})
+}
@@ -7,10 +7,18 @@ import (
 	"goa.design/goa/v3/middleware"
 )
 
+// requestIDDoer is a client Doer that sets the request ID header.
+type requestIDDoer struct {
+	Doer
+	header string
+}
+
 // RequestID returns a middleware, which initializes the context with a unique
 // value under the RequestIDKey key. Optionally uses the incoming "X-Request-Id"
 // header, if present, with or without a length limit to use as request ID. the
-// default behavior is to always generate a new ID.
+// default behavior is to always generate a new ID. The request ID is echoed in
+// the response "X-Request-Id" header (or the header set with
+// RequestIDHeaderOption).
 //
 // examples of use:
 //
@@ -24,22 +32,35 @@ import (
 //	// enable options for using "Custom-Id" header.
 //	service.Use(middleware.RequestID(middleware.RequestIDHeaderOption("Custom-Id"))
 //
+//	// derive the request ID from the "traceparent" header and generate
+//	// UUIDv7 request IDs otherwise.
+//	service.Use(middleware.RequestID(
+//	  middleware.TraceparentRequestIDOption(true),
+//	  middleware.UUIDv7RequestIDOption()))
+//
 // Deprecated: use OpenTelemetry instead, see for example
 // github.com/goadesign/clue. This function will be removed in a future version
 // of Goa.
 func RequestID(options ...middleware.RequestIDOption) func(http.Handler) http.Handler {
 	o := middleware.NewRequestIDOptions(options...)
 	useReqID := o.IsUseRequestID()
+	useTraceparent := o.IsUseTraceparent()
 	reqIDHeader := o.RequestIDHeader()
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
+			var id string
 			if useReqID {
-				if id := r.Header.Get(reqIDHeader); id != "" {
-					ctx = context.WithValue(ctx, middleware.RequestIDKey, id) // nolint: staticcheck
-				}
+				id = r.Header.Get(reqIDHeader)
+			}
+			if id == "" && useTraceparent {
+				id = middleware.TraceparentTraceID(r.Header.Get(middleware.TraceparentHeader))
+			}
+			if id != "" {
+				ctx = context.WithValue(ctx, middleware.RequestIDKey, id) // nolint: staticcheck
 			}
 			ctx = middleware.GenerateRequestID(ctx, o)
+			w.Header().Set(reqIDHeader, ctx.Value(middleware.RequestIDKey).(string))
 			h.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -60,3 +81,33 @@ func RequestIDHeaderOption(name string) middleware.RequestIDOption {
 func XRequestHeaderLimitOption(limit int) middleware.RequestIDOption {
 	return middleware.RequestIDLimitOption(limit)
 }
+
+// TraceparentRequestIDOption enables/disables deriving the request ID from the
+// incoming "traceparent" header.
+func TraceparentRequestIDOption(f bool) middleware.RequestIDOption {
+	return middleware.TraceparentRequestIDOption(f)
+}
+
+// WrapRequestIDDoer wraps a goa client Doer and sets the "X-Request-Id" header
+// (or the header set with RequestIDHeaderOption) of the outgoing requests to
+// the request ID found in the request context if any, so that the downstream
+// service may use the same request ID. Requests that already have the header
+// set are left untouched.
+//
+// Example:
+//
+//	doer := middleware.WrapRequestIDDoer(http.DefaultClient)
+func WrapRequestIDDoer(doer Doer, options ...middleware.RequestIDOption) Doer {
+	o := middleware.NewRequestIDOptions(options...)
+	return &requestIDDoer{Doer: doer, header: o.RequestIDHeader()}
+}
+
+// Do sets the request ID header before making the request.
+func (d *requestIDDoer) Do(r *http.Request) (*http.Response, error) {
+	if r.Header.Get(d.header) == "" {
+		if id, ok := r.Context().Value(middleware.RequestIDKey).(string); ok && id != "" {
+			r.Header.Set(d.header, id)
+		}
+	}
+	return d.Doer.Do(r)
+}
@@ -1,7 +1,10 @@
 package middleware_test
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	httpm "goa.design/goa/v3/http/middleware"
@@ -37,7 +40,9 @@ func TestRequestID(t *testing.T) {
 			}
 			return
 		}
-		ignored http.ResponseWriter
+		traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		uuidV7      = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+		ulid        = regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
 	)
 	for _, tc := range []*requestIDTestCase{
 		{"default without header", nil, makeRequest(""),
@@ -89,12 +94,134 @@ func TestRequestID(t *testing.T) {
 				}
 			},
 		},
+		{"traceparent",
+			[]middleware.RequestIDOption{httpm.TraceparentRequestIDOption(true)},
+			withHeader(makeRequest(""), "traceparent", traceparent),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if id != "4bf92f3577b34da6a3ce929d0e0e4736" {
+					t.Errorf("%s: unexpected request ID: %s != 4bf92f3577b34da6a3ce929d0e0e4736", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
+		{"header takes precedence over traceparent",
+			[]middleware.RequestIDOption{
+				httpm.UseXRequestIDHeaderOption(true),
+				httpm.TraceparentRequestIDOption(true),
+			},
+			withHeader(makeRequest("accept+header"), "traceparent", traceparent),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if id != "accept+header" {
+					t.Errorf("%s: unexpected request ID: %s != accept+header", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
+		{"invalid traceparent",
+			[]middleware.RequestIDOption{httpm.TraceparentRequestIDOption(true)},
+			withHeader(makeRequest(""), "traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01"),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if len(id) != 8 {
+					t.Errorf("%s: unexpected request ID length: %d != 8", r.Header.Get("Test-Case"), len(id))
+				}
+			},
+		},
+		{"uuidv7 generator",
+			[]middleware.RequestIDOption{middleware.UUIDv7RequestIDOption()},
+			makeRequest(""),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if !uuidV7.MatchString(id) {
+					t.Errorf("%s: unexpected request ID: %s is not a UUIDv7", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
+		{"ulid generator",
+			[]middleware.RequestIDOption{middleware.ULIDRequestIDOption()},
+			makeRequest(""),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if !ulid.MatchString(id) {
+					t.Errorf("%s: unexpected request ID: %s is not a ULID", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
+		{"custom generator",
+			[]middleware.RequestIDOption{middleware.RequestIDGeneratorOption(func() string { return "custom" })},
+			makeRequest(""),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if id != "custom" {
+					t.Errorf("%s: unexpected request ID: %s != custom", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
 	} {
+		w := httptest.NewRecorder()
 		httpm.RequestID(tc.options...)(
-			&requestIDTestHandler{tc.name, tc.handler}).ServeHTTP(ignored, tc.request)
+			&requestIDTestHandler{tc.name, tc.handler}).ServeHTTP(w, tc.request)
+		if w.Header().Get("X-Request-Id") == "" {
+			t.Errorf("%s: missing response request ID header", tc.name)
+		}
 	}
 }
 
+func TestRequestIDResponseHeader(t *testing.T) {
+	var id string
+	h := httpm.RequestID(httpm.RequestIDHeaderOption("Custom-Id"))(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		id, _ = r.Context().Value(middleware.RequestIDKey).(string)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if got := w.Header().Get("Custom-Id"); got == "" || got != id {
+		t.Errorf("got response header %q, expected %q", got, id)
+	}
+}
+
+func TestWrapRequestIDDoer(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		header   string
+		expected string
+	}{
+		{"no request ID", "", "", ""},
+		{"request ID", "xyz", "", "xyz"},
+		{"existing header", "xyz", "abc", "abc"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got string
+			doer := httpm.WrapRequestIDDoer(doerFunc(func(r *http.Request) (*http.Response, error) {
+				got = r.Header.Get("X-Request-Id")
+				return nil, nil
+			}))
+			ctx := context.Background()
+			if c.id != "" {
+				ctx = context.WithValue(ctx, middleware.RequestIDKey, c.id) // nolint: staticcheck
+			}
+			req, _ := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+			if c.header != "" {
+				req.Header.Set("X-Request-Id", c.header)
+			}
+			doer.Do(req) // nolint: errcheck
+			if got != c.expected {
+				t.Errorf("got request ID header %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+func withHeader(r *http.Request, name, value string) *http.Request {
+	r.Header.Set(name, value)
+	return r
+}
+
 // ServeHTTP implements http.Handler#ServeHTTP
 func (h *requestIDTestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r.Header.Set("Test-Case", h.testCaseName)
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware"
+)
+
+// Slog returns a middleware that logs the HTTP requests using the given
+// log/slog logger. The middleware logs a single entry once the response is
+// written with the following attributes:
+//
+//   - request_id: the request ID set by the RequestID middleware or a short
+//     unique ID if missing.
+//   - trace_id: the trace ID if any, see middleware.ContextTraceID.
+//   - method, route, path and from: the request HTTP method, the route
+//     pattern resolved by mux (which may be nil), the request path and the
+//     request originator.
+//   - status, duration and bytes: the response status code, the time it took
+//     to handle the request and the response body length.
+//   - error: the name of the error returned by the endpoint if any, as set in
+//     the "goa-error" response header.
+//
+// Requests that fail with a server error (5xx) are logged at the error level,
+// the others at the info level.
+//
+// Example:
+//
+//	handler = httpmdlwr.Slog(slog.Default(), mux)(handler)
+func Slog(l *slog.Logger, mux goahttp.ResolverMuxer) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			rw := goahttp.NewRecordingResponseWriter(w)
+			h.ServeHTTP(rw, r)
+
+			ctx := r.Context()
+			reqID, _ := ctx.Value(middleware.RequestIDKey).(string)
+			if reqID == "" {
+				reqID = shortID()
+			}
+			attrs := []slog.Attr{slog.String("request_id", reqID)}
+			if traceID := middleware.ContextTraceID(ctx); traceID != "" {
+				attrs = append(attrs, slog.String("trace_id", traceID))
+			}
+			attrs = append(attrs, slog.String("method", r.Method))
+			if mux != nil {
+				if route := mux.ResolvePattern(r); route != "" {
+					attrs = append(attrs, slog.String("route", route))
+				}
+			}
+			attrs = append(attrs,
+				slog.String("path", r.URL.Path),
+				slog.String("from", from(r)),
+				slog.Int("status", rw.StatusCode),
+				slog.Duration("duration", time.Since(started)),
+				slog.Int64("bytes", rw.BodySize))
+			if name := rw.Header().Get("goa-error"); name != "" {
+				attrs = append(attrs, slog.String("error", name))
+			}
+			level := slog.LevelInfo
+			if rw.StatusCode >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			l.LogAttrs(ctx, level, "request", attrs...)
+		})
+	}
+}
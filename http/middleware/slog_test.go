@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+)
+
+func TestSlog(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mux = goahttp.NewMuxer()
+	)
+	mux.Use(httpm.Slog(slog.New(slog.NewJSONHandler(&buf, nil)), mux))
+	mux.Handle("GET", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("goa-error", "fault")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom")) // nolint: errcheck
+	})
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "reqid") // nolint: staticcheck
+	ctx = context.WithValue(ctx, middleware.TraceIDKey, "traceid")            // nolint: staticcheck
+	mux.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"level":      "ERROR",
+		"msg":        "request",
+		"request_id": "reqid",
+		"trace_id":   "traceid",
+		"method":     "GET",
+		"route":      "/users/{id}",
+		"path":       "/users/42",
+		"status":     float64(500),
+		"bytes":      float64(4),
+		"error":      "fault",
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+		}
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Error("got no duration attribute")
+	}
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"net/http"
 	"regexp"
+	"time"
 
+	goahttp "goa.design/goa/v3/http"
 	"goa.design/goa/v3/middleware"
 )
 
@@ -36,37 +38,73 @@ const (
 // https://github.com/goadesign/clue. This function will be removed in a future
 // version of Goa.
 func Trace(opts ...middleware.TraceOption) func(http.Handler) http.Handler {
+	return TraceMux(nil, opts...)
+}
+
+// TraceMux is like Trace but matches the SampleRoute rules against the route
+// pattern resolved by mux (e.g. "/users/{id}") instead of the request path.
+// mux may be nil in which case TraceMux behaves like Trace.
+//
+// Deprecated: use OpenTelemetry instead, see for example
+// https://github.com/goadesign/clue. This function will be removed in a future
+// version of Goa.
+func TraceMux(mux goahttp.ResolverMuxer, opts ...middleware.TraceOption) func(http.Handler) http.Handler {
 	o := middleware.NewTraceOptions(opts...)
-	sampler := o.NewSampler()
+	sampler := o.NewTraceSampler()
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// insert a new trace ID only if not already being traced.
 			traceID := r.Header.Get(TraceIDHeader)
-			if traceID == "" {
+			traced := traceID != ""
+			var sampled bool
+			if !traced {
 				// check for discards only if we do not already have a trace ID and before sampling.
 				var discarded bool
+				var path string
 				if r.URL != nil { // docs imply but do not actually state that URL cannot be nil
+					path = r.URL.Path
 					for _, discard := range o.Discards() {
-						if discard.MatchString(r.URL.Path) {
+						if discard.MatchString(path) {
 							discarded = true
 							break
 						}
 					}
 				}
-				if !discarded && sampler.Sample() {
-					// insert tracing only within sample.
-					traceID = o.TraceID()
+				if !discarded {
+					route := path
+					if mux != nil {
+						if pattern := mux.ResolvePattern(r); pattern != "" {
+							route = pattern
+						}
+					}
+					sampled = sampler.SampleRoute(route)
+					if sampled || sampler.Tail() {
+						// insert tracing only within sample or if the
+						// decision is deferred.
+						traceID = o.TraceID()
+					}
 				}
 			}
 			if traceID == "" {
 				h.ServeHTTP(w, r)
-			} else {
-				// insert IDs into context to enable tracing.
-				spanID := o.SpanID()
-				parentID := r.Header.Get(ParentSpanIDHeader)
-				ctx := middleware.WithSpan(r.Context(), traceID, spanID, parentID)
+				return
+			}
+			// insert IDs into context to enable tracing.
+			spanID := o.SpanID()
+			parentID := r.Header.Get(ParentSpanIDHeader)
+			ctx := middleware.WithSpan(r.Context(), traceID, spanID, parentID)
+			if traced || !sampler.Tail() {
 				h.ServeHTTP(w, r.WithContext(ctx))
+				return
 			}
+			// buffer the spans until the request completes.
+			buf := middleware.NewSpanBuffer()
+			ctx = middleware.WithSpanBuffer(ctx, buf)
+			started := time.Now()
+			rw := goahttp.NewRecordingResponseWriter(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+			failed := rw.StatusCode >= http.StatusInternalServerError || rw.Header().Get("goa-error") != ""
+			buf.Complete(sampler.SampleCompleted(sampled, failed, time.Since(started)))
 		})
 	}
 }
@@ -102,6 +140,28 @@ func DiscardFromTrace(discard *regexp.Regexp) middleware.TraceOption {
 	return middleware.DiscardFromTrace(discard)
 }
 
+// SampleRoute is a wrapper for the top-level SampleRoute. The route is matched
+// against the route pattern resolved by the mux given to TraceMux, against the
+// request path if the middleware is created with Trace.
+func SampleRoute(route *regexp.Regexp, p int) middleware.TraceOption {
+	return middleware.SampleRoute(route, p)
+}
+
+// SampleErrors is a wrapper for the top-level SampleErrors.
+func SampleErrors() middleware.TraceOption {
+	return middleware.SampleErrors()
+}
+
+// SampleSlow is a wrapper for the top-level SampleSlow.
+func SampleSlow(threshold time.Duration) middleware.TraceOption {
+	return middleware.SampleSlow(threshold)
+}
+
+// TailSampling is a wrapper for the top-level TailSampling.
+func TailSampling() middleware.TraceOption {
+	return middleware.TailSampling()
+}
+
 // WrapDoer wraps a goa client Doer and sets the trace headers so that the
 // downstream service may properly retrieve the parent span ID and trace ID.
 func WrapDoer(doer Doer) Doer {
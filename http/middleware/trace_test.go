@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
+	goahttp "goa.design/goa/v3/http"
 	httpm "goa.design/goa/v3/http/middleware"
 	"goa.design/goa/v3/middleware"
 )
@@ -99,3 +101,85 @@ func TestMiddleware(t *testing.T) {
 		}
 	}
 }
+
+func TestTraceTailSampling(t *testing.T) {
+	cases := map[string]struct {
+		Options []middleware.TraceOption
+		Path    string
+		TraceID string
+		Status  int
+		// output
+		Buffered bool
+		Sampled  bool
+	}{
+		"head-sampled":      {[]middleware.TraceOption{httpm.SamplingPercent(100)}, "/", "", http.StatusOK, false, true},
+		"route-not-sampled": {[]middleware.TraceOption{httpm.SampleRoute(regexp.MustCompile("^/health"), 0)}, "/health", "", http.StatusOK, false, false},
+		"tail-sampled":      {[]middleware.TraceOption{httpm.SamplingPercent(100), httpm.TailSampling()}, "/", "", http.StatusOK, true, true},
+		"tail-dropped":      {[]middleware.TraceOption{httpm.SamplingPercent(0), httpm.TailSampling()}, "/", "", http.StatusOK, true, false},
+		"error-sampled":     {[]middleware.TraceOption{httpm.SamplingPercent(0), httpm.SampleErrors()}, "/", "", http.StatusInternalServerError, true, true},
+		"error-dropped":     {[]middleware.TraceOption{httpm.SamplingPercent(0), httpm.SampleErrors()}, "/", "", http.StatusOK, true, false},
+		"slow-dropped":      {[]middleware.TraceOption{httpm.SamplingPercent(0), httpm.SampleSlow(time.Hour)}, "/", "", http.StatusOK, true, false},
+		"traced":            {[]middleware.TraceOption{httpm.SamplingPercent(0), httpm.SampleErrors()}, "/", "trace", http.StatusOK, false, true},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var (
+				buffered bool
+				sampled  bool
+			)
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				if ctx.Value(middleware.TraceIDKey) != nil {
+					if buf := middleware.ContextSpanBuffer(ctx); buf != nil {
+						buffered = true
+						buf.Record(func() { sampled = true })
+					} else {
+						sampled = true
+					}
+				}
+				w.WriteHeader(c.Status)
+			})
+			req, _ := http.NewRequest("GET", c.Path, nil)
+			if c.TraceID != "" {
+				req.Header.Set(httpm.TraceIDHeader, c.TraceID)
+			}
+
+			httpm.Trace(c.Options...)(h).ServeHTTP(httptest.NewRecorder(), req)
+
+			if buffered != c.Buffered {
+				t.Errorf("invalid buffered, expected %v - got %v", c.Buffered, buffered)
+			}
+			if sampled != c.Sampled {
+				t.Errorf("invalid sampled, expected %v - got %v", c.Sampled, sampled)
+			}
+		})
+	}
+}
+
+func TestTraceMuxSampleRoute(t *testing.T) {
+	mux := goahttp.NewMuxer()
+	mux.Handle("GET", "/users/{id}", func(http.ResponseWriter, *http.Request) {})
+	opts := []middleware.TraceOption{httpm.SamplingPercent(100), httpm.SampleRoute(regexp.MustCompile(`^/users/\{id\}$`), 0)}
+	cases := map[string]struct {
+		Middleware func(http.Handler) http.Handler
+		Sampled    bool
+	}{
+		"pattern": {httpm.TraceMux(mux, opts...), false},
+		"path":    {httpm.Trace(opts...), true},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var sampled bool
+			h := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				sampled = r.Context().Value(middleware.TraceIDKey) != nil
+			})
+			req, _ := http.NewRequest("GET", "/users/1", nil)
+
+			c.Middleware(h).ServeHTTP(httptest.NewRecorder(), req)
+
+			if sampled != c.Sampled {
+				t.Errorf("invalid sampled, expected %v - got %v", c.Sampled, sampled)
+			}
+		})
+	}
+}
@@ -76,7 +76,7 @@ func New(service, daemon string) (func(http.Handler) http.Handler, error) {
 				h.ServeHTTP(w, r)
 			} else {
 				hs := &HTTPSegment{
-					Segment:        xray.NewSegment(service, traceID.(string), spanID.(string), connection()),
+					Segment:        xray.NewSegment(service, traceID.(string), spanID.(string), xray.BufferConn(ctx, connection())),
 					ResponseWriter: w,
 				}
 				defer hs.Close()
@@ -181,11 +181,13 @@ func (m *mux) resolveWildcard(method, pattern string) string {
 }
 
 // ensureContext makes sure chi has initialized the request context if it
-// handles it, otherwise it returns nil.
+// handles it, otherwise it returns nil. A new context is used if the request
+// has not been routed by chi yet, e.g. when ResolvePattern is called by a
+// middleware that wraps the mux.
 func (m *mux) ensureContext(r *http.Request) *chi.Context {
 	ctx := chi.RouteContext(r.Context())
 	if ctx == nil {
-		return nil // request not handled by chi
+		ctx = chi.NewRouteContext() // request not routed by chi yet
 	}
 	if ctx.RoutePattern() != "" {
 		return ctx // already initialized
@@ -224,6 +224,8 @@ func TestResolvePattern(t *testing.T) {
 				mux.Handle("GET", p, handler)
 			}
 			req, _ := http.NewRequest("GET", c.URL, nil)
+			// Make sure resolver works with middlewares wrapping the mux.
+			assert.Equal(t, c.Expected, mux.ResolvePattern(req))
 			w := httptest.NewRecorder()
 			mux.ServeHTTP(w, req)
 			assert.True(t, called)
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ProblemDetails is the data structure encoded in HTTP responses that
+	// correspond to errors when the service uses the RFC 9457 "Problem
+	// Details for HTTP APIs" error format. The ServiceError fields that do
+	// not have a standard member are encoded as extension members.
+	ProblemDetails struct {
+		// Type is a URI reference that identifies the problem type.
+		Type string
+		// Title is a short human-readable summary of the problem type.
+		Title string
+		// Status is the HTTP status code of the response.
+		Status int
+		// Detail is a human-readable explanation specific to this
+		// occurrence of the problem.
+		Detail string
+		// Instance is a URI reference that identifies the specific
+		// occurrence of the problem.
+		Instance string
+		// Name is the name of the Goa error.
+		Name string
+		// Temporary indicates whether the error is temporary.
+		Temporary bool
+		// Timeout indicates whether the error is a timeout.
+		Timeout bool
+		// Fault indicates whether the error is a server-side fault.
+		Fault bool
+		// Violations lists the validation failures that caused the
+		// error, if any.
+		Violations []*goa.Violation
+		// Details contains additional machine-readable information about
+		// the error, if any. Details are not encoded in XML responses.
+		Details map[string]any
+		// Extensions contains additional extension members.
+		Extensions map[string]any
+	}
+)
+
+const (
+	// ProblemJSONContentType is the content type of JSON problem details.
+	ProblemJSONContentType = "application/problem+json"
+
+	// ProblemXMLContentType is the content type of XML problem details.
+	ProblemXMLContentType = "application/problem+xml"
+)
+
+// problemMembers lists the members encoded by ProblemDetails that may not be
+// overridden by extensions.
+var problemMembers = map[string]struct{}{
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {},
+	"name": {}, "temporary": {}, "timeout": {}, "fault": {}, "violations": {},
+	"details": {},
+}
+
+// NewProblemDetails creates a RFC 9457 problem details HTTP response from the
+// given error. It can be given as the error formatter to the generated server
+// constructors. The response status code is computed using the same heuristic
+// as ErrorResponse and the error messages are localized if the context holds a
+// locale. The problem type is the Goa error name used as a relative URI
+// reference, see ProblemDetailsFormatter to use absolute URIs.
+func NewProblemDetails(ctx context.Context, err error) Statuser {
+	return newProblemDetails(ctx, err, "")
+}
+
+// ProblemDetailsFormatter returns an error formatter that creates problem
+// details like NewProblemDetails but prepends typeBaseURI to the Goa error name
+// to build the problem type URI, e.g. "https://example.com/problems/". It can
+// be given as the error formatter to the generated server constructors.
+func ProblemDetailsFormatter(typeBaseURI string) func(ctx context.Context, err error) Statuser {
+	return func(ctx context.Context, err error) Statuser {
+		return newProblemDetails(ctx, err, typeBaseURI)
+	}
+}
+
+// newProblemDetails implements NewProblemDetails and ProblemDetailsFormatter.
+func newProblemDetails(ctx context.Context, err error, typeBaseURI string) *ProblemDetails {
+	err = goa.LocalizeError(ctx, err)
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		return newProblemDetails(ctx, goa.Fault("%s", err.Error()), typeBaseURI)
+	}
+	status := (&ErrorResponse{
+		Name:      gerr.Name,
+		Temporary: gerr.Temporary,
+		Timeout:   gerr.Timeout,
+		Fault:     gerr.Fault,
+	}).StatusCode()
+	return &ProblemDetails{
+		Type:       typeBaseURI + gerr.Name,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     gerr.Message,
+		Instance:   gerr.ID,
+		Name:       gerr.Name,
+		Temporary:  gerr.Temporary,
+		Timeout:    gerr.Timeout,
+		Fault:      gerr.Fault,
+		Violations: gerr.Violations(),
+		Details:    gerr.Details,
+	}
+}
+
+// ProblemDetailsEncoder returns an encoder that writes the given error as a
+// RFC 9457 problem details response with the given status code. The problem
+// details are created with formatter if it returns a ProblemDetails (e.g. a
+// formatter returned by ProblemDetailsFormatter), with NewProblemDetails
+// otherwise. The generated code uses ProblemDetailsEncoder to encode the errors
+// defined in the design when the service uses the problem details error
+// format.
+func ProblemDetailsEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser, status int) func(context.Context, http.ResponseWriter, error) error {
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		var pd *ProblemDetails
+		if formatter != nil {
+			pd, _ = formatter(context.WithValue(ctx, responseWriterKey{}, w), err).(*ProblemDetails)
+		}
+		if pd == nil {
+			pd = newProblemDetails(ctx, err, "")
+		}
+		pd.Status = status
+		pd.Title = http.StatusText(status)
+		w.Header().Set("goa-error", pd.Name)
+		enc := encoder(ctx, w)
+		SetProblemContentType(w)
+		w.WriteHeader(status)
+		return enc.Encode(pd)
+	}
+}
+
+// DecodeProblemDetails decodes a problem details response body using the
+// given decoder and returns the corresponding ServiceError. The generated
+// client code uses DecodeProblemDetails to decode the errors defined in the
+// design when the service uses the problem details error format.
+func DecodeProblemDetails(dec Decoder) error {
+	var pd ProblemDetails
+	if err := dec.Decode(&pd); err != nil {
+		return goa.DecodePayloadError(err.Error())
+	}
+	gerr := &goa.ServiceError{
+		Name:      pd.Name,
+		ID:        pd.Instance,
+		Message:   pd.Detail,
+		Temporary: pd.Temporary,
+		Timeout:   pd.Timeout,
+		Fault:     pd.Fault,
+		Details:   pd.Details,
+	}
+	return gerr.WithViolations(pd.Violations...)
+}
+
+// SetProblemContentType sets the response Content-Type header to the problem
+// details media type that corresponds to the media type already set by the
+// response encoder. The header is left untouched if the encoder media type is
+// neither JSON nor XML.
+func SetProblemContentType(w http.ResponseWriter) {
+	ct := w.Header().Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	switch {
+	case ct == "" || ct == "application/json" || strings.HasSuffix(ct, "+json"):
+		w.Header().Set("Content-Type", ProblemJSONContentType)
+	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
+		w.Header().Set("Content-Type", ProblemXMLContentType)
+	}
+}
+
+// StatusCode returns the problem status.
+func (p *ProblemDetails) StatusCode() int { return p.Status }
+
+// MarshalJSON encodes the problem details as a JSON object, the extension
+// members are encoded alongside the standard members.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+11)
+	for k, v := range p.Extensions {
+		if _, ok := problemMembers[k]; !ok {
+			m[k] = v
+		}
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	m["name"] = p.Name
+	m["temporary"] = p.Temporary
+	m["timeout"] = p.Timeout
+	m["fault"] = p.Fault
+	if len(p.Violations) > 0 {
+		m["violations"] = p.Violations
+	}
+	if len(p.Details) > 0 {
+		m["details"] = p.Details
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a JSON problem details object, members that are not
+// known are stored in Extensions.
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	fields := map[string]any{
+		"type":       &p.Type,
+		"title":      &p.Title,
+		"status":     &p.Status,
+		"detail":     &p.Detail,
+		"instance":   &p.Instance,
+		"name":       &p.Name,
+		"temporary":  &p.Temporary,
+		"timeout":    &p.Timeout,
+		"fault":      &p.Fault,
+		"violations": &p.Violations,
+		"details":    &p.Details,
+	}
+	for k, v := range raw {
+		if f, ok := fields[k]; ok {
+			if err := json.Unmarshal(v, f); err != nil {
+				return err
+			}
+			continue
+		}
+		var ext any
+		if err := json.Unmarshal(v, &ext); err != nil {
+			return err
+		}
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any)
+		}
+		p.Extensions[k] = ext
+	}
+	return nil
+}
+
+// MarshalXML encodes the problem details using the XML format defined in RFC
+// 9457 Appendix B. Extension members are not encoded.
+func (p *ProblemDetails) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	type violations struct {
+		Violation []*goa.Violation `xml:"violation"`
+	}
+	var vs *violations
+	if len(p.Violations) > 0 {
+		vs = &violations{Violation: p.Violations}
+	}
+	return e.Encode(struct {
+		XMLName    xml.Name    `xml:"urn:ietf:rfc:7807 problem"`
+		Type       string      `xml:"type"`
+		Title      string      `xml:"title"`
+		Status     int         `xml:"status"`
+		Detail     string      `xml:"detail,omitempty"`
+		Instance   string      `xml:"instance,omitempty"`
+		Name       string      `xml:"name"`
+		Temporary  bool        `xml:"temporary"`
+		Timeout    bool        `xml:"timeout"`
+		Fault      bool        `xml:"fault"`
+		Violations *violations `xml:"violations,omitempty"`
+	}{
+		Type:       p.Type,
+		Title:      p.Title,
+		Status:     p.Status,
+		Detail:     p.Detail,
+		Instance:   p.Instance,
+		Name:       p.Name,
+		Temporary:  p.Temporary,
+		Timeout:    p.Timeout,
+		Fault:      p.Fault,
+		Violations: vs,
+	})
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantType   string
+		wantStatus int
+		wantFault  bool
+	}{
+		{"validation", goa.MissingFieldError("name", "body"), goa.MissingField, http.StatusBadRequest, false},
+		{"timeout", goa.PermanentTimeoutError("slow", "too slow"), "slow", http.StatusRequestTimeout, false},
+		{"unsupported media type", goa.UnsupportedMediaTypeError("text/csv"), goa.UnsupportedMediaType, http.StatusUnsupportedMediaType, false},
+		{"not a service error", errors.New("boom"), "fault", http.StatusInternalServerError, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pd, ok := NewProblemDetails(context.Background(), c.err).(*ProblemDetails)
+			require.True(t, ok)
+			assert.Equal(t, c.wantType, pd.Type)
+			assert.Equal(t, c.wantStatus, pd.Status)
+			assert.Equal(t, c.wantStatus, pd.StatusCode())
+			assert.Equal(t, http.StatusText(c.wantStatus), pd.Title)
+			assert.Equal(t, c.wantFault, pd.Fault)
+			assert.NotEmpty(t, pd.Instance)
+		})
+	}
+}
+
+func TestProblemDetailsFormatter(t *testing.T) {
+	formatter := ProblemDetailsFormatter("https://example.com/problems/")
+
+	pd, ok := formatter(context.Background(), goa.MissingFieldError("name", "body")).(*ProblemDetails)
+
+	require.True(t, ok)
+	assert.Equal(t, "https://example.com/problems/"+goa.MissingField, pd.Type)
+	assert.Equal(t, http.StatusBadRequest, pd.Status)
+
+	relative := NewProblemDetails(context.Background(), goa.MissingFieldError("name", "body")).(*ProblemDetails)
+	assert.Equal(t, goa.MissingField, relative.Type)
+}
+
+func TestProblemDetailsJSON(t *testing.T) {
+	pd := &ProblemDetails{
+		Type:       "https://example.com/problems/missing_field",
+		Title:      "Bad Request",
+		Status:     http.StatusBadRequest,
+		Detail:     "\"name\" is missing from body",
+		Instance:   "abc",
+		Name:       goa.MissingField,
+		Violations: []*goa.Violation{{Path: "/name", Code: goa.MissingField, Message: "\"name\" is missing from body"}},
+		Extensions: map[string]any{"trace": "123", "status": 500},
+	}
+
+	b, err := json.Marshal(pd)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "https://example.com/problems/missing_field",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "\"name\" is missing from body",
+		"instance": "abc",
+		"name": "missing_field",
+		"temporary": false,
+		"timeout": false,
+		"fault": false,
+		"violations": [{"path": "/name", "code": "missing_field", "message": "\"name\" is missing from body"}],
+		"trace": "123"
+	}`, string(b))
+
+	var got ProblemDetails
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, pd.Type, got.Type)
+	assert.Equal(t, pd.Status, got.Status)
+	assert.Equal(t, pd.Violations, got.Violations)
+	assert.Equal(t, map[string]any{"trace": "123"}, got.Extensions)
+}
+
+func TestProblemErrorEncoder(t *testing.T) {
+	cases := []struct {
+		accept string
+		wantCT string
+		prefix string
+	}{
+		{"application/json", ProblemJSONContentType, "{"},
+		{"application/xml", ProblemXMLContentType, `<problem xmlns="urn:ietf:rfc:7807">`},
+	}
+	for _, c := range cases {
+		t.Run(c.accept, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.accept)
+			w := httptest.NewRecorder()
+
+			err := ProblemErrorEncoder(ResponseEncoder, nil)(ctx, w, goa.MissingFieldError("name", "body"))
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, c.wantCT, w.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(w.Body.String(), c.prefix), w.Body.String())
+		})
+	}
+}
+
+func TestProblemDetailsEncoderDecode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	serr := goa.PermanentError("not_found", "no such thing").WithDetails(map[string]any{"resource": "widget"})
+
+	err := ProblemDetailsEncoder(ResponseEncoder, nil, http.StatusNotFound)(ctx, w, serr)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "not_found", w.Header().Get("goa-error"))
+	assert.Equal(t, ProblemJSONContentType, w.Header().Get("Content-Type"))
+
+	resp := w.Result()
+	derr := DecodeProblemDetails(ResponseDecoder(resp))
+	var gerr *goa.ServiceError
+	require.ErrorAs(t, derr, &gerr)
+	assert.Equal(t, "not_found", gerr.Name)
+	assert.Equal(t, serr.ID, gerr.ID)
+	assert.Equal(t, "no such thing", gerr.Message)
+	assert.Equal(t, map[string]any{"resource": "widget"}, gerr.Details)
+}
+
+func TestProblemDetailsEncoderFormatter(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	formatter := ProblemDetailsFormatter("https://example.com/problems/")
+
+	err := ProblemDetailsEncoder(ResponseEncoder, formatter, http.StatusNotFound)(ctx, w, goa.PermanentError("not_found", "no such thing"))
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "https://example.com/problems/not_found", body["type"])
+}
+
+func TestProblemDetailsDecodeViolations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	serr := goa.MergeErrors(goa.MissingFieldError("name", "body"), goa.InvalidRangeError("body.age", 200, 150, false))
+
+	require.NoError(t, ProblemDetailsEncoder(ResponseEncoder, nil, http.StatusBadRequest)(ctx, w, serr))
+
+	derr := DecodeProblemDetails(ResponseDecoder(w.Result()))
+	var gerr *goa.ServiceError
+	require.ErrorAs(t, derr, &gerr)
+	vs := gerr.Violations()
+	require.Len(t, vs, 2)
+	assert.Equal(t, "/name", vs[0].Path)
+	assert.Equal(t, goa.MissingField, vs[0].Code)
+	assert.Equal(t, "/age", vs[1].Path)
+	assert.Equal(t, goa.InvalidRange, vs[1].Code)
+	assert.Equal(t, serr.Error(), gerr.Message)
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// RecordingResponseWriter is a http.ResponseWriter that records the response
+// status code and body size. It is used by the server hooks and the HTTP
+// middlewares that report on responses.
+type RecordingResponseWriter struct {
+	http.ResponseWriter
+	// StatusCode is the response status code, http.StatusOK if the
+	// handler does not call WriteHeader and http.StatusSwitchingProtocols
+	// if it hijacks the connection.
+	StatusCode int
+	// BodySize is the number of bytes written to the response body.
+	BodySize int64
+	// Written is true once the handler has written the response status
+	// code or body or hijacked the connection.
+	Written bool
+}
+
+// NewRecordingResponseWriter returns a RecordingResponseWriter that wraps w.
+func NewRecordingResponseWriter(w http.ResponseWriter) *RecordingResponseWriter {
+	return &RecordingResponseWriter{ResponseWriter: w, StatusCode: http.StatusOK}
+}
+
+// WriteHeader records the value of the status code before writing it.
+func (w *RecordingResponseWriter) WriteHeader(code int) {
+	w.StatusCode = code
+	w.Written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write counts the bytes written to the response body.
+func (w *RecordingResponseWriter) Write(b []byte) (int, error) {
+	w.Written = true
+	n, err := w.ResponseWriter.Write(b)
+	w.BodySize += int64(n)
+	return n, err
+}
+
+// Flush implements the http.Flusher interface if the underlying response
+// writer supports it.
+func (w *RecordingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface if the underlying response
+// writer supports it.
+func (w *RecordingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.StatusCode = http.StatusSwitchingProtocols
+		w.Written = true
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// Unwrap returns the underlying response writer so that it may be used with
+// http.ResponseController.
+func (w *RecordingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
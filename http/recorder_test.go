@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordingResponseWriter(t *testing.T) {
+	w := NewRecordingResponseWriter(httptest.NewRecorder())
+	if w.StatusCode != http.StatusOK || w.Written {
+		t.Errorf("got status %d and written %t, expected %d and false", w.StatusCode, w.Written, http.StatusOK)
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("hello")) // nolint: errcheck
+	if w.StatusCode != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", w.StatusCode, http.StatusCreated)
+	}
+	if w.BodySize != 5 {
+		t.Errorf("got body size %d, expected 5", w.BodySize)
+	}
+	if !w.Written {
+		t.Error("got written false, expected true")
+	}
+	if _, _, err := w.Hijack(); err == nil {
+		t.Error("got no error hijacking a recorder, expected one")
+	}
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Redactor masks the values of sensitive headers, parameters and body
+	// fields in dumped HTTP requests and responses. It is used by the debug
+	// doer returned by NewDebugDoer and by the http/middleware Debug
+	// middleware.
+	Redactor struct {
+		headers map[string]struct{}
+		fields  map[string]struct{}
+	}
+
+	// RedactOption configures a Redactor.
+	RedactOption func(*Redactor)
+)
+
+// DefaultSensitiveHeaders lists the headers whose values are always redacted.
+var DefaultSensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// NewRedactor returns a redactor that masks the headers listed in
+// DefaultSensitiveHeaders as well as the headers, parameters and body fields
+// specified via the given options.
+func NewRedactor(opts ...RedactOption) *Redactor {
+	r := &Redactor{
+		headers: make(map[string]struct{}),
+		fields:  make(map[string]struct{}),
+	}
+	for _, h := range DefaultSensitiveHeaders {
+		r.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RedactHeaders adds the given names to the list of headers whose values are
+// redacted.
+func RedactHeaders(names ...string) RedactOption {
+	return func(r *Redactor) {
+		for _, n := range names {
+			r.headers[http.CanonicalHeaderKey(n)] = struct{}{}
+		}
+	}
+}
+
+// RedactFields adds the given names to the list of body fields and
+// parameters whose values are redacted. Body fields are matched against the
+// keys of JSON objects at any depth.
+func RedactFields(names ...string) RedactOption {
+	return func(r *Redactor) {
+		for _, n := range names {
+			r.fields[n] = struct{}{}
+		}
+	}
+}
+
+// Header returns the values of the header with the given name joined with
+// commas, or Redacted if the header is sensitive.
+func (r *Redactor) Header(name string, vals []string) string {
+	if _, ok := r.headers[http.CanonicalHeaderKey(name)]; ok {
+		return goa.Redacted
+	}
+	return strings.Join(vals, ", ")
+}
+
+// HeaderValues returns a copy of the values of the header with the given name,
+// or a single Redacted value if the header is sensitive.
+func (r *Redactor) HeaderValues(name string, vals []string) []string {
+	if _, ok := r.headers[http.CanonicalHeaderKey(name)]; ok {
+		return []string{goa.Redacted}
+	}
+	return append([]string(nil), vals...)
+}
+
+// Param returns the given parameter value or Redacted if the parameter is
+// sensitive.
+func (r *Redactor) Param(name, val string) string {
+	if _, ok := r.fields[name]; ok {
+		return goa.Redacted
+	}
+	return val
+}
+
+// URL returns the string representation of u where the values of sensitive
+// query string parameters are redacted.
+func (r *Redactor) URL(u *url.URL) string {
+	if len(r.fields) == 0 || u.RawQuery == "" {
+		return u.String()
+	}
+	q := u.Query()
+	redacted := false
+	for k := range q {
+		if _, ok := r.fields[k]; ok {
+			q[k] = []string{goa.Redacted}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	ru := *u
+	ru.RawQuery = q.Encode()
+	return ru.String()
+}
+
+// Body returns b where the values of the sensitive fields are redacted. b is
+// returned unchanged if it is not a JSON document or if it does not contain
+// any sensitive field.
+func (r *Redactor) Body(b []byte) []byte {
+	if len(r.fields) == 0 || len(b) == 0 {
+		return b
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+	if !r.redact(v) {
+		return b
+	}
+	rb, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return rb
+}
+
+// redact replaces the values of the sensitive fields in v recursively and
+// returns true if any value was replaced.
+func (r *Redactor) redact(v any) bool {
+	redacted := false
+	switch actual := v.(type) {
+	case map[string]any:
+		for k, val := range actual {
+			if _, ok := r.fields[k]; ok {
+				actual[k] = goa.Redacted
+				redacted = true
+				continue
+			}
+			if r.redact(val) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, val := range actual {
+			if r.redact(val) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
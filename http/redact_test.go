@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactorBody(t *testing.T) {
+	r := NewRedactor(RedactFields("password", "token"))
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"top-level":  {`{"login":"joe","password":"secret"}`, `{"login":"joe","password":"[REDACTED]"}`},
+		"nested":     {`{"creds":[{"token":"secret"}]}`, `{"creds":[{"token":"[REDACTED]"}]}`},
+		"unchanged":  {`{ "login": "joe" }`, `{ "login": "joe" }`},
+		"not-json":   {`password=secret`, `password=secret`},
+		"empty-body": {``, ``},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := string(r.Body([]byte(tc.body)))
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactorHeaderAndURL(t *testing.T) {
+	r := NewRedactor(RedactHeaders("x-api-key"), RedactFields("token"))
+	if got := r.Header("authorization", []string{"Bearer secret"}); got != "[REDACTED]" {
+		t.Errorf("got %q for default sensitive header", got)
+	}
+	if got := r.Header("X-Api-Key", []string{"secret"}); got != "[REDACTED]" {
+		t.Errorf("got %q for sensitive header", got)
+	}
+	if got := r.Header("Accept", []string{"a", "b"}); got != "a, b" {
+		t.Errorf("got %q for non sensitive header", got)
+	}
+	u, _ := url.Parse("http://localhost/foo?token=secret&page=1")
+	if got := r.URL(u); strings.Contains(got, "secret") || !strings.Contains(got, "page=1") {
+		t.Errorf("got %q, token not redacted", got)
+	}
+}
+
+func TestDebugDoerRedacts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"password":"secret"}`)) // nolint: errcheck
+	}))
+	defer srv.Close()
+
+	doer := NewDebugDoer(http.DefaultClient, RedactFields("password"))
+	req, _ := http.NewRequest("POST", srv.URL, bytes.NewBufferString(`{"password":"secret"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"password":"secret"}` {
+		t.Errorf("got response body %s, redaction must not alter the response", body)
+	}
+	var buf bytes.Buffer
+	doer.Fprint(&buf)
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("got %s, sensitive values not redacted", buf.String())
+	}
+}
@@ -0,0 +1,303 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ReplayResult is the result of replaying a capture.
+	ReplayResult struct {
+		// Capture is the replayed capture.
+		Capture *Capture
+		// Status is the status code of the new response.
+		Status int
+		// Header contains the headers of the new response.
+		Header http.Header
+		// Body is the body of the new response.
+		Body []byte
+		// Duration is the time it took to get the new response.
+		Duration time.Duration
+		// Err is the error that prevented replaying the capture if any.
+		Err error
+		// Diffs lists the differences between the recorded and new
+		// responses.
+		Diffs []string
+	}
+
+	// ReplayOption configures Replay.
+	ReplayOption func(*replayOptions)
+
+	// replayOptions contains the Replay options.
+	replayOptions struct {
+		skipHeaders map[string]struct{}
+		ignore      map[string]struct{}
+		headers     []string
+	}
+)
+
+// replaySkippedHeaders lists the request headers that are never replayed.
+var replaySkippedHeaders = []string{"Connection", "Content-Length", "Host", "Te", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+// ReplaySkipHeaders adds headers that are not sent when replaying the
+// requests. The recorded headers whose values were redacted are never sent.
+func ReplaySkipHeaders(names ...string) ReplayOption {
+	return func(o *replayOptions) {
+		for _, n := range names {
+			o.skipHeaders[http.CanonicalHeaderKey(n)] = struct{}{}
+		}
+	}
+}
+
+// ReplayIgnoreFields adds names of JSON body fields (at any depth) whose values
+// are not compared, e.g. generated IDs or timestamps. The recorded fields whose
+// values were redacted are never compared.
+func ReplayIgnoreFields(names ...string) ReplayOption {
+	return func(o *replayOptions) {
+		for _, n := range names {
+			o.ignore[n] = struct{}{}
+		}
+	}
+}
+
+// ReplayCompareHeaders adds response headers whose values are compared. By
+// default only the status code, the media type of the Content-Type header and
+// the body are compared.
+func ReplayCompareHeaders(names ...string) ReplayOption {
+	return func(o *replayOptions) {
+		for _, n := range names {
+			o.headers = append(o.headers, http.CanonicalHeaderKey(n))
+		}
+	}
+}
+
+// Replay sends the recorded requests to the server at baseURL using doer in
+// order and compares the new responses with the recorded ones. The request
+// paths and query strings are appended to baseURL, e.g.
+// "http://localhost:8080". JSON bodies are compared semantically. Replay
+// returns one result per capture, use Match or Diffs to check whether the new
+// response matches the recorded one. Replay stops early if ctx is canceled.
+// Redacted headers are not sent, redacted query string parameters and body
+// fields are sent as recorded.
+//
+// Replay makes it possible to write regression tests from captured traffic:
+//
+//	f, _ := os.Open("testdata/traffic.jsonl")
+//	captures, _ := goahttp.ReadCaptures(f)
+//	srv := httptest.NewServer(handler)
+//	for _, res := range goahttp.Replay(ctx, srv.Client(), srv.URL, captures) {
+//		if !res.Match() {
+//			t.Errorf("%s %s: %s", res.Capture.Request.Method, res.Capture.Request.URL, res)
+//		}
+//	}
+func Replay(ctx context.Context, doer Doer, baseURL string, captures []*Capture, opts ...ReplayOption) []*ReplayResult {
+	o := &replayOptions{skipHeaders: make(map[string]struct{}), ignore: make(map[string]struct{})}
+	for _, h := range replaySkippedHeaders {
+		o.skipHeaders[h] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	results := make([]*ReplayResult, 0, len(captures))
+	for _, c := range captures {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, replay(ctx, doer, baseURL, c, o))
+	}
+	return results
+}
+
+// Match returns true if the capture was replayed successfully and the new
+// response matches the recorded one.
+func (r *ReplayResult) Match() bool {
+	return r.Err == nil && len(r.Diffs) == 0
+}
+
+// String returns a description of the replay error or of the differences
+// between the recorded and new responses.
+func (r *ReplayResult) String() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if len(r.Diffs) == 0 {
+		return "match"
+	}
+	return strings.Join(r.Diffs, "; ")
+}
+
+// replay replays a single capture.
+func replay(ctx context.Context, doer Doer, baseURL string, c *Capture, o *replayOptions) *ReplayResult {
+	res := &ReplayResult{Capture: c}
+	if c.Request.BodyTruncated {
+		res.Err = fmt.Errorf("request body was too large to be recorded")
+		return res
+	}
+	body, err := c.Request.Bytes()
+	if err != nil {
+		res.Err = fmt.Errorf("invalid request body: %w", err)
+		return res
+	}
+	req, err := http.NewRequestWithContext(ctx, c.Request.Method, baseURL+c.Request.URL, bytesBody(body))
+	if err != nil {
+		res.Err = fmt.Errorf("invalid request: %w", err)
+		return res
+	}
+	for k, vals := range c.Request.Header {
+		if _, ok := o.skipHeaders[http.CanonicalHeaderKey(k)]; ok {
+			continue
+		}
+		for _, v := range vals {
+			if v != goa.Redacted {
+				req.Header.Add(k, v)
+			}
+		}
+	}
+	started := time.Now()
+	resp, err := doer.Do(req)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer resp.Body.Close()
+	res.Body, err = io.ReadAll(resp.Body)
+	res.Duration = time.Since(started)
+	if err != nil {
+		res.Err = fmt.Errorf("failed to read response body: %w", err)
+		return res
+	}
+	res.Status = resp.StatusCode
+	res.Header = resp.Header
+
+	expected, err := c.Response.Bytes()
+	if err != nil {
+		res.Err = fmt.Errorf("invalid recorded response body: %w", err)
+		return res
+	}
+	if res.Status != c.Response.Status {
+		res.Diffs = append(res.Diffs, fmt.Sprintf("status: expected %d, got %d", c.Response.Status, res.Status))
+	}
+	if e, a := mediaType(c.Response.Header.Get("Content-Type")), mediaType(res.Header.Get("Content-Type")); e != a {
+		res.Diffs = append(res.Diffs, fmt.Sprintf("Content-Type: expected %q, got %q", e, a))
+	}
+	for _, h := range o.headers {
+		e, a := c.Response.Header.Values(h), res.Header.Values(h)
+		if len(e) == 1 && e[0] == goa.Redacted {
+			continue
+		}
+		if !slices.Equal(e, a) {
+			res.Diffs = append(res.Diffs, fmt.Sprintf("%s: expected %q, got %q", h, e, a))
+		}
+	}
+	if !c.Response.BodyTruncated {
+		res.Diffs = append(res.Diffs, compareBodies(expected, res.Body, o.ignore)...)
+	}
+	return res
+}
+
+// compareBodies returns the differences between the expected and actual
+// bodies. JSON bodies are compared semantically, other bodies byte by byte.
+func compareBodies(expected, actual []byte, ignore map[string]struct{}) []string {
+	var e, a any
+	if decodeJSON(expected, &e) && decodeJSON(actual, &a) {
+		var diffs []string
+		compareJSON("body", e, a, ignore, &diffs)
+		return diffs
+	}
+	if !bytes.Equal(expected, actual) {
+		return []string{fmt.Sprintf("body: expected %q, got %q", expected, actual)}
+	}
+	return nil
+}
+
+// compareJSON appends the differences between the decoded JSON values e and a
+// found at the given path to diffs.
+func compareJSON(path string, e, a any, ignore map[string]struct{}, diffs *[]string) {
+	if e == goa.Redacted {
+		return
+	}
+	switch ev := e.(type) {
+	case map[string]any:
+		av, ok := a.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(ev)+len(av))
+		for k := range ev {
+			keys = append(keys, k)
+		}
+		for k := range av {
+			if _, ok := ev[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, ok := ignore[k]; ok {
+				continue
+			}
+			p := path + "." + k
+			evk, eok := ev[k]
+			avk, aok := av[k]
+			switch {
+			case !aok:
+				*diffs = append(*diffs, fmt.Sprintf("%s: missing", p))
+			case !eok:
+				*diffs = append(*diffs, fmt.Sprintf("%s: unexpected", p))
+			default:
+				compareJSON(p, evk, avk, ignore, diffs)
+			}
+		}
+		return
+	case []any:
+		av, ok := a.([]any)
+		if !ok {
+			break
+		}
+		if len(ev) != len(av) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(ev), len(av)))
+			return
+		}
+		for i := range ev {
+			compareJSON(fmt.Sprintf("%s[%d]", path, i), ev[i], av[i], ignore, diffs)
+		}
+		return
+	}
+	if !reflect.DeepEqual(e, a) {
+		eb, _ := json.Marshal(e)
+		ab, _ := json.Marshal(a)
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, eb, ab))
+	}
+}
+
+// decodeJSON decodes b into v and returns true if b is a JSON document.
+func decodeJSON(b []byte, v *any) bool {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return false
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v) == nil && !dec.More()
+}
+
+// mediaType returns the media type of the given Content-Type header value.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mt
+}
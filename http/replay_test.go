@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestReplay(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write([]byte(`{"id":"new","items":[1,2],"name":"` + r.URL.Query().Get("name") + `"}`)) // nolint: errcheck
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("hello")) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	newCapture := func(url string, status int, contentType, body string) *Capture {
+		return &Capture{
+			Request: &CaptureRequest{Method: "GET", URL: url, Header: http.Header{
+				"Authorization":  {goa.Redacted},
+				"Content-Length": {"0"},
+			}},
+			Response: &CaptureResponse{Status: status, Header: http.Header{"Content-Type": {contentType}}, Body: body},
+		}
+	}
+	cases := []struct {
+		Name    string
+		Capture *Capture
+		Options []ReplayOption
+		Diffs   []string
+	}{
+		{"json-match", newCapture("/json?name=a", 200, "application/json", `{"id":"old","items":[1,2],"name":"a"}`), []ReplayOption{ReplayIgnoreFields("id")}, nil},
+		{"json-redacted", newCapture("/json?name=a", 200, "application/json", `{"id":"`+goa.Redacted+`","items":[1,2],"name":"a"}`), nil, nil},
+		{"json-diff", newCapture("/json?name=b", 200, "application/json", `{"id":"old","items":[1],"other":true,"name":"a"}`), nil, []string{
+			`body.id: expected "old", got "new"`,
+			"body.items: expected 1 elements, got 2",
+			`body.name: expected "a", got "b"`,
+			"body.other: missing",
+		}},
+		{"text-match", newCapture("/text", 200, "text/plain", "hello"), nil, nil},
+		{"text-diff", newCapture("/text", 200, "text/plain", "bye"), nil, []string{`body: expected "bye", got "hello"`}},
+		{"status-diff", newCapture("/missing", 200, "", ""), nil, []string{"status: expected 200, got 404"}},
+		{"content-type-diff", newCapture("/text", 200, "application/json", "hello"), nil, []string{`Content-Type: expected "application/json", got "text/plain"`}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			auth = nil
+			res := Replay(context.Background(), srv.Client(), srv.URL+"/", []*Capture{c.Capture}, c.Options...)
+			require.Len(t, res, 1)
+			require.NoError(t, res[0].Err)
+			assert.Equal(t, c.Diffs, res[0].Diffs)
+			assert.Equal(t, len(c.Diffs) == 0, res[0].Match())
+			assert.Equal(t, []string{""}, auth, "redacted headers must not be replayed")
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		res := Replay(ctx, srv.Client(), srv.URL, []*Capture{newCapture("/text", 200, "text/plain", "hello")})
+		assert.Empty(t, res)
+	})
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"context"
 	"net/http"
+
+	"goa.design/goa/v3/security"
 )
 
 type (
@@ -36,3 +39,14 @@ func (s Servers) Mount(mux Muxer) {
 		m.Mount(mux)
 	}
 }
+
+// WithClientCertificates returns a copy of ctx that holds the client certificate
+// chain verified during the TLS handshake of r if any. The generated handlers
+// of endpoints secured with mutual TLS call WithClientCertificates so that the
+// chain may be retrieved with security.ContextClientCertificates.
+func WithClientCertificates(ctx context.Context, r *http.Request) context.Context {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return ctx
+	}
+	return security.WithClientCertificates(ctx, r.TLS.VerifiedChains[0])
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"goa.design/goa/v3/security"
+)
+
+// WithCSRF returns a copy of ctx that holds the CSRF data of r so that it may
+// be checked with security.VerifyCSRF. header is the name of the header that
+// holds the CSRF token and cookie the name of the CSRF cookie in double-submit
+// mode, empty in synchronizer token mode. The generated handlers of endpoints
+// secured with a cookie session scheme that enables CSRF protection call
+// WithCSRF.
+func WithCSRF(ctx context.Context, r *http.Request, header, cookie string) context.Context {
+	csrf := &security.CSRF{
+		Token: r.Header.Get(header),
+		Safe:  isSafeMethod(r.Method),
+	}
+	if cookie != "" {
+		if c, err := r.Cookie(cookie); err == nil {
+			csrf.Cookie = c.Value
+		}
+	}
+	return security.WithCSRF(ctx, csrf)
+}
+
+// SetCSRFHeader sets the header that holds the CSRF token of req if the
+// request method is unsafe. The token is the one stored in the request context
+// with security.WithCSRFToken if any. Otherwise in double-submit mode (cookie
+// is not empty) the token is the value of the CSRF cookie found in the request
+// or in the cookie jar of doer. The generated clients of endpoints secured with
+// a cookie session scheme that enables CSRF protection call SetCSRFHeader.
+func SetCSRFHeader(req *http.Request, header, cookie string, doer Doer) {
+	if isSafeMethod(req.Method) || req.Header.Get(header) != "" {
+		return
+	}
+	if token := security.ContextCSRFToken(req.Context()); token != "" {
+		req.Header.Set(header, token)
+		return
+	}
+	if cookie == "" {
+		return
+	}
+	if c, err := req.Cookie(cookie); err == nil {
+		req.Header.Set(header, c.Value)
+		return
+	}
+	jar := cookieJar(doer)
+	if jar == nil {
+		return
+	}
+	for _, c := range jar.Cookies(req.URL) {
+		if c.Name == cookie {
+			req.Header.Set(header, c.Value)
+			return
+		}
+	}
+}
+
+// cookieJar returns the cookie jar used by doer if any.
+func cookieJar(doer Doer) http.CookieJar {
+	switch d := doer.(type) {
+	case *http.Client:
+		return d.Jar
+	case *debugDoer:
+		return cookieJar(d.Doer)
+	}
+	return nil
+}
+
+// isSafeMethod returns true if method is safe as defined in section 9.2.1 of
+// RFC 9110.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"goa.design/goa/v3/security"
+)
+
+func TestWithCSRF(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("X-CSRF-Token", "tok")
+	r.AddCookie(&http.Cookie{Name: "csrf_token", Value: "cookie"})
+	csrf := security.ContextCSRF(WithCSRF(context.Background(), r, "X-CSRF-Token", "csrf_token"))
+	if csrf == nil {
+		t.Fatal("got no CSRF data")
+	}
+	if csrf.Token != "tok" || csrf.Cookie != "cookie" || csrf.Safe {
+		t.Errorf("got %+v, expected token \"tok\", cookie \"cookie\" and unsafe method", csrf)
+	}
+	r = httptest.NewRequest("GET", "/", nil)
+	if csrf := security.ContextCSRF(WithCSRF(context.Background(), r, "X-CSRF-Token", "")); !csrf.Safe {
+		t.Error("got unsafe method, expected GET to be safe")
+	}
+}
+
+func TestSetCSRFHeader(t *testing.T) {
+	u, _ := url.Parse("http://example.com/items")
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "csrf_token", Value: "jar"}})
+	cases := []struct {
+		Name     string
+		Method   string
+		Context  string
+		Cookie   string
+		Doer     Doer
+		Expected string
+	}{
+		{"safe-method", "GET", "ctx", "csrf_token", nil, ""},
+		{"context", "POST", "ctx", "csrf_token", nil, "ctx"},
+		{"cookie-jar", "POST", "", "csrf_token", &http.Client{Jar: jar}, "jar"},
+		{"debug-doer", "POST", "", "csrf_token", NewDebugDoer(&http.Client{Jar: jar}), "jar"},
+		{"no-jar", "POST", "", "csrf_token", http.DefaultClient, ""},
+		{"synchronizer", "POST", "", "", &http.Client{Jar: jar}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Context != "" {
+				ctx = security.WithCSRFToken(ctx, c.Context)
+			}
+			req, _ := http.NewRequestWithContext(ctx, c.Method, u.String(), nil)
+			SetCSRFHeader(req, "X-CSRF-Token", c.Cookie, c.Doer)
+			if got := req.Header.Get("X-CSRF-Token"); got != c.Expected {
+				t.Errorf("got header %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
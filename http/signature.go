@@ -0,0 +1,326 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"goa.design/goa/v3/security"
+)
+
+const (
+	// SignatureInputHeader is the name of the header that lists the
+	// components covered by the request signature and its parameters as
+	// defined by RFC 9421.
+	SignatureInputHeader = "Signature-Input"
+	// SignatureHeader is the name of the header that holds the request
+	// signature as defined by RFC 9421.
+	SignatureHeader = "Signature"
+	// ContentDigestHeader is the name of the header that holds the digest
+	// of the request body as defined by RFC 9530.
+	ContentDigestHeader = "Content-Digest"
+
+	// signatureLabel is the label of the signatures created by SignRequest.
+	signatureLabel = "sig1"
+	// contentDigestComponent is the component that covers the request body
+	// via the Content-Digest header.
+	contentDigestComponent = "content-digest"
+)
+
+// MaxSignedBodySize is the maximum size in bytes of the request bodies read by
+// ReadSignature to check the Content-Digest header. ReadSignature returns an
+// error for larger bodies.
+var MaxSignedBodySize int64 = 10 << 20
+
+// SignRequest signs req following RFC 9421 (HTTP Message Signatures) using
+// HMAC-SHA256 and the key stored in the request context with
+// security.WithSigningKey. components lists the request components covered by
+// the signature: derived components ("@method", "@authority", "@path" or
+// "@query") or header names. If components includes "content-digest" then
+// SignRequest also sets the Content-Digest header to the SHA-256 digest of the
+// request body. The signature includes the creation time and a random nonce so
+// that servers may reject stale and replayed requests.
+//
+// The generated clients call SignRequest for the endpoints secured with a
+// signature scheme.
+func SignRequest(req *http.Request, components []string) error {
+	key := security.ContextSigningKey(req.Context())
+	if key == nil {
+		return fmt.Errorf("missing signing key, use security.WithSigningKey to set it in the request context")
+	}
+	if slices.Contains(components, contentDigestComponent) {
+		body, err := readRequestBody(req, -1)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(ContentDigestHeader, contentDigest(body))
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	params := signatureParams(&security.Signature{
+		KeyID:      key.ID,
+		Algorithm:  security.SignatureAlgorithm,
+		Created:    time.Now(),
+		Nonce:      base64.RawURLEncoding.EncodeToString(nonce),
+		Components: components,
+	})
+	base, err := signatureBase(req, components, params)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(SignatureInputHeader, signatureLabel+"="+params)
+	req.Header.Set(SignatureHeader, signatureLabel+"=:"+base64.StdEncoding.EncodeToString(key.Sign(base))+":")
+	return nil
+}
+
+// ReadSignature reads the signature of r following RFC 9421 and computes the
+// corresponding signature base. If the signature covers the Content-Digest
+// header then ReadSignature also checks that it matches the request body. The
+// body is restored so that it may be read again. ReadSignature does not verify
+// the signature itself, see security.VerifySignature.
+func ReadSignature(r *http.Request) (*security.Signature, error) {
+	input := r.Header.Get(SignatureInputHeader)
+	if input == "" || r.Header.Get(SignatureHeader) == "" {
+		return nil, security.ErrMissingSignature
+	}
+	label, params, ok := strings.Cut(input, "=")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed %s header", security.ErrInvalidSignature, SignatureInputHeader)
+	}
+	label = strings.TrimSpace(label)
+	params = strings.TrimSpace(firstMember(params))
+	sig, err := parseSignatureParams(params)
+	if err != nil {
+		return nil, err
+	}
+	value, err := signatureValue(r.Header.Get(SignatureHeader), label)
+	if err != nil {
+		return nil, err
+	}
+	sig.Value = value
+	if slices.Contains(sig.Components, contentDigestComponent) {
+		body, err := readRequestBody(r, MaxSignedBodySize)
+		if err != nil {
+			return nil, err
+		}
+		if r.Header.Get(ContentDigestHeader) != contentDigest(body) {
+			return nil, fmt.Errorf("%w: content digest mismatch", security.ErrInvalidSignature)
+		}
+	}
+	if sig.Base, err = signatureBase(r, sig.Components, params); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// WithSignature returns a copy of ctx that holds the signature read from r
+// with ReadSignature. If the signature cannot be read the error is recorded in
+// the Err field of the stored signature so that it is reported by
+// security.VerifySignature. The generated handlers of endpoints secured with a
+// signature scheme call WithSignature so that the signature may be retrieved
+// with security.ContextSignature.
+func WithSignature(ctx context.Context, r *http.Request) context.Context {
+	if r.Header.Get(SignatureInputHeader) == "" && r.Header.Get(SignatureHeader) == "" {
+		return ctx
+	}
+	sig, err := ReadSignature(r)
+	if err != nil {
+		sig = &security.Signature{Err: err}
+	}
+	return security.WithSignature(ctx, sig)
+}
+
+// signatureParams serializes the signature parameters as described in section
+// 2.3 of RFC 9421.
+func signatureParams(sig *security.Signature) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, c := range sig.Components {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.Quote(c))
+	}
+	b.WriteByte(')')
+	fmt.Fprintf(&b, ";created=%d", sig.Created.Unix())
+	fmt.Fprintf(&b, ";keyid=%q", sig.KeyID)
+	fmt.Fprintf(&b, ";nonce=%q", sig.Nonce)
+	fmt.Fprintf(&b, ";alg=%q", sig.Algorithm)
+	return b.String()
+}
+
+// parseSignatureParams parses the serialized signature parameters.
+func parseSignatureParams(params string) (*security.Signature, error) {
+	invalid := fmt.Errorf("%w: malformed %s header", security.ErrInvalidSignature, SignatureInputHeader)
+	if !strings.HasPrefix(params, "(") {
+		return nil, invalid
+	}
+	end := strings.IndexByte(params, ')')
+	if end < 0 {
+		return nil, invalid
+	}
+	var sig security.Signature
+	for _, c := range strings.Fields(params[1:end]) {
+		name, err := strconv.Unquote(c)
+		if err != nil || name == "" {
+			return nil, invalid
+		}
+		sig.Components = append(sig.Components, name)
+	}
+	for _, p := range strings.Split(params[end+1:], ";") {
+		if p == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, invalid
+		}
+		switch k {
+		case "created":
+			secs, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, invalid
+			}
+			sig.Created = time.Unix(secs, 0)
+			continue
+		}
+		s, err := strconv.Unquote(v)
+		if err != nil {
+			return nil, invalid
+		}
+		switch k {
+		case "keyid":
+			sig.KeyID = s
+		case "nonce":
+			sig.Nonce = s
+		case "alg":
+			sig.Algorithm = s
+		}
+	}
+	return &sig, nil
+}
+
+// firstMember returns the first member of a structured field dictionary value
+// ignoring the commas that appear in quoted strings.
+func firstMember(v string) string {
+	var quoted bool
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				return v[:i]
+			}
+		}
+	}
+	return v
+}
+
+// signatureValue returns the decoded signature with the given label from the
+// value of the Signature header.
+func signatureValue(header, label string) ([]byte, error) {
+	for _, member := range strings.Split(header, ",") {
+		l, v, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok || l != label {
+			continue
+		}
+		if len(v) < 2 || v[0] != ':' || v[len(v)-1] != ':' {
+			break
+		}
+		value, err := base64.StdEncoding.DecodeString(v[1 : len(v)-1])
+		if err != nil {
+			break
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("%w: malformed %s header", security.ErrInvalidSignature, SignatureHeader)
+}
+
+// signatureBase computes the signature base of r as described in section 2.5
+// of RFC 9421.
+func signatureBase(r *http.Request, components []string, params string) ([]byte, error) {
+	var b bytes.Buffer
+	for _, c := range components {
+		var v string
+		switch c {
+		case "@method":
+			v = r.Method
+		case "@authority":
+			v = r.Host
+			if v == "" {
+				v = r.URL.Host
+			}
+			v = strings.ToLower(v)
+		case "@path":
+			v = r.URL.EscapedPath()
+			if v == "" {
+				v = "/"
+			}
+		case "@query":
+			v = "?" + r.URL.RawQuery
+		default:
+			if strings.HasPrefix(c, "@") {
+				return nil, fmt.Errorf("%w: unsupported component %q", security.ErrInvalidSignature, c)
+			}
+			vals := r.Header.Values(c)
+			if len(vals) == 0 {
+				return nil, fmt.Errorf("%w: missing signed header %q", security.ErrInvalidSignature, c)
+			}
+			for i, val := range vals {
+				vals[i] = strings.TrimSpace(val)
+			}
+			v = strings.Join(vals, ", ")
+		}
+		fmt.Fprintf(&b, "%q: %s\n", c, v)
+	}
+	fmt.Fprintf(&b, "%q: %s", "@signature-params", params)
+	return b.Bytes(), nil
+}
+
+// contentDigest returns the value of the Content-Digest header for body.
+func contentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
+
+// readRequestBody reads the body of r and restores it so that it may be read
+// again. It returns an error if max is positive and the body is larger than
+// max bytes.
+func readRequestBody(r *http.Request, max int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	var body []byte
+	var err error
+	if max > 0 {
+		body, err = io.ReadAll(io.LimitReader(r.Body, max+1))
+		if err == nil && int64(len(body)) > max {
+			err = fmt.Errorf("%w: request body exceeds %d bytes", security.ErrInvalidSignature, max)
+		}
+	} else {
+		body, err = io.ReadAll(r.Body)
+	}
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
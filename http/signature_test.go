@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/security"
+)
+
+func TestSignature(t *testing.T) {
+	var (
+		key        = &security.SigningKey{ID: "partner", Secret: []byte("secret")}
+		components = []string{"@method", "@authority", "@path", "@query", "content-type", "content-digest"}
+		scheme     = &security.SignatureScheme{Name: "signed", Components: components}
+		keyFn      = func(_ context.Context, keyID string) ([]byte, error) {
+			if keyID != key.ID {
+				return nil, errors.New("unknown key")
+			}
+			return key.Secret, nil
+		}
+	)
+	signed := func(t *testing.T) *http.Request {
+		t.Helper()
+		req, _ := http.NewRequest("POST", "http://example.com/orders?dry=true", strings.NewReader(`{"id":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(security.WithSigningKey(context.Background(), key))
+		if err := SignRequest(req, components); err != nil {
+			t.Fatalf("failed to sign request: %s", err)
+		}
+		// Build the request as received by the server.
+		body, _ := io.ReadAll(req.Body)
+		r := httptest.NewRequest(req.Method, req.URL.String(), bytes.NewReader(body))
+		r.Header = req.Header.Clone()
+		return r
+	}
+
+	cases := []struct {
+		Name   string
+		Tamper func(r *http.Request)
+		Scheme *security.SignatureScheme
+		Error  error
+	}{
+		{"valid", nil, scheme, nil},
+		{"tampered-body", func(r *http.Request) { r.Body = io.NopCloser(strings.NewReader(`{"id":2}`)) }, scheme, security.ErrInvalidSignature},
+		{"tampered-path", func(r *http.Request) { r.URL.Path = "/users" }, scheme, security.ErrInvalidSignature},
+		{"tampered-header", func(r *http.Request) { r.Header.Set("Content-Type", "text/plain") }, scheme, security.ErrInvalidSignature},
+		{"unsigned-component", nil, &security.SignatureScheme{Components: []string{"x-request-id"}}, security.ErrInvalidSignature},
+		{"unknown-key", func(r *http.Request) {
+			r.Header.Set(SignatureInputHeader, strings.Replace(r.Header.Get(SignatureInputHeader), `keyid="partner"`, `keyid="other"`, 1))
+		}, scheme, errors.New("unknown key")},
+		{"missing", func(r *http.Request) { r.Header.Del(SignatureHeader) }, scheme, security.ErrMissingSignature},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := signed(t)
+			if c.Tamper != nil {
+				c.Tamper(r)
+			}
+			ctx := WithSignature(context.Background(), r)
+			err := security.VerifySignature(ctx, security.ContextSignature(ctx), c.Scheme, keyFn, nil)
+			switch {
+			case c.Error == nil && err != nil:
+				t.Errorf("got error %q, expected none", err)
+			case c.Error != nil && err == nil:
+				t.Errorf("got no error, expected %q", c.Error)
+			case c.Error != nil && !errors.Is(err, c.Error) && err.Error() != c.Error.Error():
+				t.Errorf("got error %q, expected %q", err, c.Error)
+			}
+		})
+	}
+
+	t.Run("body-restored", func(t *testing.T) {
+		r := signed(t)
+		WithSignature(context.Background(), r)
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"id":1}` {
+			t.Errorf("got body %q, expected it to be restored", body)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		nonces := security.NewMemoryNonceStore()
+		r := signed(t)
+		sig, err := ReadSignature(r)
+		if err != nil {
+			t.Fatalf("failed to read signature: %s", err)
+		}
+		if err := security.VerifySignature(context.Background(), sig, scheme, keyFn, nonces); err != nil {
+			t.Fatalf("got error %q on first use", err)
+		}
+		if err := security.VerifySignature(context.Background(), sig, scheme, keyFn, nonces); !errors.Is(err, security.ErrReplayedNonce) {
+			t.Errorf("got error %v on replay, expected %q", err, security.ErrReplayedNonce)
+		}
+	})
+
+	t.Run("nonce-expiry", func(t *testing.T) {
+		nonces := security.NewMemoryNonceStore()
+		ctx := context.Background()
+		if err := nonces.Use(ctx, "k", "expired", time.Now().Add(-time.Second)); err != nil {
+			t.Fatalf("got error %q on first use", err)
+		}
+		if err := nonces.Use(ctx, "k", "live", time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("got error %q on first use", err)
+		}
+		if err := nonces.Use(ctx, "k", "expired", time.Now().Add(time.Minute)); err != nil {
+			t.Errorf("got error %q, expected expired nonce to be removed", err)
+		}
+		if err := nonces.Use(ctx, "k", "live", time.Now().Add(time.Minute)); !errors.Is(err, security.ErrReplayedNonce) {
+			t.Errorf("got error %v, expected %q", err, security.ErrReplayedNonce)
+		}
+	})
+
+	t.Run("body-too-large", func(t *testing.T) {
+		defer func(max int64) { MaxSignedBodySize = max }(MaxSignedBodySize)
+		MaxSignedBodySize = 4
+		if _, err := ReadSignature(signed(t)); !errors.Is(err, security.ErrInvalidSignature) {
+			t.Errorf("got error %v, expected %q", err, security.ErrInvalidSignature)
+		}
+	})
+
+	t.Run("clock-skew", func(t *testing.T) {
+		r := signed(t)
+		sig, err := ReadSignature(r)
+		if err != nil {
+			t.Fatalf("failed to read signature: %s", err)
+		}
+		sig.Created = time.Now().Add(-2 * time.Minute)
+		s := &security.SignatureScheme{Components: components, MaxClockSkew: time.Minute}
+		if err := security.VerifySignature(context.Background(), sig, s, keyFn, nil); !errors.Is(err, security.ErrSignatureExpired) {
+			t.Errorf("got error %v, expected %q", err, security.ErrSignatureExpired)
+		}
+	})
+
+	t.Run("no-signing-key", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		if err := SignRequest(req, components); err == nil {
+			t.Error("got no error, expected missing signing key error")
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// strictDecoder is a decoder that checks the fields of JSON request bodies
+// before delegating the decoding to the wrapped decoder.
+type strictDecoder struct {
+	r       *http.Request
+	decoder func(*http.Request) Decoder
+}
+
+// StrictDecoder wraps the given request decoder so that decoding JSON request
+// bodies fails if the body contains fields that are not defined by the target
+// type or that are defined more than once. The returned error lists all the
+// offending fields. Request bodies using other content types are decoded by
+// the wrapped decoder as is. The code generated for methods that use strict
+// decoding uses StrictDecoder to wrap the server request decoder.
+func StrictDecoder(decoder func(*http.Request) Decoder) func(*http.Request) Decoder {
+	return func(r *http.Request) Decoder {
+		ct := r.Header.Get("Content-Type")
+		if mt, _, err := mime.ParseMediaType(ct); err == nil {
+			ct = mt
+		}
+		if ct != "" && ct != "application/json" && !strings.HasSuffix(ct, "+json") {
+			return decoder(r)
+		}
+		return &strictDecoder{r: r, decoder: decoder}
+	}
+}
+
+// CheckQueryParams returns an error listing the query string parameters of r
+// that are not in the given list of names. The code generated for methods
+// that use strict decoding uses CheckQueryParams to validate the requests.
+func CheckQueryParams(r *http.Request, names ...string) error {
+	q := r.URL.Query()
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var err error
+	for _, k := range keys {
+		known := false
+		for _, n := range names {
+			if k == n {
+				known = true
+				break
+			}
+		}
+		if !known {
+			err = goa.MergeErrors(err, goa.UnknownFieldError(k, "query string"))
+		}
+	}
+	return err
+}
+
+// Decode checks the fields of the JSON body against the fields of v and
+// decodes the body into v using the wrapped decoder.
+func (d *strictDecoder) Decode(v any) error {
+	data, err := io.ReadAll(d.r.Body)
+	if err != nil {
+		return err
+	}
+	d.r.Body.Close() // nolint: errcheck
+	d.r.Body = io.NopCloser(bytes.NewReader(data))
+	if err := checkJSONFields(data, reflect.TypeOf(v)); err != nil {
+		return err
+	}
+	return d.decoder(d.r).Decode(v)
+}
+
+// checkJSONFields returns an error listing the fields of the JSON document
+// data that are not defined by t or that are defined more than once. It
+// returns nil if data is not a valid JSON document so that the decoder
+// reports the syntax error.
+func checkJSONFields(data []byte, t reflect.Type) error {
+	var (
+		err error
+		dec = json.NewDecoder(bytes.NewReader(data))
+	)
+	walkJSON(dec, t, "", func(path string) {
+		err = goa.MergeErrors(err, goa.UnknownFieldError(path, "body"))
+	}, func(path string) {
+		err = goa.MergeErrors(err, goa.DuplicateFieldError(path, "body"))
+	})
+	return err
+}
+
+// walkJSON reads the next JSON value from dec and calls unknown for each
+// object key that does not match a field of t and duplicate for each object
+// key that appears more than once. t may be nil in which case only duplicate
+// keys are reported. walkJSON returns false if the JSON document is invalid.
+func walkJSON(dec *json.Decoder, t reflect.Type, path string, unknown, duplicate func(path string)) bool {
+	tok, err := dec.Token()
+	if err != nil {
+		return false
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]struct{})
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return false
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return false
+			}
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			var ft reflect.Type
+			id := key
+			if t != nil {
+				switch t.Kind() {
+				case reflect.Struct:
+					name, f, ok := jsonField(t, key)
+					if !ok {
+						unknown(p)
+					} else {
+						// encoding/json matches keys case-insensitively,
+						// keys that map to the same field are duplicates.
+						id = name
+					}
+					ft = f
+				case reflect.Map:
+					ft = t.Elem()
+				}
+			}
+			if _, ok := seen[id]; ok {
+				duplicate(p)
+			}
+			seen[id] = struct{}{}
+			if !walkJSON(dec, ft, p, unknown, duplicate) {
+				return false
+			}
+		}
+		_, err := dec.Token()
+		return err == nil
+	case json.Delim('['):
+		var et reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			et = t.Elem()
+		}
+		for i := 0; dec.More(); i++ {
+			if !walkJSON(dec, et, path+"["+strconv.Itoa(i)+"]", unknown, duplicate) {
+				return false
+			}
+		}
+		_, err := dec.Token()
+		return err == nil
+	}
+	return true
+}
+
+// jsonField returns the name and type of the field of the struct type t that
+// encoding/json maps to the given key.
+func jsonField(t reflect.Type, key string) (string, reflect.Type, bool) {
+	var (
+		foldName string
+		fold     reflect.Type
+	)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			n := strings.Split(tag, ",")[0]
+			if n == "-" {
+				continue
+			}
+			if n != "" {
+				name = n
+			}
+		}
+		if name == key {
+			return f.Name, f.Type, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			foldName, fold = f.Name, f.Type
+		}
+	}
+	return foldName, fold, fold != nil
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestStrictDecoder(t *testing.T) {
+	type (
+		inner struct {
+			Value *string `json:"value,omitempty"`
+		}
+		body struct {
+			Name  *string  `json:"name,omitempty"`
+			Inner *inner   `json:"inner,omitempty"`
+			Items []*inner `json:"items,omitempty"`
+		}
+	)
+	cases := []struct {
+		name    string
+		ct      string
+		body    string
+		wantErr string
+	}{
+		{"valid", "application/json", `{"name":"a","inner":{"value":"b"},"items":[{"value":"c"}]}`, ""},
+		{"no content type", "", `{"name":"a"}`, ""},
+		{"case insensitive", "application/json", `{"Name":"a"}`, ""},
+		{"unknown", "application/json", `{"name":"a","other":1}`, `"other" is not a known field of body`},
+		{"unknown nested", "application/json", `{"inner":{"value":"b","other":1}}`, `"inner.other" is not a known field of body`},
+		{"unknown in array", "application/vnd.api+json", `{"items":[{"other":1}]}`, `"items[0].other" is not a known field of body`},
+		{"duplicate", "application/json", `{"name":"a","name":"b"}`, `"name" is defined more than once in body`},
+		{"duplicate case insensitive", "application/json", `{"name":"a","Name":"b"}`, `"Name" is defined more than once in body`},
+		{"not json", "application/xml", `<body><other>1</other></body>`, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.body))
+			if c.ct != "" {
+				r.Header.Set("Content-Type", c.ct)
+			}
+			var v body
+			err := StrictDecoder(RequestDecoder)(r).Decode(&v)
+			if c.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, c.wantErr, err.Error())
+			var gerr *goa.ServiceError
+			require.ErrorAs(t, err, &gerr)
+			assert.Contains(t, []string{goa.UnknownField, goa.DuplicateField}, gerr.Name)
+		})
+	}
+}
+
+func TestCheckQueryParams(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		names   []string
+		wantErr string
+	}{
+		{"none", "/", nil, ""},
+		{"known", "/?a=1&b=2", []string{"a", "b"}, ""},
+		{"unknown", "/?a=1&c=2", []string{"a", "b"}, `"c" is not a known field of query string`},
+		{"multiple unknown", "/?d=1&c=2", []string{"a"}, `"c" is not a known field of query string; "d" is not a known field of query string`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.url, nil)
+			err := CheckQueryParams(r, c.names...)
+			if c.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, c.wantErr, err.Error())
+		})
+	}
+}
@@ -24,4 +24,41 @@ type (
 	// custom handlers. The cancel function cancels the request context when
 	// invoked in the configure function.
 	ConnConfigureFunc func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn
+
+	// StreamObserver is notified of the messages sent and received by the
+	// server streams of the streaming methods, see WithStreamObserver.
+	StreamObserver interface {
+		// MessageSent is called after a message is sent.
+		MessageSent()
+		// MessageReceived is called after a message is received.
+		MessageReceived()
+	}
+
+	// streamObserverKey is the context key used to store the stream
+	// observer.
+	streamObserverKey struct{}
 )
+
+// WithStreamObserver returns a copy of ctx that holds the given stream
+// observer. The generated server streams notify the observer stored in the
+// request context of each message sent or received over the websocket
+// connection.
+func WithStreamObserver(ctx context.Context, o StreamObserver) context.Context {
+	return context.WithValue(ctx, streamObserverKey{}, o)
+}
+
+// StreamMessageSent notifies the stream observer stored in ctx if any that a
+// message was sent.
+func StreamMessageSent(ctx context.Context) {
+	if o, ok := ctx.Value(streamObserverKey{}).(StreamObserver); ok {
+		o.MessageSent()
+	}
+}
+
+// StreamMessageReceived notifies the stream observer stored in ctx if any that
+// a message was received.
+func StreamMessageReceived(ctx context.Context) {
+	if o, ok := ctx.Value(streamObserverKey{}).(StreamObserver); ok {
+		o.MessageReceived()
+	}
+}
@@ -16,4 +16,8 @@ var (
 	// TraceParentSpanIDKey is the request context key used to store the current
 	// trace parent span ID if any.
 	TraceParentSpanIDKey = "goa-trace-parent-span-id"
+
+	// TraceSpanBufferKey is the request context key used to store the span
+	// buffer of the current request when tail sampling is enabled.
+	TraceSpanBufferKey = "goa-trace-span-buffer"
 )
@@ -5,6 +5,10 @@ The middleware should invoke the endpoint function given as argument and may
 apply additional transformations prior to and after calling the original. The
 middlewares included in this package include a logger middleware to log incoming
 requests, a request ID middleware that makes sure every request as a unique ID
-stored in the context and a couple of middlewares used to implement tracing.
+stored in the context, a couple of middlewares used to implement tracing,
+including an OpenTelemetry middleware that annotates the request span, and a
+metrics collector that exposes RED metrics in the Prometheus text format.
+NewSlogLogger adapts a log/slog logger to the Logger interface and Recover
+converts panics raised by endpoints into goa fault errors.
 */
 package middleware
@@ -0,0 +1,367 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Metrics collects RED (rate, errors and duration) metrics for the
+	// requests handled by a service and writes them in the Prometheus text
+	// exposition format. The metrics are labelled with the service and
+	// method names, the route (HTTP route pattern or gRPC full method) and
+	// the response status code. The transport middlewares (see the Metrics
+	// middlewares of the http/middleware and grpc/middleware packages)
+	// record the route, status code and sizes while the endpoint middleware
+	// returned by Endpoint records the service and method names and the
+	// name of the errors returned by the endpoints. Both should be mounted,
+	// e.g.:
+	//
+	//	metrics := middleware.NewMetrics()
+	//	endpoints.Use(metrics.Endpoint)
+	//	handler = httpmdlwr.Metrics(metrics, mux)(handler)
+	//	mux.Handle("GET", "/metrics", httpmdlwr.MetricsHandler(metrics).ServeHTTP)
+	//
+	// The following metrics are collected, prefixed with the namespace
+	// ("goa" by default):
+	//
+	//   - requests_total: counter of handled requests.
+	//   - request_errors_total: counter of failed requests labelled with
+	//     the error name.
+	//   - request_duration_seconds: histogram of request durations.
+	//   - requests_in_flight: gauge of requests being handled by the
+	//     endpoints.
+	//   - request_size_bytes and response_size_bytes: histograms of the
+	//     request and response sizes.
+	//   - stream_messages_received_total and stream_messages_sent_total:
+	//     counters of the messages received and sent by gRPC streaming
+	//     methods.
+	Metrics struct {
+		namespace       string
+		durationBuckets []float64
+		sizeBuckets     []float64
+
+		mu       sync.Mutex
+		families map[string]*metricFamily
+	}
+
+	// MetricsOption is a constructor option that makes it possible to
+	// customize the collected metrics.
+	MetricsOption func(*Metrics) *Metrics
+
+	// MetricsRequest holds the data of a single request recorded by the
+	// transport middlewares.
+	MetricsRequest struct {
+		// Service is the name of the service.
+		Service string
+		// Method is the name of the method.
+		Method string
+		// Route is the HTTP route pattern or gRPC full method.
+		Route string
+		// Error is the name of the error returned by the endpoint if
+		// any, see goa.GoaErrorNamer.
+		Error string
+		// RequestSize is the size of the request in bytes.
+		RequestSize int64
+		// ResponseSize is the size of the response in bytes.
+		ResponseSize int64
+
+		metrics *Metrics
+		start   time.Time
+	}
+
+	// metricFamily is a metric and its time series.
+	metricFamily struct {
+		name    string
+		help    string
+		typ     string
+		labels  []string
+		buckets []float64
+		series  map[string]*metricSeries
+	}
+
+	// metricSeries is the time series of a metric for a given set of label
+	// values.
+	metricSeries struct {
+		values []string
+		value  float64
+		counts []uint64
+		count  uint64
+	}
+
+	// metricsRequestKey is the context key used to store the request
+	// metrics.
+	metricsRequestKey struct{}
+)
+
+var (
+	// DefaultDurationBuckets are the default buckets of the request
+	// duration histogram in seconds.
+	DefaultDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	// DefaultSizeBuckets are the default buckets of the request and
+	// response size histograms in bytes.
+	DefaultSizeBuckets = []float64{100, 1000, 10000, 100000, 1e6, 1e7, 1e8}
+)
+
+// NewMetrics returns a metrics collector configured with the given options.
+func NewMetrics(opts ...MetricsOption) *Metrics {
+	m := &Metrics{
+		namespace:       "goa",
+		durationBuckets: DefaultDurationBuckets,
+		sizeBuckets:     DefaultSizeBuckets,
+		families:        make(map[string]*metricFamily),
+	}
+	for _, opt := range opts {
+		m = opt(m)
+	}
+	return m
+}
+
+// MetricsNamespace sets the prefix of the metric names, "goa" by default.
+func MetricsNamespace(ns string) MetricsOption {
+	return func(m *Metrics) *Metrics {
+		m.namespace = ns
+		return m
+	}
+}
+
+// MetricsDurationBuckets sets the upper bounds in seconds of the request
+// duration histogram buckets.
+func MetricsDurationBuckets(buckets ...float64) MetricsOption {
+	return func(m *Metrics) *Metrics {
+		m.durationBuckets = buckets
+		return m
+	}
+}
+
+// MetricsSizeBuckets sets the upper bounds in bytes of the request and
+// response size histogram buckets.
+func MetricsSizeBuckets(buckets ...float64) MetricsOption {
+	return func(m *Metrics) *Metrics {
+		m.sizeBuckets = buckets
+		return m
+	}
+}
+
+// Endpoint is the endpoint middleware that records the service and method
+// names stored in the request context by the generated code as well as the
+// name of the error returned by the endpoint and the number of requests in
+// flight. The request is recorded by the transport middleware if any or by
+// Endpoint otherwise in which case the route and status code labels are
+// empty.
+func (m *Metrics) Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		r := ContextMetricsRequest(ctx)
+		standalone := r == nil
+		if standalone {
+			ctx, r = m.StartRequest(ctx, "")
+		}
+		if svc, ok := ctx.Value(goa.ServiceKey).(string); ok {
+			r.Service = svc
+		}
+		if meth, ok := ctx.Value(goa.MethodKey).(string); ok {
+			r.Method = meth
+		}
+		labels := []string{r.Service, r.Method}
+		m.add("requests_in_flight", "gauge", "Number of requests being handled.", []string{"service", "method"}, labels, 1)
+		defer m.add("requests_in_flight", "gauge", "Number of requests being handled.", []string{"service", "method"}, labels, -1)
+		res, err := e(ctx, req)
+		if err != nil {
+			r.Error = errorName(err)
+		}
+		if standalone {
+			r.End("", err != nil)
+		}
+		return res, err
+	}
+}
+
+// StartRequest returns a copy of ctx that holds the metrics of a new request
+// made to route. The transport middlewares call StartRequest when they
+// receive a request and MetricsRequest.End once the response is written.
+func (m *Metrics) StartRequest(ctx context.Context, route string) (context.Context, *MetricsRequest) {
+	r := &MetricsRequest{Route: route, metrics: m, start: time.Now()}
+	return context.WithValue(ctx, metricsRequestKey{}, r), r
+}
+
+// ContextMetricsRequest returns the request metrics stored in ctx, nil if
+// there isn't any.
+func ContextMetricsRequest(ctx context.Context) *MetricsRequest {
+	r, _ := ctx.Value(metricsRequestKey{}).(*MetricsRequest)
+	return r
+}
+
+// End records the request given the response status code. failed indicates
+// whether the request failed in which case the request is counted as an error
+// labelled with the name of the error returned by the endpoint or "unknown"
+// if there isn't one.
+func (r *MetricsRequest) End(code string, failed bool) {
+	m := r.metrics
+	labels := []string{r.Service, r.Method, r.Route, code}
+	names := []string{"service", "method", "route", "code"}
+	m.add("requests_total", "counter", "Total number of requests.", names, labels, 1)
+	if failed || r.Error != "" {
+		name := r.Error
+		if name == "" {
+			name = "unknown"
+		}
+		m.add("request_errors_total", "counter", "Total number of failed requests.",
+			append(names, "error"), append(labels, name), 1)
+	}
+	m.observe("request_duration_seconds", "Request duration in seconds.", m.durationBuckets, names, labels, time.Since(r.start).Seconds())
+	m.observe("request_size_bytes", "Request size in bytes.", m.sizeBuckets, names, labels, float64(r.RequestSize))
+	m.observe("response_size_bytes", "Response size in bytes.", m.sizeBuckets, names, labels, float64(r.ResponseSize))
+}
+
+// MessageReceived records a message received by a streaming method.
+func (r *MetricsRequest) MessageReceived() {
+	r.metrics.add("stream_messages_received_total", "counter", "Total number of stream messages received.",
+		[]string{"service", "method", "route"}, []string{r.Service, r.Method, r.Route}, 1)
+}
+
+// MessageSent records a message sent by a streaming method.
+func (r *MetricsRequest) MessageSent() {
+	r.metrics.add("stream_messages_sent_total", "counter", "Total number of stream messages sent.",
+		[]string{"service", "method", "route"}, []string{r.Service, r.Method, r.Route}, 1)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format
+// (version 0.0.4). The metrics are rendered in memory first so that slow
+// writers do not block the recording of new metrics.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	m.mu.Lock()
+	names := make([]string, 0, len(m.families))
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m.families[name].write(&buf)
+	}
+	m.mu.Unlock()
+	return buf.WriteTo(w)
+}
+
+// add adds delta to the value of the counter or gauge with the given name
+// and label values.
+func (m *Metrics) add(name, typ, help string, labels, values []string, delta float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.family(name, typ, help, labels, nil).get(values).value += delta
+}
+
+// observe records v in the histogram with the given name and label values.
+func (m *Metrics) observe(name, help string, buckets []float64, labels, values []string, v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.family(name, "histogram", help, labels, buckets).get(values)
+	if s.counts == nil {
+		s.counts = make([]uint64, len(buckets))
+	}
+	for i, b := range buckets {
+		if v <= b {
+			s.counts[i]++
+		}
+	}
+	s.value += v
+	s.count++
+}
+
+// family returns the metric family with the given name, creating it if
+// needed. It must be called with m.mu held.
+func (m *Metrics) family(name, typ, help string, labels []string, buckets []float64) *metricFamily {
+	if m.namespace != "" {
+		name = m.namespace + "_" + name
+	}
+	f, ok := m.families[name]
+	if !ok {
+		f = &metricFamily{name: name, help: help, typ: typ, labels: labels, buckets: buckets, series: make(map[string]*metricSeries)}
+		m.families[name] = f
+	}
+	return f
+}
+
+// get returns the series with the given label values, creating it if needed.
+func (f *metricFamily) get(values []string) *metricSeries {
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &metricSeries{values: append([]string(nil), values...)}
+		f.series[key] = s
+	}
+	return s
+}
+
+// write writes the family in the Prometheus text exposition format.
+func (f *metricFamily) write(w *bytes.Buffer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.typ)
+	keys := make([]string, 0, len(f.series))
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := f.series[k]
+		labels := formatLabels(f.labels, s.values)
+		if f.typ != "histogram" {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, labels, formatFloat(s.value))
+			continue
+		}
+		for i, b := range f.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(append(f.labels, "le"), append(s.values, formatFloat(b))), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(append(f.labels, "le"), append(s.values, "+Inf")), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, labels, formatFloat(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, labels, s.count)
+	}
+}
+
+// formatLabels returns the label pairs in the Prometheus text format.
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, n := range names {
+		pairs[i] = n + `="` + labelEscaper.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelEscaper escapes label values as required by the Prometheus text
+// format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatFloat formats v as a Prometheus sample value.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// errorName returns the name of err as defined in the design if any and
+// "unknown" otherwise.
+func errorName(err error) string {
+	var en goa.GoaErrorNamer
+	if errors.As(err, &en) {
+		return en.GoaErrorName()
+	}
+	return "unknown"
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	var (
+		m   = NewMetrics(MetricsDurationBuckets(1), MetricsSizeBuckets(10))
+		ctx = context.WithValue(context.WithValue(context.Background(), goa.ServiceKey, "calc"), goa.MethodKey, "add")
+	)
+	e := m.Endpoint(func(context.Context, any) (any, error) {
+		return nil, goa.PermanentError("not_found", "not found")
+	})
+	if _, err := e(ctx, nil); err == nil {
+		t.Fatal("got no error, expected the endpoint error")
+	}
+	e = m.Endpoint(func(context.Context, any) (any, error) { return nil, errors.New("boom") })
+	e(ctx, nil) // nolint: errcheck
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"# TYPE goa_requests_total counter",
+		`goa_requests_total{service="calc",method="add",route="",code=""} 2`,
+		`goa_request_errors_total{service="calc",method="add",route="",code="",error="not_found"} 1`,
+		`goa_request_errors_total{service="calc",method="add",route="",code="",error="unknown"} 1`,
+		`goa_requests_in_flight{service="calc",method="add"} 0`,
+		"# TYPE goa_request_duration_seconds histogram",
+		`goa_request_duration_seconds_bucket{service="calc",method="add",route="",code="",le="+Inf"} 2`,
+		`goa_request_duration_seconds_count{service="calc",method="add",route="",code=""} 2`,
+		`goa_request_size_bytes_bucket{service="calc",method="add",route="",code="",le="10"} 2`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("got metrics:\n%s\nexpected line %q", out, line)
+		}
+	}
+}
+
+func TestMetricsRequest(t *testing.T) {
+	m := NewMetrics(MetricsNamespace("api"))
+	ctx, r := m.StartRequest(context.Background(), "/chat")
+	if ContextMetricsRequest(ctx) != r {
+		t.Fatal("got a context without the request metrics")
+	}
+	e := m.Endpoint(func(context.Context, any) (any, error) { return nil, nil })
+	ctx = context.WithValue(context.WithValue(ctx, goa.ServiceKey, "chat"), goa.MethodKey, "say\"hi\"")
+	if _, err := e(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	r.MessageReceived()
+	r.MessageSent()
+	r.MessageSent()
+	r.RequestSize = 150
+	r.End("OK", false)
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		`api_requests_total{service="chat",method="say\"hi\"",route="/chat",code="OK"} 1`,
+		`api_stream_messages_received_total{service="chat",method="say\"hi\"",route="/chat"} 1`,
+		`api_stream_messages_sent_total{service="chat",method="say\"hi\"",route="/chat"} 2`,
+		`api_request_size_bytes_bucket{service="chat",method="say\"hi\"",route="/chat",code="OK",le="100"} 0`,
+		`api_request_size_bytes_bucket{service="chat",method="say\"hi\"",route="/chat",code="OK",le="1000"} 1`,
+		`api_request_size_bytes_sum{service="chat",method="say\"hi\"",route="/chat",code="OK"} 150`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("got metrics:\n%s\nexpected line %q", out, line)
+		}
+	}
+	if strings.Contains(out, "api_request_errors_total") {
+		t.Errorf("got metrics:\n%s\nexpected no error", out)
+	}
+}
+
+func TestMetricsEndpointPanic(t *testing.T) {
+	var (
+		m   = NewMetrics()
+		ctx = context.WithValue(context.WithValue(context.Background(), goa.ServiceKey, "calc"), goa.MethodKey, "add")
+	)
+	e := m.Endpoint(func(context.Context, any) (any, error) { panic("boom") })
+	func() {
+		defer func() { recover() }() // nolint: errcheck
+		e(ctx, nil)                  // nolint: errcheck
+	}()
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if line := `goa_requests_in_flight{service="calc",method="add"} 0`; !strings.Contains(buf.String(), line+"\n") {
+		t.Errorf("got metrics:\n%s\nexpected line %q", buf.String(), line)
+	}
+}
+
+func TestMetricsWriteToSlowWriter(t *testing.T) {
+	var (
+		m       = NewMetrics()
+		ctx     = context.WithValue(context.WithValue(context.Background(), goa.ServiceKey, "calc"), goa.MethodKey, "add")
+		e       = m.Endpoint(func(context.Context, any) (any, error) { return nil, nil })
+		w       = &blockingWriter{writing: make(chan struct{}), release: make(chan struct{})}
+		written = make(chan struct{})
+	)
+	e(ctx, nil) // nolint: errcheck
+	go func() {
+		m.WriteTo(w) // nolint: errcheck
+		close(written)
+	}()
+	<-w.writing
+	done := make(chan struct{})
+	go func() {
+		e(ctx, nil) // nolint: errcheck
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("recording metrics blocked by a slow writer")
+	}
+	close(w.release)
+	<-written
+}
+
+// blockingWriter is a writer that closes writing on the first write and blocks
+// until release is closed.
+type blockingWriter struct {
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	close(w.writing)
+	<-w.release
+	return len(p), nil
+}
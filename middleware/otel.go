@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// OTelOption is a constructor option that makes it possible to
+	// customize the OpenTelemetry middlewares.
+	OTelOption func(*OTelOptions) *OTelOptions
+
+	// OTelOptions is the struct storing all the options for the
+	// OpenTelemetry middlewares.
+	OTelOptions struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagator     propagation.TextMapPropagator
+	}
+)
+
+// OTelInstrumentationName is the name of the OpenTelemetry instrumentation
+// scope used by the Goa middlewares to create tracers and meters.
+const OTelInstrumentationName = "goa.design/goa/v3"
+
+// NewOTelOptions returns the OpenTelemetry middleware options by running the
+// given constructors. The options default to the global tracer provider, meter
+// provider and propagator, see the go.opentelemetry.io/otel package.
+func NewOTelOptions(opts ...OTelOption) *OTelOptions {
+	o := &OTelOptions{}
+	for _, opt := range opts {
+		o = opt(o)
+	}
+	return o
+}
+
+// Tracer returns the tracer used by the middlewares.
+func (o *OTelOptions) Tracer() trace.Tracer {
+	tp := o.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(OTelInstrumentationName)
+}
+
+// Meter returns the meter used by the middlewares.
+func (o *OTelOptions) Meter() metric.Meter {
+	mp := o.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	return mp.Meter(OTelInstrumentationName)
+}
+
+// Propagator returns the propagator used by the middlewares to extract and
+// inject the trace context.
+func (o *OTelOptions) Propagator() propagation.TextMapPropagator {
+	if o.propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return o.propagator
+}
+
+// OTelTracerProvider configures the tracer provider used to create spans.
+func OTelTracerProvider(tp trace.TracerProvider) OTelOption {
+	return func(o *OTelOptions) *OTelOptions {
+		o.tracerProvider = tp
+		return o
+	}
+}
+
+// OTelMeterProvider configures the meter provider used to record metrics.
+func OTelMeterProvider(mp metric.MeterProvider) OTelOption {
+	return func(o *OTelOptions) *OTelOptions {
+		o.meterProvider = mp
+		return o
+	}
+}
+
+// OTelPropagator configures the propagator used to extract and inject the
+// trace context, e.g. propagation.TraceContext{} for W3C trace context.
+func OTelPropagator(p propagation.TextMapPropagator) OTelOption {
+	return func(o *OTelOptions) *OTelOptions {
+		o.propagator = p
+		return o
+	}
+}
+
+// OTelEndpoint returns an endpoint middleware that adds the names of the
+// service and method stored in the request context by the generated code to
+// the current OpenTelemetry span as the "goa.service" and "goa.method"
+// attributes. The middleware also records the errors returned by the endpoint
+// on the span, see RecordOTelError. The span is created by the transport
+// middlewares, see the OTel middlewares of the http/middleware and
+// grpc/middleware packages. Use it with the generated Endpoints Use method:
+//
+//	endpoints := calc.NewEndpoints(svc)
+//	endpoints.Use(middleware.OTelEndpoint())
+func OTelEndpoint() func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			span := trace.SpanFromContext(ctx)
+			if svc, ok := ctx.Value(goa.ServiceKey).(string); ok {
+				span.SetAttributes(attribute.String("goa.service", svc))
+			}
+			if m, ok := ctx.Value(goa.MethodKey).(string); ok {
+				span.SetAttributes(attribute.String("goa.method", m))
+			}
+			res, err := e(ctx, req)
+			if err != nil {
+				RecordOTelError(span, err)
+			}
+			return res, err
+		}
+	}
+}
+
+// RecordOTelError records err on span. The details of goa service errors are
+// recorded as "goa.error.*" attributes. The span status is set to error
+// unless err is a service error that is not a fault or an error defined in the
+// design.
+func RecordOTelError(span trace.Span, err error) {
+	span.RecordError(err)
+	var (
+		gerr *goa.ServiceError
+		en   goa.GoaErrorNamer
+	)
+	switch {
+	case errors.As(err, &gerr):
+		span.SetAttributes(
+			attribute.String("goa.error.name", gerr.Name),
+			attribute.String("goa.error.id", gerr.ID),
+			attribute.Bool("goa.error.temporary", gerr.Temporary),
+			attribute.Bool("goa.error.timeout", gerr.Timeout),
+			attribute.Bool("goa.error.fault", gerr.Fault),
+		)
+		if gerr.Fault {
+			span.SetStatus(codes.Error, gerr.Message)
+		}
+	case errors.As(err, &en):
+		span.SetAttributes(attribute.String("goa.error.name", en.GoaErrorName()))
+	default:
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestOTelEndpoint(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Err    error
+		Status codes.Code
+		Attrs  map[string]string
+	}{
+		{"success", nil, codes.Unset, map[string]string{"goa.service": "calc", "goa.method": "add"}},
+		{"service-error", goa.PermanentError("not_found", "not found"), codes.Unset, map[string]string{"goa.error.name": "not_found", "goa.error.fault": "false"}},
+		{"fault", goa.Fault("boom"), codes.Error, map[string]string{"goa.error.name": "fault", "goa.error.fault": "true"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+			ctx = context.WithValue(ctx, goa.ServiceKey, "calc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "add")
+			endpoint := OTelEndpoint()(func(context.Context, any) (any, error) { return nil, c.Err })
+
+			if _, err := endpoint(ctx, nil); err != c.Err {
+				t.Errorf("got error %v, expected %v", err, c.Err)
+			}
+			span.End()
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			if spans[0].Status.Code != c.Status {
+				t.Errorf("got status %s, expected %s", spans[0].Status.Code, c.Status)
+			}
+			attrs := make(map[attribute.Key]string)
+			for _, a := range spans[0].Attributes {
+				attrs[a.Key] = a.Value.Emit()
+			}
+			for k, v := range c.Attrs {
+				if attrs[attribute.Key(k)] != v {
+					t.Errorf("got attribute %q = %q, expected %q", k, attrs[attribute.Key(k)], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Recover returns an endpoint middleware that recovers from panics raised by
+// the endpoint. The panic is converted into a goa fault error which is encoded
+// by the method error encoder so that clients get a well-formed response. The
+// panic value and stack trace are logged with l together with the error ID so
+// that the response can be correlated with the log entry, see LogPanic. Use it
+// with the generated Endpoints Use method:
+//
+//	endpoints := calc.NewEndpoints(svc)
+//	endpoints.Use(middleware.Recover(logger))
+func Recover(l Logger) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (res any, err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					res, err = nil, LogPanic(ctx, l, v)
+				}
+			}()
+			return e(ctx, req)
+		}
+	}
+}
+
+// LogPanic logs the value v recovered from a panic and the current stack trace
+// with l and returns the corresponding goa fault error. The log entry includes
+// the error ID and the request ID stored in ctx if any. l may be nil in which
+// case nothing is logged. The error message does not include the panic value
+// to avoid leaking internal details to clients.
+func LogPanic(ctx context.Context, l Logger, v any) *goa.ServiceError {
+	err := goa.Fault("internal error")
+	if l != nil {
+		keyvals := []any{"msg", "panic recovered", "id", err.ID}
+		if reqID, ok := ctx.Value(RequestIDKey).(string); ok {
+			keyvals = append(keyvals, "request_id", reqID)
+		}
+		keyvals = append(keyvals, "panic", fmt.Sprint(v), "stack", string(debug.Stack()))
+		l.Log(keyvals...) // nolint: errcheck
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type testLogger struct {
+	keyvals []any
+}
+
+func (l *testLogger) Log(keyvals ...any) error {
+	l.keyvals = append(l.keyvals, keyvals...)
+	return nil
+}
+
+func (l *testLogger) value(key string) any {
+	for i := 0; i < len(l.keyvals)-1; i += 2 {
+		if l.keyvals[i] == key {
+			return l.keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	var (
+		l   = &testLogger{}
+		ctx = context.WithValue(context.Background(), RequestIDKey, "reqid") // nolint: staticcheck
+	)
+	e := Recover(l)(func(context.Context, any) (any, error) {
+		panic("boom")
+	})
+	res, err := e(ctx, nil)
+	if res != nil {
+		t.Errorf("got result %v, expected nil", res)
+	}
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) {
+		t.Fatalf("got error %v, expected a service error", err)
+	}
+	if !gerr.Fault || gerr.Name != "fault" || gerr.ID == "" {
+		t.Errorf("got error %+v, expected a fault with an ID", gerr)
+	}
+	if strings.Contains(gerr.Message, "boom") {
+		t.Errorf("got message %q, expected the panic value not to be leaked", gerr.Message)
+	}
+	if got := l.value("id"); got != gerr.ID {
+		t.Errorf("got logged ID %v, expected %q", got, gerr.ID)
+	}
+	if got := l.value("request_id"); got != "reqid" {
+		t.Errorf("got logged request ID %v, expected %q", got, "reqid")
+	}
+	if got := l.value("panic"); got != "boom" {
+		t.Errorf("got logged panic %v, expected %q", got, "boom")
+	}
+	if stack, _ := l.value("stack").(string); !strings.Contains(stack, "TestRecover") {
+		t.Errorf("got logged stack %q, expected the stack of the panic", stack)
+	}
+
+	e = Recover(nil)(func(context.Context, any) (any, error) { return "ok", nil })
+	if res, err := e(ctx, nil); res != "ok" || err != nil {
+		t.Errorf("got %v, %v, expected the endpoint result", res, err)
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type (
@@ -23,12 +28,24 @@ type (
 		// requestIDLimit if positive truncates the request ID at the specified
 		// length. Defaults to no limit.
 		requestIDLimit int
+		// useTraceparent if true causes the middleware to derive the request
+		// ID from the trace ID of the incoming W3C traceparent header when
+		// the request ID header is missing.
+		useTraceparent bool
+		// generator generates new request IDs. Defaults to short random IDs.
+		generator func() string
 	}
 )
 
+// TraceparentHeader is the name of the W3C trace context header.
+const TraceparentHeader = "traceparent"
+
+// crockford is the Crockford base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
 // NewRequestIDOptions initializes the options for the request ID middleware.
 func NewRequestIDOptions(options ...RequestIDOption) *RequestIDOptions {
-	o := new(RequestIDOptions)
+	o := &RequestIDOptions{requestIDHeader: "X-Request-Id"}
 	for _, option := range options {
 		o = option(o)
 	}
@@ -36,12 +53,14 @@ func NewRequestIDOptions(options ...RequestIDOption) *RequestIDOptions {
 }
 
 // GenerateRequestID initializes the given context with a unique value under
-// the RequestIDKey key. If UseRequestIDOption is set to true, it uses the
-// RequestIDKey key in the context (if present) instead of generating a new ID.
+// the RequestIDKey key. If UseRequestIDOption or TraceparentRequestIDOption is
+// set to true, it uses the RequestIDKey key in the context (if present) instead
+// of generating a new ID. New IDs are generated with the function set with
+// RequestIDGeneratorOption if any.
 func GenerateRequestID(ctx context.Context, o *RequestIDOptions) context.Context {
 	var id string
 	{
-		if o.useRequestID {
+		if o.useRequestID || o.useTraceparent {
 			if i := ctx.Value(RequestIDKey); i != nil {
 				id = i.(string)
 				if o.requestIDLimit > 0 && len(id) > o.requestIDLimit {
@@ -50,12 +69,36 @@ func GenerateRequestID(ctx context.Context, o *RequestIDOptions) context.Context
 			}
 		}
 		if id == "" {
-			id = shortID()
+			id = o.NewRequestID()
 		}
 	}
 	return context.WithValue(ctx, RequestIDKey, id) // nolint: staticcheck
 }
 
+// TraceparentTraceID returns the trace ID of the given W3C traceparent header
+// value or the empty string if the value is not a valid traceparent, see
+// https://www.w3.org/TR/trace-context/#traceparent-header.
+func TraceparentTraceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return ""
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return ""
+	}
+	if !isLowerHex(traceID, 32) || traceID == strings.Repeat("0", 32) {
+		return ""
+	}
+	if !isLowerHex(parentID, 16) || parentID == strings.Repeat("0", 16) {
+		return ""
+	}
+	if !isLowerHex(flags, 2) {
+		return ""
+	}
+	return traceID
+}
+
 // UseRequestIDOption enables/disables using RequestID context key to store
 // the unique request ID.
 func UseRequestIDOption(f bool) RequestIDOption {
@@ -85,6 +128,43 @@ func RequestIDLimitOption(limit int) RequestIDOption {
 	}
 }
 
+// TraceparentRequestIDOption enables/disables deriving the request ID from the
+// trace ID of the incoming W3C traceparent header when the request does not
+// carry a request ID header (or if UseRequestIDOption is not enabled).
+func TraceparentRequestIDOption(f bool) RequestIDOption {
+	return func(o *RequestIDOptions) *RequestIDOptions {
+		o.useTraceparent = f
+		return o
+	}
+}
+
+// RequestIDGeneratorOption sets the function used to generate new request
+// IDs. See also UUIDv4RequestIDOption, UUIDv7RequestIDOption and
+// ULIDRequestIDOption.
+func RequestIDGeneratorOption(f func() string) RequestIDOption {
+	return func(o *RequestIDOptions) *RequestIDOptions {
+		o.generator = f
+		return o
+	}
+}
+
+// UUIDv4RequestIDOption generates random (version 4) UUIDs as request IDs.
+func UUIDv4RequestIDOption() RequestIDOption {
+	return RequestIDGeneratorOption(uuid.NewString)
+}
+
+// UUIDv7RequestIDOption generates time-ordered (version 7) UUIDs as request
+// IDs.
+func UUIDv7RequestIDOption() RequestIDOption {
+	return RequestIDGeneratorOption(uuidV7)
+}
+
+// ULIDRequestIDOption generates ULIDs as request IDs, see
+// https://github.com/ulid/spec.
+func ULIDRequestIDOption() RequestIDOption {
+	return RequestIDGeneratorOption(ulid)
+}
+
 // IsUseRequestID returns the request ID option.
 func (o *RequestIDOptions) IsUseRequestID() bool {
 	return o.useRequestID
@@ -95,6 +175,19 @@ func (o *RequestIDOptions) RequestIDHeader() string {
 	return o.requestIDHeader
 }
 
+// IsUseTraceparent returns the traceparent option.
+func (o *RequestIDOptions) IsUseTraceparent() bool {
+	return o.useTraceparent
+}
+
+// NewRequestID generates a new request ID using the configured generator.
+func (o *RequestIDOptions) NewRequestID() string {
+	if o.generator != nil {
+		return o.generator()
+	}
+	return shortID()
+}
+
 // shortID produces a " unique" 6 bytes long string.
 // Do not use as a reliable way to get unique IDs, instead use for things like logging.
 func shortID() string {
@@ -102,3 +195,45 @@ func shortID() string {
 	io.ReadFull(rand.Reader, b) // nolint: errcheck
 	return base64.RawURLEncoding.EncodeToString(b)
 }
+
+// uuidV7 returns a new version 7 UUID or a version 4 UUID if the system clock
+// or random source fails.
+func uuidV7() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// ulid returns a new ULID: a 48 bits millisecond timestamp followed by 80
+// random bits encoded with the Crockford base32 alphabet.
+func ulid() string {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	io.ReadFull(rand.Reader, b[6:]) // nolint: errcheck
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var id [26]byte
+	for i := len(id) - 1; i >= 0; i-- {
+		id[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(id[:])
+}
+
+// isLowerHex returns true if s is made of n lowercase hexadecimal digits.
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
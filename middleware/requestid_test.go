@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestTraceparentTraceID(t *testing.T) {
+	cases := []struct {
+		name        string
+		traceparent string
+		expected    string
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"future-version", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"empty", "", ""},
+		{"invalid-version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ""},
+		{"extra-fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", ""},
+		{"zero-trace-id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ""},
+		{"zero-parent-id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ""},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", ""},
+		{"short-trace-id", "00-4bf92f3577b34da6-00f067aa0ba902b7-01", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := TraceparentTraceID(c.traceparent); got != c.expected {
+				t.Errorf("got %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateRequestID(t *testing.T) {
+	cases := []struct {
+		name    string
+		options []RequestIDOption
+		pattern string
+	}{
+		{"default", nil, `^[A-Za-z0-9_-]{8}$`},
+		{"uuidv4", []RequestIDOption{UUIDv4RequestIDOption()}, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{"uuidv7", []RequestIDOption{UUIDv7RequestIDOption()}, `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{"ulid", []RequestIDOption{ULIDRequestIDOption()}, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`},
+		{"custom", []RequestIDOption{RequestIDGeneratorOption(func() string { return "custom" })}, `^custom$`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := GenerateRequestID(context.Background(), NewRequestIDOptions(c.options...))
+			id, _ := ctx.Value(RequestIDKey).(string)
+			if !regexp.MustCompile(c.pattern).MatchString(id) {
+				t.Errorf("got %q, expected to match %q", id, c.pattern)
+			}
+		})
+	}
+}
+
+func TestULIDOrdering(t *testing.T) {
+	a := ulid()
+	b := ulid()
+	if a[:10] > b[:10] {
+		t.Errorf("got timestamps %q > %q, expected ULIDs to be sortable", a[:10], b[:10])
+	}
+}
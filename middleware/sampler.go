@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"math/rand"
+	"regexp"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,11 +26,41 @@ type (
 	}
 
 	fixedSampler int
+
+	// TraceSampler makes the sampling decisions of the trace middlewares
+	// according to the rules configured with the trace options. Use
+	// TraceOptions.NewTraceSampler to create a trace sampler.
+	TraceSampler struct {
+		sampler Sampler
+		routes  []*routeSampler
+		errors  bool
+		slow    time.Duration
+		tail    bool
+	}
+
+	// SpanBuffer buffers the span data recorded while handling a request
+	// until the tail sampling decision is made, see TailSampling.
+	SpanBuffer struct {
+		mu      sync.Mutex
+		pending []func()
+		done    bool
+		sampled bool
+	}
+
+	// routeSampler samples the requests whose route matches pattern.
+	routeSampler struct {
+		pattern *regexp.Regexp
+		sampler Sampler
+	}
 )
 
 // Let tests override the random number generator.
 var intn = rand.Intn
 
+// MaxBufferedSpans is the maximum number of span data recorded in a SpanBuffer,
+// the span data recorded once the buffer is full is discarded.
+const MaxBufferedSpans = 1000
+
 const (
 	// adaptive upper bound has granularity in case caller becomes extremely busy.
 	adaptiveUpperBoundInt   = 10000
@@ -99,3 +131,82 @@ func (s fixedSampler) Sample() bool {
 	samplingPercent := int(s)
 	return samplingPercent > 0 && (samplingPercent == 100 || intn(100) < samplingPercent)
 }
+
+// SampleRoute makes the head sampling decision for a request made to the given
+// route (the HTTP route pattern or request path or the gRPC full method). It uses the sampling
+// rate of the first SampleRoute rule whose pattern matches the route if any,
+// the global sampling rate otherwise.
+func (s *TraceSampler) SampleRoute(route string) bool {
+	for _, r := range s.routes {
+		if r.pattern.MatchString(route) {
+			return r.sampler.Sample()
+		}
+	}
+	return s.sampler.Sample()
+}
+
+// Tail returns true if the sampling decision must be deferred until the
+// request completes, see TailSampling.
+func (s *TraceSampler) Tail() bool {
+	return s.tail
+}
+
+// SampleCompleted makes the tail sampling decision for a completed request
+// given the head sampling decision, whether the request failed and how long it
+// took to complete.
+func (s *TraceSampler) SampleCompleted(sampled, failed bool, duration time.Duration) bool {
+	return sampled || s.errors && failed || s.slow > 0 && duration >= s.slow
+}
+
+// NewSpanBuffer returns an empty span buffer.
+func NewSpanBuffer() *SpanBuffer {
+	return &SpanBuffer{}
+}
+
+// WithSpanBuffer returns a context containing the given span buffer.
+func WithSpanBuffer(ctx context.Context, b *SpanBuffer) context.Context {
+	return context.WithValue(ctx, TraceSpanBufferKey, b) // nolint: staticcheck
+}
+
+// ContextSpanBuffer returns the span buffer stored in ctx if any. Span data
+// producers (e.g. the AWS X-Ray middleware) use it to defer sending the spans
+// of requests subject to tail sampling.
+func ContextSpanBuffer(ctx context.Context) *SpanBuffer {
+	b, _ := ctx.Value(TraceSpanBufferKey).(*SpanBuffer)
+	return b
+}
+
+// Record buffers f until the sampling decision is made. f is called once the
+// request completes if it is sampled and discarded otherwise. Record calls f
+// right away if the request is already known to be sampled. f is discarded if
+// the buffer already holds MaxBufferedSpans functions.
+func (b *SpanBuffer) Record(f func()) {
+	b.mu.Lock()
+	if !b.done {
+		if len(b.pending) < MaxBufferedSpans {
+			b.pending = append(b.pending, f)
+		}
+		b.mu.Unlock()
+		return
+	}
+	sampled := b.sampled
+	b.mu.Unlock()
+	if sampled {
+		f()
+	}
+}
+
+// Complete records the sampling decision and calls the pending functions in
+// the order they were recorded if sampled is true.
+func (b *SpanBuffer) Complete(sampled bool) {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending, b.done, b.sampled = nil, true, sampled
+	b.mu.Unlock()
+	if !sampled {
+		return
+	}
+	for _, f := range pending {
+		f()
+	}
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -124,3 +127,92 @@ func TestAdaptiveSampler(t *testing.T) {
 		t.Errorf("Unexpected trueCount: %d", trueCount)
 	}
 }
+
+func TestTraceSampler(t *testing.T) {
+	o := NewTraceOptions(
+		SamplingPercent(0),
+		SampleRoute(regexp.MustCompile("^/always"), 100),
+		SampleRoute(regexp.MustCompile("^/"), 0),
+		SampleSlow(time.Second),
+		SampleErrors(),
+	)
+	s := o.NewTraceSampler()
+	if !s.Tail() {
+		t.Error("expected tail sampling to be enabled")
+	}
+	cases := []struct {
+		name     string
+		route    string
+		failed   bool
+		duration time.Duration
+		expected bool
+	}{
+		{"route", "/always/sampled", false, 0, true},
+		{"first-rule-wins", "/always", false, 0, true},
+		{"other-route", "/other", false, 0, false},
+		{"global-rate", "Test.Test", false, 0, false},
+		{"error", "/other", true, 0, true},
+		{"slow", "/other", false, time.Second, true},
+		{"fast", "/other", false, time.Second - time.Millisecond, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sampled := s.SampleRoute(c.route)
+			if got := s.SampleCompleted(sampled, c.failed, c.duration); got != c.expected {
+				t.Errorf("got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+	if NewTraceOptions().NewTraceSampler().Tail() {
+		t.Error("expected tail sampling to be disabled by default")
+	}
+}
+
+func TestSpanBuffer(t *testing.T) {
+	cases := []struct {
+		name     string
+		sampled  bool
+		expected []int
+	}{
+		{"sampled", true, []int{1, 2, 3}},
+		{"dropped", false, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var (
+				got []int
+				b   = NewSpanBuffer()
+			)
+			b.Record(func() { got = append(got, 1) })
+			b.Record(func() { got = append(got, 2) })
+			if len(got) != 0 {
+				t.Fatalf("got %v before completion, expected nothing", got)
+			}
+			b.Complete(c.sampled)
+			b.Record(func() { got = append(got, 3) })
+			if fmt.Sprint(got) != fmt.Sprint(c.expected) {
+				t.Errorf("got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+	t.Run("full", func(t *testing.T) {
+		var (
+			count int
+			b     = NewSpanBuffer()
+		)
+		for i := 0; i < MaxBufferedSpans+1; i++ {
+			b.Record(func() { count++ })
+		}
+		b.Complete(true)
+		if count != MaxBufferedSpans {
+			t.Errorf("got %d spans, expected %d", count, MaxBufferedSpans)
+		}
+	})
+	ctx := WithSpanBuffer(context.Background(), NewSpanBuffer())
+	if ContextSpanBuffer(ctx) == nil {
+		t.Error("expected span buffer in context")
+	}
+	if ContextSpanBuffer(context.Background()) != nil {
+		t.Error("expected no span buffer in context")
+	}
+}
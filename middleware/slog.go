@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// slogAdapter is a thin wrapper around a log/slog logger that adapts it to
+// the Logger interface.
+type slogAdapter struct {
+	*slog.Logger
+}
+
+// NewSlogLogger creates a Logger backed by a log/slog logger. The value of the
+// "msg" key if any is used as the log message, the other key value pairs are
+// logged as attributes at the info level.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogAdapter{l}
+}
+
+func (a *slogAdapter) Log(keyvals ...any) error {
+	var msg string
+	attrs := make([]slog.Attr, 0, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		k := fmt.Sprint(keyvals[i])
+		var v any = "MISSING"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		if k == "msg" {
+			msg = fmt.Sprint(v)
+			continue
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	a.Logger.LogAttrs(context.Background(), slog.LevelInfo, msg, attrs...)
+	return nil
+}
+
+// ContextTraceID returns the ID of the trace the request whose context is
+// given belongs to. It is the trace ID of the OpenTelemetry span stored in ctx
+// if any, the trace ID stored under the TraceIDKey key by the tracing
+// middlewares otherwise and the empty string if there is none.
+func ContextTraceID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	if id, ok := ctx.Value(TraceIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	if err := l.Log("msg", "hello", "id", "123", "status", 200, "odd"); err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{"msg": "hello", "id": "123", "status": float64(200), "odd": "MISSING", "level": "INFO"}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("got %s=%v, expected %v", k, entry[k], v)
+		}
+	}
+}
+
+func TestContextTraceID(t *testing.T) {
+	if got := ContextTraceID(context.Background()); got != "" {
+		t.Errorf("got trace ID %q, expected none", got)
+	}
+	ctx := context.WithValue(context.Background(), TraceIDKey, "trace") // nolint: staticcheck
+	if got := ContextTraceID(ctx); got != "trace" {
+		t.Errorf("got trace ID %q, expected %q", got, "trace")
+	}
+}
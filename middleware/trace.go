@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"regexp"
+	"time"
 )
 
 type (
@@ -22,6 +23,10 @@ type (
 		maxSamplingRate int
 		sampleSize      int
 		discards        []*regexp.Regexp
+		routes          []*routeSampler
+		sampleErrors    bool
+		slowThreshold   time.Duration
+		tail            bool
 	}
 
 	// tracedLogger is a logger which logs the trace ID with every log entry
@@ -57,6 +62,19 @@ func (o *TraceOptions) NewSampler() Sampler {
 	return NewFixedSampler(o.samplingPercent)
 }
 
+// NewTraceSampler returns a TraceSampler that applies the sampling rules
+// configured with SampleRoute, SampleErrors, SampleSlow and TailSampling on
+// top of the sampler returned by NewSampler.
+func (o *TraceOptions) NewTraceSampler() *TraceSampler {
+	return &TraceSampler{
+		sampler: o.NewSampler(),
+		routes:  o.routes,
+		errors:  o.sampleErrors,
+		slow:    o.slowThreshold,
+		tail:    o.tail,
+	}
+}
+
 // TraceID returns a new trace ID. Use TraceIDFunc to set the function that
 // generates trace IDs.
 func (o *TraceOptions) TraceID() string {
@@ -152,6 +170,69 @@ func DiscardFromTrace(discard *regexp.Regexp) TraceOption {
 	}
 }
 
+// SampleRoute sets the percentage of requests whose route matches the given
+// regular expression that should be traced. The route is the route pattern
+// (e.g. "/users/{id}") for HTTP requests, see the HTTP TraceMux middleware, and
+// the full method name for gRPC requests. The rules are
+// evaluated in the order they are defined and the first matching rule wins,
+// requests that do not match any rule are sampled using the global sampling
+// rate. It panics if route is nil or if p is less than 0 or more than 100.
+func SampleRoute(route *regexp.Regexp, p int) TraceOption {
+	if route == nil {
+		panic("route cannot be nil")
+	}
+	if p < 0 || p > 100 {
+		panic("sampling rate must be between 0 and 100")
+	}
+	return func(o *TraceOptions) *TraceOptions {
+		o.routes = append(o.routes, &routeSampler{pattern: route, sampler: NewFixedSampler(p)})
+		return o
+	}
+}
+
+// SampleErrors causes all the requests that fail to be traced regardless of
+// the sampling rate. A HTTP request fails if the response status code is 5xx
+// or if the endpoint returns an error, a gRPC request fails if the handler
+// returns an error. SampleErrors enables tail sampling, see TailSampling.
+func SampleErrors() TraceOption {
+	return func(o *TraceOptions) *TraceOptions {
+		o.sampleErrors = true
+		o.tail = true
+		return o
+	}
+}
+
+// SampleSlow causes all the requests that take longer than the given
+// threshold to complete to be traced regardless of the sampling rate.
+// SampleSlow enables tail sampling, see TailSampling. It panics if threshold is
+// not positive.
+func SampleSlow(threshold time.Duration) TraceOption {
+	if threshold <= 0 {
+		panic("slow request threshold must be greater than 0")
+	}
+	return func(o *TraceOptions) *TraceOptions {
+		o.slowThreshold = threshold
+		o.tail = true
+		return o
+	}
+}
+
+// TailSampling defers the sampling decision until the request completes. The
+// trace middlewares initialize the trace information of all the requests that
+// are not discarded and store a SpanBuffer in the request context. The span
+// data producers record the spans in the buffer which sends them only if the
+// request is sampled once it completes. Requests that already have a trace ID
+// are always traced and are not buffered.
+//
+// Note that the trace information is propagated to the downstream services
+// (e.g. via WrapDoer) before the sampling decision is made.
+func TailSampling() TraceOption {
+	return func(o *TraceOptions) *TraceOptions {
+		o.tail = true
+		return o
+	}
+}
+
 // WithSpan returns a context containing the given trace, span and parent span
 // IDs.
 func WithSpan(ctx context.Context, traceID, spanID, parentID string) context.Context {
@@ -9,6 +9,8 @@ import (
 	"net"
 	"sync"
 	"time"
+
+	"goa.design/goa/v3/middleware"
 )
 
 const (
@@ -19,6 +21,13 @@ const (
 type (
 	// private type used to define context keys.
 	contextKey int
+
+	// spanBufferConn is a connection whose writes are recorded in a span
+	// buffer.
+	spanBufferConn struct {
+		net.Conn
+		buf *middleware.SpanBuffer
+	}
 )
 
 // Connect creates a goroutine to periodically re-dial a connection, so the
@@ -64,6 +73,25 @@ func Connect(ctx context.Context, renewPeriod time.Duration, dial func() (net.Co
 	}, nil
 }
 
+// BufferConn returns conn if ctx does not contain a span buffer. Otherwise it
+// returns a connection that records the writes made to conn in the span buffer
+// so that the segments are only sent if the request is sampled once it
+// completes, see middleware.TailSampling.
+func BufferConn(ctx context.Context, conn net.Conn) net.Conn {
+	buf := middleware.ContextSpanBuffer(ctx)
+	if buf == nil {
+		return conn
+	}
+	return &spanBufferConn{Conn: conn, buf: buf}
+}
+
+// Write records the write in the span buffer.
+func (c *spanBufferConn) Write(b []byte) (int, error) {
+	p := append([]byte(nil), b...)
+	c.buf.Record(func() { c.Conn.Write(p) }) // nolint: errcheck
+	return len(b), nil
+}
+
 // NewID is a span ID creation algorithm which produces values that are
 // compatible with AWS X-Ray.
 func NewID() string {
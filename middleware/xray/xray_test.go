@@ -8,6 +8,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"goa.design/goa/v3/middleware"
 )
 
 func TestNewID(t *testing.T) {
@@ -128,3 +130,37 @@ func TestConnect(t *testing.T) {
 		}
 	})
 }
+
+func TestBufferConn(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	if conn := BufferConn(context.Background(), client); conn != client {
+		t.Errorf("got %v, expected the connection to be returned as is", conn)
+	}
+
+	buf := middleware.NewSpanBuffer()
+	conn := BufferConn(middleware.WithSpanBuffer(context.Background(), buf), client)
+	msg := []byte("segment")
+	if n, err := conn.Write(msg); n != len(msg) || err != nil {
+		t.Fatalf("got %d, %v, expected %d, nil", n, err, len(msg))
+	}
+	msg[0] = 'X' // the buffered write must not be affected
+
+	received := make(chan string, 1)
+	go func() {
+		b := make([]byte, 16)
+		n, _ := server.Read(b)
+		received <- string(b[:n])
+	}()
+	buf.Complete(true)
+	select {
+	case got := <-received:
+		if got != "segment" {
+			t.Errorf("got %q, expected %q", got, "segment")
+		}
+	case <-time.After(time.Second):
+		t.Error("timed out waiting for the buffered write")
+	}
+}
@@ -0,0 +1,252 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	// CircuitState is the state of a circuit breaker circuit.
+	CircuitState int
+
+	// CircuitBreakerOption configures the CircuitBreaker middleware.
+	CircuitBreakerOption func(*circuitBreakerOptions)
+
+	// circuitBreakerOptions contains the CircuitBreaker options.
+	circuitBreakerOptions struct {
+		threshold     int
+		openTimeout   time.Duration
+		halfOpenMax   int
+		isFailure     func(error) bool
+		onStateChange func(ctx context.Context, from, to CircuitState)
+		now           func() time.Time
+	}
+
+	// circuit is the state of the circuit of a single endpoint.
+	circuit struct {
+		opts     *circuitBreakerOptions
+		mu       sync.Mutex
+		state    CircuitState
+		failures int
+		openedAt time.Time
+		inflight int
+	}
+)
+
+const (
+	// CircuitClosed is the state of circuits that let requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen is the state of circuits that reject requests.
+	CircuitOpen
+	// CircuitHalfOpen is the state of circuits that let a limited number of
+	// trial requests through to decide whether to close again.
+	CircuitHalfOpen
+)
+
+// CircuitBreaker returns an endpoint middleware that stops calling the
+// endpoints it wraps after consecutive failures. Each endpoint wrapped by the
+// middleware has its own circuit: when used with the generated Endpoints.Use
+// method or to wrap the endpoints of a generated client each method has its
+// own circuit.
+//
+// A circuit opens after a number of consecutive failures (5 by default, see
+// CircuitBreakerThreshold). Requests made while the circuit is open fail
+// immediately with a temporary CircuitBreakerOpen error. Once the open timeout
+// elapses (30s by default, see CircuitBreakerOpenTimeout) the circuit becomes
+// half-open and lets one trial request through (see CircuitBreakerHalfOpenMax):
+// the circuit closes if the request succeeds and opens again otherwise.
+//
+// Errors count as failures as defined by IsFailure unless overridden with
+// CircuitBreakerFailure. Endpoints that panic count as failures, the panic is
+// propagated to the caller.
+//
+// Example:
+//
+//	endpoints.Use(goa.CircuitBreaker(goa.CircuitBreakerThreshold(10)))
+//
+//	c := calc.NewClient(...)
+//	c.AddEndpoint = goa.CircuitBreaker()(c.AddEndpoint)
+func CircuitBreaker(opts ...CircuitBreakerOption) func(Endpoint) Endpoint {
+	o := &circuitBreakerOptions{
+		threshold:   5,
+		openTimeout: 30 * time.Second,
+		halfOpenMax: 1,
+		isFailure:   IsFailure,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(e Endpoint) Endpoint {
+		c := &circuit{opts: o}
+		return func(ctx context.Context, req any) (res any, err error) {
+			if err := c.allow(ctx); err != nil {
+				return nil, err
+			}
+			panicked := true
+			defer func() {
+				if panicked {
+					// The panic propagates, record it as a failure.
+					c.record(ctx, true)
+					return
+				}
+				c.done(ctx, err)
+			}()
+			res, err = e(ctx, req)
+			panicked = false
+			return res, err
+		}
+	}
+}
+
+// CircuitBreakerThreshold sets the number of consecutive failures that open
+// a circuit. It panics if n is less than 1.
+func CircuitBreakerThreshold(n int) CircuitBreakerOption {
+	if n < 1 {
+		panic("circuit breaker threshold must be greater than 0")
+	}
+	return func(o *circuitBreakerOptions) {
+		o.threshold = n
+	}
+}
+
+// CircuitBreakerOpenTimeout sets the duration during which an open circuit
+// rejects requests before becoming half-open. It panics if d is not positive.
+func CircuitBreakerOpenTimeout(d time.Duration) CircuitBreakerOption {
+	if d <= 0 {
+		panic("circuit breaker open timeout must be greater than 0")
+	}
+	return func(o *circuitBreakerOptions) {
+		o.openTimeout = d
+	}
+}
+
+// CircuitBreakerHalfOpenMax sets the maximum number of concurrent trial
+// requests let through by half-open circuits. It panics if n is less than 1.
+func CircuitBreakerHalfOpenMax(n int) CircuitBreakerOption {
+	if n < 1 {
+		panic("circuit breaker half-open max must be greater than 0")
+	}
+	return func(o *circuitBreakerOptions) {
+		o.halfOpenMax = n
+	}
+}
+
+// CircuitBreakerFailure sets the function used to decide whether an error
+// returned by the endpoint counts as a failure. It panics if f is nil.
+func CircuitBreakerFailure(f func(error) bool) CircuitBreakerOption {
+	if f == nil {
+		panic("circuit breaker failure function cannot be nil")
+	}
+	return func(o *circuitBreakerOptions) {
+		o.isFailure = f
+	}
+}
+
+// CircuitBreakerOnStateChange sets a function called whenever a circuit
+// changes state, e.g. to log or record metrics. The context is the context of
+// the request that caused the change, the service and method names can be
+// retrieved from it on the server side (see ServiceKey and MethodKey). f is
+// called while the circuit is locked and must not block.
+func CircuitBreakerOnStateChange(f func(ctx context.Context, from, to CircuitState)) CircuitBreakerOption {
+	return func(o *circuitBreakerOptions) {
+		o.onStateChange = f
+	}
+}
+
+// IsFailure returns true if err indicates that the service or the network is
+// not healthy. Service errors count as failures if they are faults, timeouts
+// or temporary errors: errors defined in the design without any of these flags
+// (e.g. "not_found") do not. Errors that are not service errors, e.g. network
+// errors returned by clients, count as failures unless they are caused by the
+// request context being canceled.
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		return serr.Fault || serr.Timeout || serr.Temporary
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// allow returns an error if the request must be rejected.
+func (c *circuit) allow(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.state == CircuitOpen {
+		if c.opts.now().Sub(c.openedAt) < c.opts.openTimeout {
+			return TemporaryError(CircuitBreakerOpen, "circuit breaker is open")
+		}
+		c.setState(ctx, CircuitHalfOpen)
+	}
+	if c.state == CircuitHalfOpen {
+		if c.inflight >= c.opts.halfOpenMax {
+			return TemporaryError(CircuitBreakerOpen, "circuit breaker is half-open")
+		}
+	}
+	c.inflight++
+	return nil
+}
+
+// done records the outcome of a request let through by allow given the error
+// returned by the endpoint.
+func (c *circuit) done(ctx context.Context, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The request outcome says nothing about the endpoint health.
+		c.mu.Lock()
+		c.inflight--
+		c.mu.Unlock()
+		return
+	}
+	c.record(ctx, c.opts.isFailure(err))
+}
+
+// record records the outcome of a request let through by allow.
+func (c *circuit) record(ctx context.Context, failure bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inflight--
+	if !failure {
+		c.failures = 0
+		if c.state == CircuitHalfOpen {
+			c.setState(ctx, CircuitClosed)
+		}
+		return
+	}
+	c.failures++
+	if c.state == CircuitHalfOpen || c.state == CircuitClosed && c.failures >= c.opts.threshold {
+		c.openedAt = c.opts.now()
+		c.setState(ctx, CircuitOpen)
+	}
+}
+
+// setState changes the state of the circuit and calls the state change
+// callback. The caller must hold the circuit lock.
+func (c *circuit) setState(ctx context.Context, s CircuitState) {
+	if c.state == s {
+		return
+	}
+	from := c.state
+	c.state = s
+	if c.opts.onStateChange != nil {
+		c.opts.onStateChange(ctx, from, s)
+	}
+}
@@ -0,0 +1,154 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsFailure(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"nil":       {nil, false},
+		"fault":     {Fault("oops"), true},
+		"temporary": {TemporaryError("unavailable", "down"), true},
+		"timeout":   {PermanentTimeoutError("timeout", "slow"), true},
+		"design":    {PermanentError("not_found", "missing"), false},
+		"wrapped":   {fmt.Errorf("wrapped: %w", PermanentError("not_found", "missing")), false},
+		"network":   {errors.New("connection refused"), true},
+		"deadline":  {context.DeadlineExceeded, true},
+		"canceled":  {context.Canceled, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			if got := IsFailure(tc.err); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		now     = time.Now()
+		calls   int
+		fail    bool
+		changes []string
+	)
+	m := CircuitBreaker(
+		CircuitBreakerThreshold(2),
+		CircuitBreakerOpenTimeout(time.Minute),
+		CircuitBreakerOnStateChange(func(_ context.Context, from, to CircuitState) {
+			changes = append(changes, from.String()+"->"+to.String())
+		}),
+		func(o *circuitBreakerOptions) { o.now = func() time.Time { return now } },
+	)
+	endpoint := m(func(context.Context, any) (any, error) {
+		calls++
+		if fail {
+			return nil, Fault("oops")
+		}
+		return "ok", nil
+	})
+	call := func() error {
+		_, err := endpoint(context.Background(), nil)
+		return err
+	}
+	isOpen := func(err error) bool {
+		var serr *ServiceError
+		return errors.As(err, &serr) && serr.Name == CircuitBreakerOpen && serr.Temporary
+	}
+
+	fail = true
+	call() // nolint: errcheck
+	call() // nolint: errcheck
+	if err := call(); !isOpen(err) {
+		t.Fatalf("got error %v, want circuit open error", err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+
+	now = now.Add(time.Minute)
+	if err := call(); isOpen(err) {
+		t.Fatalf("got circuit open error, want trial request")
+	}
+	if err := call(); !isOpen(err) {
+		t.Fatalf("got error %v, want circuit open error after failed trial", err)
+	}
+
+	now = now.Add(time.Minute)
+	fail = false
+	if err := call(); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if err := call(); err != nil {
+		t.Fatalf("got error %v, want nil once closed", err)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if fmt.Sprint(changes) != fmt.Sprint(want) {
+		t.Errorf("got state changes %v, want %v", changes, want)
+	}
+}
+
+func TestCircuitBreakerIgnoresDesignErrors(t *testing.T) {
+	endpoint := CircuitBreaker(CircuitBreakerThreshold(1))(func(context.Context, any) (any, error) {
+		return nil, PermanentError("not_found", "missing")
+	})
+	for i := 0; i < 3; i++ {
+		_, err := endpoint(context.Background(), nil)
+		var serr *ServiceError
+		if !errors.As(err, &serr) || serr.Name != "not_found" {
+			t.Fatalf("got error %v, want not_found", err)
+		}
+	}
+}
+
+func TestCircuitBreakerPanic(t *testing.T) {
+	var (
+		now   = time.Now()
+		calls int
+	)
+	endpoint := CircuitBreaker(
+		CircuitBreakerThreshold(1),
+		CircuitBreakerOpenTimeout(time.Minute),
+		func(o *circuitBreakerOptions) { o.now = func() time.Time { return now } },
+	)(func(context.Context, any) (any, error) {
+		calls++
+		if calls < 3 {
+			panic("oops")
+		}
+		return "ok", nil
+	})
+	call := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		_, err = endpoint(context.Background(), nil)
+		return err
+	}
+
+	if err := call(); err == nil || err.Error() != "panic: oops" {
+		t.Fatalf("got error %v, want panic", err)
+	}
+	var serr *ServiceError
+	if err := call(); !errors.As(err, &serr) || serr.Name != CircuitBreakerOpen {
+		t.Fatalf("got error %v, want circuit open error", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := call(); err == nil || err.Error() != "panic: oops" {
+		t.Fatalf("got error %v, want panic from trial request", err)
+	}
+	now = now.Add(time.Minute)
+	if err := call(); err != nil {
+		t.Fatalf("got error %v, want trial request let through after panic", err)
+	}
+}
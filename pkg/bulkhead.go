@@ -0,0 +1,52 @@
+package goa
+
+import (
+	"context"
+	"time"
+)
+
+// Bulkhead returns an endpoint middleware that limits the number of requests
+// handled concurrently by each endpoint it wraps to max. Requests received
+// while the limit is reached wait for up to maxWait for a slot to free up, they
+// fail with a temporary BulkheadFull error if none does or if the request
+// context is done first. A maxWait of 0 fails the requests immediately. It
+// panics if max is less than 1.
+//
+// Each endpoint wrapped by the middleware has its own limit so that a slow
+// method cannot starve the others, e.g.:
+//
+//	endpoints.Use(goa.Bulkhead(100, 50*time.Millisecond))
+func Bulkhead(max int, maxWait time.Duration) func(Endpoint) Endpoint {
+	if max <= 0 {
+		panic("bulkhead max must be greater than 0")
+	}
+	return func(e Endpoint) Endpoint {
+		slots := make(chan struct{}, max)
+		return func(ctx context.Context, req any) (any, error) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				if maxWait <= 0 {
+					return nil, bulkheadFullError(max)
+				}
+				timer := time.NewTimer(maxWait)
+				select {
+				case slots <- struct{}{}:
+					timer.Stop()
+				case <-timer.C:
+					return nil, bulkheadFullError(max)
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, bulkheadFullError(max)
+				}
+			}
+			defer func() { <-slots }()
+			return e(ctx, req)
+		}
+	}
+}
+
+// bulkheadFullError returns the error returned when the bulkhead is full.
+func bulkheadFullError(max int) *ServiceError {
+	return TemporaryError(BulkheadFull, "too many concurrent requests (max %d)", max)
+}
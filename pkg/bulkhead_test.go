@@ -0,0 +1,82 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkhead(t *testing.T) {
+	cases := map[string]struct {
+		maxWait time.Duration
+		release bool
+		wantErr bool
+	}{
+		"no-wait":      {0, false, true},
+		"wait-timeout": {10 * time.Millisecond, false, true},
+		"wait-slot":    {time.Second, true, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var (
+				started = make(chan struct{})
+				release = make(chan struct{})
+			)
+			endpoint := Bulkhead(1, tc.maxWait)(func(_ context.Context, req any) (any, error) {
+				if req == "block" {
+					close(started)
+					<-release
+				}
+				return req, nil
+			})
+			go endpoint(context.Background(), "block") // nolint: errcheck
+			<-started
+			if tc.release {
+				time.AfterFunc(10*time.Millisecond, func() { close(release) })
+			} else {
+				defer close(release)
+			}
+
+			res, err := endpoint(context.Background(), "other")
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				if res != "other" {
+					t.Errorf("got result %v, want %q", res, "other")
+				}
+				return
+			}
+			var serr *ServiceError
+			if !errors.As(err, &serr) {
+				t.Fatalf("got error %v, want service error", err)
+			}
+			if serr.Name != BulkheadFull || !serr.Temporary {
+				t.Errorf("got error %q (temporary: %v), want temporary %q", serr.Name, serr.Temporary, BulkheadFull)
+			}
+		})
+	}
+}
+
+func TestBulkheadPerEndpoint(t *testing.T) {
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	defer close(release)
+	m := Bulkhead(1, 0)
+	blocking := m(func(context.Context, any) (any, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	other := m(func(context.Context, any) (any, error) { return "ok", nil })
+	go blocking(context.Background(), nil) // nolint: errcheck
+	<-started
+
+	if _, err := other(context.Background(), nil); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+}
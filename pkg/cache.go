@@ -0,0 +1,326 @@
+package goa
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// CacheOption configures the Cache middleware.
+	CacheOption func(*cacheOptions)
+
+	// cacheOptions contains the Cache options.
+	cacheOptions struct {
+		maxEntries int
+		cacheable  func(ctx context.Context, payload any) bool
+		principal  func(ctx context.Context) (string, bool)
+		now        func() time.Time
+	}
+
+	// resultCache is the LRU cache of a single endpoint.
+	resultCache struct {
+		mu      sync.Mutex
+		entries map[string]*list.Element
+		lru     *list.List
+	}
+
+	// cacheEntry is a resultCache entry.
+	cacheEntry struct {
+		key     string
+		result  any
+		expires time.Time
+	}
+
+	// flight is a request being handled on behalf of all the identical
+	// requests received concurrently.
+	flight struct {
+		done   chan struct{}
+		result any
+		err    error
+	}
+)
+
+// Coalesce returns an endpoint middleware that coalesces identical concurrent
+// requests: while a request is being handled, requests made to the same
+// endpoint with an identical payload wait for it and receive the same result
+// and error instead of calling the endpoint. Payloads are identical if their
+// JSON representations are. Waiting requests return early with the context
+// error if their context is done first. The context error of the handled
+// request is not shared: if the handled request fails because its own context
+// is done, the waiting requests are handled again.
+//
+// The coalesced requests share the same result value which must thus be
+// treated as read-only. Requests whose payload cannot be hashed, e.g. streaming
+// requests or requests with a raw body, are never coalesced. As with Cache,
+// Coalesce must not wrap the endpoints of secured methods.
+func Coalesce() func(Endpoint) Endpoint {
+	return func(e Endpoint) Endpoint {
+		var (
+			mu      sync.Mutex
+			flights = make(map[string]*flight)
+		)
+		return func(ctx context.Context, req any) (any, error) {
+			key, ok := PayloadHash(req)
+			if !ok {
+				return e(ctx, req)
+			}
+			for {
+				mu.Lock()
+				f, ok := flights[key]
+				if !ok {
+					break
+				}
+				mu.Unlock()
+				select {
+				case <-f.done:
+					if !isContextError(f.err) {
+						return f.result, f.err
+					}
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			f := &flight{done: make(chan struct{}), err: Fault("coalesced request did not complete")}
+			flights[key] = f
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				delete(flights, key)
+				mu.Unlock()
+				close(f.done)
+			}()
+			f.result, f.err = e(ctx, req)
+			return f.result, f.err
+		}
+	}
+}
+
+// Cache returns an endpoint middleware that caches the results of the
+// endpoints it wraps for the duration ttl. The cache is keyed by payload hash
+// (see PayloadHash): requests made with a payload identical to the payload of
+// a previous successful request receive the cached result until it expires.
+// Errors are never cached. Any other request property that affects the result
+// must be part of the payload or handled with CacheIf or CachePrincipal. Each
+// endpoint wrapped by the middleware has its own cache holding up to 1000
+// results by default, see CacheMaxEntries. The least recently used results are
+// evicted first. It panics if ttl is not positive.
+//
+// Cache must not wrap the endpoints of secured methods: the middlewares
+// applied with the generated Endpoints.Use method wrap the authentication,
+// authorization and audit logic of the endpoints so that a cached result is
+// returned without running them. Endpoints of secured methods may only be
+// cached with CachePrincipal if the principal is authenticated before the
+// endpoint is called, e.g. by a transport middleware.
+//
+// The cached results are shared between requests and must thus be treated as
+// read-only. Requests whose payload cannot be hashed and results that cannot
+// be shared, e.g. streams or raw bodies, are never cached. Cache can be
+// combined with Coalesce to avoid calling the endpoint concurrently for the
+// same payload when the result is not cached yet:
+//
+//	endpoints.Use(goa.Coalesce())
+//	endpoints.Use(goa.Cache(time.Minute))
+func Cache(ttl time.Duration, opts ...CacheOption) func(Endpoint) Endpoint {
+	if ttl <= 0 {
+		panic("cache TTL must be greater than 0")
+	}
+	o := &cacheOptions{maxEntries: 1000, now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(e Endpoint) Endpoint {
+		c := &resultCache{entries: make(map[string]*list.Element), lru: list.New()}
+		return func(ctx context.Context, req any) (any, error) {
+			if o.cacheable != nil && !o.cacheable(ctx, req) {
+				return e(ctx, req)
+			}
+			key, ok := PayloadHash(req)
+			if !ok {
+				return e(ctx, req)
+			}
+			if o.principal != nil {
+				principal, ok := o.principal(ctx)
+				if !ok {
+					return e(ctx, req)
+				}
+				sum := sha256.Sum256([]byte(principal + "\x00" + key))
+				key = hex.EncodeToString(sum[:])
+			}
+			now := o.now()
+			if res, ok := c.get(key, now); ok {
+				return res, nil
+			}
+			res, err := e(ctx, req)
+			if err == nil && shareable(res) {
+				c.add(key, res, now.Add(ttl), o.maxEntries)
+			}
+			return res, err
+		}
+	}
+}
+
+// CacheMaxEntries sets the maximum number of results cached for each endpoint.
+// It panics if n is less than 1.
+func CacheMaxEntries(n int) CacheOption {
+	if n < 1 {
+		panic("cache max entries must be greater than 0")
+	}
+	return func(o *cacheOptions) {
+		o.maxEntries = n
+	}
+}
+
+// CacheIf sets a function called with the request context and payload that
+// decides whether the request may be served from and its result stored in the
+// cache, e.g. to bypass the cache for requests made with a "Cache-Control:
+// no-cache" header.
+func CacheIf(f func(ctx context.Context, payload any) bool) CacheOption {
+	return func(o *cacheOptions) {
+		o.cacheable = f
+	}
+}
+
+// CachePrincipal sets a function that returns the identifier of the principal
+// making the request given the request context. Results are cached per
+// principal: requests only receive the results cached for requests made by the
+// same principal. Requests for which f returns false, e.g. because the
+// principal is not known, bypass the cache.
+func CachePrincipal(f func(ctx context.Context) (string, bool)) CacheOption {
+	return func(o *cacheOptions) {
+		o.principal = f
+	}
+}
+
+// PayloadHash returns the SHA-256 hash of the JSON representation of payload
+// and true if payload can be hashed. Payloads that embed streams or raw bodies
+// (values of interface types declaring methods, e.g. the endpoint inputs of
+// streaming methods) and payloads that cannot be serialized to JSON cannot be
+// hashed.
+func PayloadHash(payload any) (string, bool) {
+	if !shareable(payload) {
+		return "", false
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// get returns the result cached for key if any and not expired.
+func (c *resultCache) get(key string, now time.Time) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !now.Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.result, true
+}
+
+// add caches result for key and evicts the least recently used results if
+// the cache holds more than max entries.
+func (c *resultCache) add(key string, result any, expires time.Time, max int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.result, entry.expires = result, expires
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, result: result, expires: expires})
+	for c.lru.Len() > max {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// shareable returns false if v is or embeds a stream or a raw body, i.e. has
+// values with Send or Recv methods, values whose types are interfaces
+// declaring methods, or functions or channels at any depth.
+func shareable(v any) bool {
+	if v == nil {
+		return true
+	}
+	return shareableValue(reflect.ValueOf(v), make(map[uintptr]struct{}))
+}
+
+// shareableValue implements shareable, seen holds the addresses of the
+// pointers already visited.
+func shareableValue(v reflect.Value, seen map[uintptr]struct{}) bool {
+	if !v.IsValid() {
+		return true
+	}
+	t := v.Type()
+	if _, ok := t.MethodByName("Send"); ok {
+		return false
+	}
+	if _, ok := t.MethodByName("Recv"); ok {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if t.NumMethod() > 0 {
+			return false
+		}
+		return shareableValue(v.Elem(), seen)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return true
+		}
+		if _, ok := seen[v.Pointer()]; ok {
+			return true
+		}
+		seen[v.Pointer()] = struct{}{}
+		return shareableValue(v.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !shareableValue(v.Field(i), seen) {
+				return false
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if k := t.Elem().Kind(); k <= reflect.Complex128 || k == reflect.String {
+			return true
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !shareableValue(v.Index(i), seen) {
+				return false
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if !shareableValue(iter.Key(), seen) || !shareableValue(iter.Value(), seen) {
+				return false
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// isContextError returns true if err is caused by a context being canceled or
+// its deadline being exceeded.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,240 @@
+package goa
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type (
+	cacheTestPayload struct {
+		Name  string
+		Token string
+	}
+
+	cacheTestStreamInput struct {
+		Stream io.Reader
+	}
+
+	cacheTestStream struct{}
+
+	cacheTestPrincipalKey struct{}
+)
+
+func (*cacheTestStream) Recv() (string, error) { return "", nil }
+
+func TestPayloadHash(t *testing.T) {
+	cases := map[string]struct {
+		payload any
+		hashed  bool
+	}{
+		"nil":        {nil, true},
+		"string":     {"a", true},
+		"struct":     {&cacheTestPayload{Name: "a"}, true},
+		"any-field":  {&struct{ V any }{V: 1}, true},
+		"stream":     {&cacheTestStreamInput{}, false},
+		"recv":       {&cacheTestStream{}, false},
+		"not-json":   {func() {}, false},
+		"map-values": {map[string]any{"a": 1}, true},
+		"nested":     {&struct{ In *cacheTestStreamInput }{In: &cacheTestStreamInput{}}, false},
+		"any-stream": {&struct{ V any }{V: &cacheTestStream{}}, false},
+		"slice":      {[]any{"a", &cacheTestStream{}}, false},
+		"map-stream": {map[string]any{"a": &cacheTestStream{}}, false},
+		"bytes":      {&struct{ B []byte }{B: []byte("a")}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			if _, ok := PayloadHash(tc.payload); ok != tc.hashed {
+				t.Errorf("got %v, want %v", ok, tc.hashed)
+			}
+		})
+	}
+	h1, _ := PayloadHash(&cacheTestPayload{Name: "a", Token: "t1"})
+	h2, _ := PayloadHash(&cacheTestPayload{Name: "a", Token: "t1"})
+	h3, _ := PayloadHash(&cacheTestPayload{Name: "a", Token: "t2"})
+	if h1 != h2 {
+		t.Errorf("got different hashes %q and %q for identical payloads", h1, h2)
+	}
+	if h1 == h3 {
+		t.Errorf("got identical hashes for different payloads")
+	}
+}
+
+func TestCache(t *testing.T) {
+	var (
+		now   = time.Now()
+		calls int
+		fail  bool
+	)
+	endpoint := Cache(time.Minute,
+		CacheMaxEntries(2),
+		CacheIf(func(_ context.Context, p any) bool { return p != "nocache" }),
+		func(o *cacheOptions) { o.now = func() time.Time { return now } },
+	)(func(_ context.Context, req any) (any, error) {
+		calls++
+		if fail {
+			return nil, Fault("oops")
+		}
+		return req, nil
+	})
+	call := func(p string) {
+		t.Helper()
+		res, err := endpoint(context.Background(), p)
+		if fail {
+			if err == nil {
+				t.Fatalf("got nil error, want fault")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if res != p {
+			t.Fatalf("got result %v, want %q", res, p)
+		}
+	}
+	assertCalls := func(want int) {
+		t.Helper()
+		if calls != want {
+			t.Errorf("got %d calls, want %d", calls, want)
+		}
+	}
+
+	call("a")
+	call("a")
+	assertCalls(1)
+
+	call("nocache")
+	call("nocache")
+	assertCalls(3)
+
+	now = now.Add(time.Minute)
+	call("a")
+	assertCalls(4)
+
+	call("b")
+	call("c") // evicts "a"
+	call("c")
+	call("a")
+	assertCalls(7)
+
+	fail = true
+	call("d")
+	call("d")
+	assertCalls(9)
+}
+
+func TestCachePrincipal(t *testing.T) {
+	var calls int
+	endpoint := Cache(time.Minute, CachePrincipal(func(ctx context.Context) (string, bool) {
+		p, ok := ctx.Value(cacheTestPrincipalKey{}).(string)
+		return p, ok
+	}))(func(context.Context, any) (any, error) {
+		calls++
+		return calls, nil
+	})
+	call := func(principal string) any {
+		ctx := context.Background()
+		if principal != "" {
+			ctx = context.WithValue(ctx, cacheTestPrincipalKey{}, principal)
+		}
+		res, err := endpoint(ctx, "a")
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		return res
+	}
+
+	if res := call("alice"); res != 1 {
+		t.Errorf("got %v, want 1", res)
+	}
+	if res := call("alice"); res != 1 {
+		t.Errorf("got %v, want cached result 1", res)
+	}
+	if res := call("bob"); res != 2 {
+		t.Errorf("got %v, want 2 for another principal", res)
+	}
+	if res := call(""); res != 3 {
+		t.Errorf("got %v, want 3 for unknown principal", res)
+	}
+	if res := call(""); res != 4 {
+		t.Errorf("got %v, want 4 for unknown principal", res)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	var (
+		calls   int32
+		release = make(chan struct{})
+	)
+	endpoint := Coalesce()(func(_ context.Context, req any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return req, nil
+	})
+	var wg sync.WaitGroup
+	results := make([]any, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = endpoint(context.Background(), &cacheTestPayload{Name: "a"})
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond) // let the requests join the flight
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("got %d calls, want 1", n)
+	}
+	for i, res := range results {
+		if res != results[0] {
+			t.Errorf("got result %d %v, want %v", i, res, results[0])
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := endpoint(ctx, &cacheTestStreamInput{}); err != nil {
+		t.Errorf("got error %v for uncoalesced request, want nil", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+}
+
+func TestCoalesceLeaderCanceled(t *testing.T) {
+	var (
+		calls   int32
+		started = make(chan struct{})
+	)
+	endpoint := Coalesce()(func(ctx context.Context, req any) (any, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return req, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	go endpoint(ctx, "a") // nolint: errcheck
+	<-started
+	done := make(chan error)
+	go func() {
+		_, err := endpoint(context.Background(), "a")
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond) // let the request join the flight
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+}
@@ -12,6 +12,10 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// localeKey is the request context key used to store the locale used
+	// to localize error messages, see WithLocale.
+	localeKey
 )
 
 type (
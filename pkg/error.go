@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"strings"
 )
 
@@ -27,11 +28,42 @@ type (
 		Temporary bool
 		// Is the error a server-side fault?
 		Fault bool
+		// Details contains additional machine-readable information about
+		// the error.
+		Details map[string]any
 		// History tracks all the individual errors that were built into this error, should
 		// this error have been merged.
 		history []*ServiceError
 		// err holds the original error if exists.
 		err error
+		// violation describes the validation failure that caused this
+		// error, nil if the error is not a validation error.
+		violation *Violation
+	}
+
+	// Violation describes a single validation failure. Validation errors
+	// produced by the generated code retain one violation per failure even
+	// after being merged, see ServiceError.Violations.
+	Violation struct {
+		// Path is the JSON pointer (RFC 6901) to the offending field
+		// relative to the request body, e.g. "/items/0/name". Fields that
+		// are not part of the body (path, query string, headers...) use
+		// their name as single token, e.g. "/limit".
+		Path string `json:"path" xml:"path" form:"path"`
+		// Code is the name of the validation rule that failed, e.g.
+		// "missing_field" or "invalid_pattern".
+		Code string `json:"code" xml:"code" form:"code"`
+		// Message describes the failure.
+		Message string `json:"message" xml:"message" form:"message"`
+		// Params contains the parameters of the validation rule, e.g.
+		// "pattern" for invalid_pattern or "min" and "max" for
+		// invalid_range.
+		Params map[string]any `json:"params,omitempty" xml:"-" form:"params,omitempty"`
+
+		// context and value are the request context (e.g. "body") and
+		// value of the offending field used to localize the message.
+		context string
+		value   any
 	}
 
 	// GoaErrorNamer is an interface implemented by generated error structs that
@@ -56,9 +88,28 @@ const (
 	InvalidRange = "invalid_range"
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = "invalid_length"
+	// UnknownField is the error name for unknown field errors.
+	UnknownField = "unknown_field"
+	// DuplicateField is the error name for duplicate field errors.
+	DuplicateField = "duplicate_field"
 	// UnsupportedMediaType is the error name returned by the Goa decoder
 	// when the content type of the HTTP request body is not supported.
 	UnsupportedMediaType = "unsupported_media_type"
+	// PermissionDenied is the error name returned by the generated code
+	// when a request does not satisfy the authorization policy of the
+	// method.
+	PermissionDenied = "permission_denied"
+	// Unauthorized is the error name used by the transports to encode
+	// requests that do not satisfy any of the security requirements of the
+	// method, see security.AuthError.
+	Unauthorized = "unauthorized"
+	// BulkheadFull is the error name returned by the Bulkhead endpoint
+	// middleware when the endpoint is handling the maximum number of
+	// concurrent requests.
+	BulkheadFull = "bulkhead_full"
+	// CircuitBreakerOpen is the error name returned by the CircuitBreaker
+	// endpoint middleware when the circuit of the endpoint is open.
+	CircuitBreakerOpen = "circuit_open"
 )
 
 // NewServiceError creates an error.
@@ -125,17 +176,23 @@ func UnsupportedMediaTypeError(ct string) error {
 	return PermanentError(UnsupportedMediaType, "unsupported media type %s", ct)
 }
 
+// PermissionDeniedError is the error produced by the generated code when a
+// request does not satisfy the authorization policy of the method.
+func PermissionDeniedError() error {
+	return PermanentError(PermissionDenied, "permission denied")
+}
+
 // InvalidFieldTypeError is the error produced by the generated code when the
 // type of a payload field does not match the type defined in the design.
 func InvalidFieldTypeError(name string, val any, expected string) error {
-	return withField(name, PermanentError(
+	return withViolation(name, "", val, map[string]any{"expected": expected}, PermanentError(
 		InvalidFieldType, "invalid value %#v for %q, must be a %s", val, name, expected))
 }
 
 // MissingFieldError is the error produced by the generated code when a payload
 // is missing a required field.
 func MissingFieldError(name, context string) error {
-	return withField(name, PermanentError(
+	return withViolation(name, context, nil, nil, PermanentError(
 		MissingField, "%q is missing from %s", name, context))
 }
 
@@ -147,7 +204,7 @@ func InvalidEnumValueError(name string, val any, allowed []any) error {
 	for i, a := range allowed {
 		elems[i] = fmt.Sprintf("%#v", a)
 	}
-	return withField(name, PermanentError(
+	return withViolation(name, "", val, map[string]any{"allowed": allowed}, PermanentError(
 		InvalidEnumValue, "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val))
 }
 
@@ -155,7 +212,7 @@ func InvalidEnumValueError(name string, val any, allowed []any) error {
 // of a payload field does not match the format validation defined in the
 // design.
 func InvalidFormatError(name, target string, format Format, formatError error) error {
-	return withField(name, PermanentError(
+	return withViolation(name, "", target, map[string]any{"format": string(format)}, PermanentError(
 		InvalidFormat, "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error()))
 }
 
@@ -163,7 +220,7 @@ func InvalidFormatError(name, target string, format Format, formatError error) e
 // value of a payload field does not match the pattern validation defined in the
 // design.
 func InvalidPatternError(name, target, pattern string) error {
-	return withField(name, PermanentError(
+	return withViolation(name, "", target, map[string]any{"pattern": pattern}, PermanentError(
 		InvalidPattern, "%s must match the regexp %q but got value %q", name, pattern, target))
 }
 
@@ -171,11 +228,11 @@ func InvalidPatternError(name, target, pattern string) error {
 // of a payload field does not match the range validation defined in the design.
 // value may be an int or a float64.
 func InvalidRangeError(name string, target, value any, min bool) error {
-	comp := "greater or equal"
+	comp, param := "greater or equal", "min"
 	if !min {
-		comp = "lesser or equal"
+		comp, param = "lesser or equal", "max"
 	}
-	return withField(name, PermanentError(
+	return withViolation(name, "", target, map[string]any{param: value}, PermanentError(
 		InvalidRange, "%s must be %s than %d but got value %#v", name, comp, value, target))
 }
 
@@ -183,14 +240,29 @@ func InvalidRangeError(name string, target, value any, min bool) error {
 // of a payload field does not match the length validation defined in the
 // design.
 func InvalidLengthError(name string, target any, ln, value int, min bool) error {
-	comp := "greater or equal"
+	comp, param := "greater or equal", "min"
 	if !min {
-		comp = "lesser or equal"
+		comp, param = "lesser or equal", "max"
 	}
-	return withField(name, PermanentError(
+	return withViolation(name, "", target, map[string]any{param: value}, PermanentError(
 		InvalidLength, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln))
 }
 
+// UnknownFieldError is the error produced by the generated code in strict
+// decoding mode when a request contains a field that is not defined in the
+// design.
+func UnknownFieldError(name, context string) error {
+	return withViolation(name, context, nil, nil, PermanentError(
+		UnknownField, "%q is not a known field of %s", name, context))
+}
+
+// DuplicateFieldError is the error produced by the generated code in strict
+// decoding mode when a request defines the same field more than once.
+func DuplicateFieldError(name, context string) error {
+	return withViolation(name, context, nil, nil, PermanentError(
+		DuplicateField, "%q is defined more than once in %s", name, context))
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -213,6 +285,9 @@ func NewErrorID() string {
 //
 // * computes Timeout and Temporary by "and"ing the fields of both errors.
 //
+// * merges the details of both errors, keeping the values of err for keys
+// present in both.
+//
 // Merge returns the updated error. This makes it possible to return other when
 // err is nil.
 func MergeErrors(err, other error) error {
@@ -234,15 +309,69 @@ func MergeErrors(err, other error) error {
 	// Combine error lineage. We only ever put original errors into the history slice, so we
 	// don't need to worry about gaining intermediate merges.
 	//
-	// Do this before we modify ourselves, as History() may include us!
-	e.history = append(e.History(), o.History()...)
+	// Do this before we modify ourselves, as History() may include us! Keep
+	// a copy in this case so that the history retains the original message.
+	hist := e.History()
+	if len(e.history) == 0 {
+		hist = []*ServiceError{e.snapshot()}
+	}
+	e.history = append(hist, o.History()...)
 	e.err = errors.Join(e.err, o.err)
 
 	e.Message = e.Message + "; " + o.Message
 	e.Timeout = e.Timeout && o.Timeout
 	e.Temporary = e.Temporary && o.Temporary
 	e.Fault = e.Fault && o.Fault
+	for k, v := range o.Details {
+		if _, ok := e.Details[k]; !ok {
+			if e.Details == nil {
+				e.Details = make(map[string]any, len(o.Details))
+			}
+			e.Details[k] = v
+		}
+	}
+
+	return e
+}
+
+// WithDetails adds the given key/value pairs to the error details and returns
+// the error so that calls can be chained.
+func (e *ServiceError) WithDetails(details map[string]any) *ServiceError {
+	if len(details) == 0 {
+		return e
+	}
+	if e.Details == nil {
+		e.Details = make(map[string]any, len(details))
+	}
+	for k, v := range details {
+		e.Details[k] = v
+	}
+	return e
+}
 
+// WithViolations records the given validation failures in the error and
+// returns the error so that calls can be chained. It makes it possible for
+// clients to rebuild the violations decoded from an error response, see
+// Violations.
+func (e *ServiceError) WithViolations(vs ...*Violation) *ServiceError {
+	if len(vs) == 0 {
+		return e
+	}
+	if len(e.history) == 0 {
+		// Keep the error itself in the history, see History.
+		e.history = []*ServiceError{e.snapshot()}
+	}
+	for _, v := range vs {
+		e.history = append(e.history, &ServiceError{
+			Name:      v.Code,
+			ID:        e.ID,
+			Message:   v.Message,
+			Timeout:   e.Timeout,
+			Temporary: e.Temporary,
+			Fault:     e.Fault,
+			violation: v,
+		})
+	}
 	return e
 }
 
@@ -255,6 +384,14 @@ func (e *ServiceError) History() []*ServiceError {
 	return []*ServiceError{e}
 }
 
+// snapshot returns a copy of e that does not share its details so that it may
+// be kept in the history of e.
+func (e *ServiceError) snapshot() *ServiceError {
+	cp := *e
+	cp.Details = maps.Clone(e.Details)
+	return &cp
+}
+
 // Error returns the error message.
 func (e *ServiceError) Error() string { return e.Message }
 
@@ -268,8 +405,67 @@ func (e *ServiceError) GoaErrorName() string { return e.ErrorName() }
 
 func (e *ServiceError) Unwrap() error { return e.err }
 
-func withField(field string, err *ServiceError) *ServiceError {
+// Violations returns the validation failures that make up the error, one per
+// validation error merged into it. It returns nil if the error is not a
+// validation error.
+func (e *ServiceError) Violations() []*Violation {
+	var vs []*Violation
+	for _, h := range e.History() {
+		if h.violation != nil {
+			vs = append(vs, h.violation)
+		}
+	}
+	return vs
+}
+
+// FieldPointer returns the JSON pointer (RFC 6901) corresponding to the field
+// name used by the generated validation code, e.g. "body.items[0].name"
+// becomes "/items/0/name". The leading "body" token is removed so that
+// pointers are relative to the request body.
+func FieldPointer(name string) string {
+	var (
+		tokens []string
+		cur    strings.Builder
+	)
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range name {
+		switch r {
+		case '.', '[':
+			flush()
+		case ']':
+			tokens = append(tokens, strings.Trim(cur.String(), `"`))
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	if len(tokens) > 0 && tokens[0] == "body" {
+		tokens = tokens[1:]
+	}
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
+
+func withViolation(field, context string, value any, params map[string]any, err *ServiceError) *ServiceError {
 	err.Field = &field
+	err.violation = &Violation{
+		Path:    FieldPointer(field),
+		Code:    err.Name,
+		Message: err.Message,
+		Params:  params,
+		context: context,
+		value:   value,
+	}
 	return err
 }
 
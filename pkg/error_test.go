@@ -48,3 +48,88 @@ func TestAsError(t *testing.T) {
 		t.Errorf("got %#v, want %#v", se, err)
 	}
 }
+
+func TestServiceErrorViolations(t *testing.T) {
+	err := MergeErrors(MissingFieldError("body.name", "body"), InvalidRangeError("body.age", 200, 150, false))
+	err = MergeErrors(err, PermanentError("other", "not a validation error"))
+	var se *ServiceError
+	if !errors.As(err, &se) {
+		t.Fatalf("got %T, want *ServiceError", err)
+	}
+	vs := se.Violations()
+	if len(vs) != 2 {
+		t.Fatalf("got %d violations, want 2", len(vs))
+	}
+	if vs[0].Path != "/name" || vs[0].Code != MissingField || vs[0].Params != nil {
+		t.Errorf("got %+v, want missing_field violation for /name", vs[0])
+	}
+	if vs[1].Path != "/age" || vs[1].Code != InvalidRange || vs[1].Params["max"] != 150 {
+		t.Errorf("got %+v, want invalid_range violation for /age with max 150", vs[1])
+	}
+	if vs := Fault("boom").Violations(); vs != nil {
+		t.Errorf("got %v, want no violation", vs)
+	}
+}
+
+func TestServiceErrorWithViolations(t *testing.T) {
+	vs := []*Violation{
+		{Path: "/name", Code: MissingField, Message: "\"name\" is missing from body"},
+		{Path: "/age", Code: InvalidRange, Message: "age must be lesser or equal than 150"},
+	}
+	e := PermanentError("invalid", "invalid request").WithViolations(vs...)
+	if got := e.Violations(); len(got) != 2 || got[0] != vs[0] || got[1] != vs[1] {
+		t.Errorf("got violations %v, want %v", got, vs)
+	}
+	if e.Error() != "invalid request" {
+		t.Errorf("got message %q, want %q", e.Error(), "invalid request")
+	}
+	if h := e.History(); len(h) != 3 || h[0].Name != "invalid" || h[0].Message != "invalid request" {
+		t.Errorf("got history %v, want the error followed by the violations", h)
+	}
+	if got := Fault("boom").WithViolations().Violations(); got != nil {
+		t.Errorf("got violations %v, want nil", got)
+	}
+}
+
+func TestServiceErrorDetails(t *testing.T) {
+	e := PermanentError("quota", "quota exceeded").
+		WithDetails(map[string]any{"limit": 10}).
+		WithDetails(map[string]any{"retry_after": 30})
+	if len(e.Details) != 2 || e.Details["limit"] != 10 || e.Details["retry_after"] != 30 {
+		t.Errorf("got details %v, want limit and retry_after", e.Details)
+	}
+	o := PermanentError("other", "other").WithDetails(map[string]any{"limit": 20, "scope": "user"})
+	err := MergeErrors(e, o)
+	var se *ServiceError
+	if !errors.As(err, &se) {
+		t.Fatalf("got %T, want *ServiceError", err)
+	}
+	if se.Details["limit"] != 10 || se.Details["scope"] != "user" {
+		t.Errorf("got details %v, want limit 10 and scope user", se.Details)
+	}
+	if d := se.History()[0].Details; len(d) != 2 || d["scope"] != nil {
+		t.Errorf("got history details %v, want the details before the merge", d)
+	}
+	if d := Fault("boom").WithDetails(nil).Details; d != nil {
+		t.Errorf("got details %v, want nil", d)
+	}
+}
+
+func TestFieldPointer(t *testing.T) {
+	cases := map[string]string{
+		"body":               "",
+		"body.name":          "/name",
+		"body.items[0].name": "/items/0/name",
+		"q[*]":               "/q/*",
+		`m["a/b"]`:           "/m/a~1b",
+		"limit":              "/limit",
+		"target.a~b":         "/target/a~0b",
+	}
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := FieldPointer(name); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package goa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+type (
+	// MessageCatalog contains the localized messages used to describe the
+	// errors returned to clients. Messages are indexed by language tag and
+	// error name (the validation rule code for validation errors, e.g.
+	// "missing_field"). MessageCatalog is safe for concurrent use.
+	MessageCatalog struct {
+		mu       sync.RWMutex
+		tags     []language.Tag
+		messages map[string]map[string]*template.Template
+	}
+
+	// Messages maps error names to message templates. The templates use the
+	// text/template syntax and are executed with a MessageData value, e.g.
+	//
+	//	goa.Messages{
+	//	    goa.MissingField: `le champ {{ printf "%q" .Field }} est requis`,
+	//	    goa.InvalidRange: `{{ .Field }} doit être au plus {{ .Params.max }}`,
+	//	}
+	Messages map[string]string
+
+	// MessageData is the data given to the message templates.
+	MessageData struct {
+		// Name is the error name, e.g. "missing_field".
+		Name string
+		// Message is the original (English) error message.
+		Message string
+		// Field is the name of the offending field as used by the
+		// generated code, e.g. "body.items[0].name", empty if the error
+		// is not a validation error.
+		Field string
+		// Path is the JSON pointer to the offending field, e.g.
+		// "/items/0/name".
+		Path string
+		// Context is the part of the request that contains the field for
+		// missing and unknown field errors, e.g. "body".
+		Context string
+		// Value is the offending value if any.
+		Value any
+		// Params contains the parameters of the validation rule, see
+		// Violation.
+		Params map[string]any
+	}
+
+	// localizedError wraps an error whose ServiceError was localized so
+	// that the original error chain is preserved.
+	localizedError struct {
+		// err is the original error.
+		err error
+		// loc is the localized ServiceError found in err.
+		loc *ServiceError
+	}
+
+	// locale is the value stored in the request context by WithLocale.
+	locale struct {
+		catalog *MessageCatalog
+		lang    string
+	}
+)
+
+// DefaultLanguage is the language of the messages built by the error
+// constructors. It is used when no language requested by the client has
+// messages in the catalog.
+const DefaultLanguage = "en"
+
+// DefaultMessageCatalog is the catalog used by the transport middlewares when
+// none is provided.
+var DefaultMessageCatalog = NewMessageCatalog()
+
+// NewMessageCatalog returns an empty message catalog. Errors are described
+// using the messages built by the error constructors until translations are
+// registered.
+func NewMessageCatalog() *MessageCatalog {
+	return &MessageCatalog{messages: make(map[string]map[string]*template.Template)}
+}
+
+// Register adds the given messages to the catalog for the given language tag,
+// e.g. "fr" or "pt-BR". Messages registered for the same language and error
+// name override previous registrations. Register returns an error if the
+// language tag or one of the templates is invalid.
+func (c *MessageCatalog) Register(lang string, msgs Messages) error {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return fmt.Errorf("invalid language tag %q: %w", lang, err)
+	}
+	key := tag.String()
+	parsed := make(map[string]*template.Template, len(msgs))
+	for name, msg := range msgs {
+		t, err := template.New(name).Option("missingkey=zero").Parse(msg)
+		if err != nil {
+			return fmt.Errorf("invalid %s message for %q: %w", key, name, err)
+		}
+		parsed[name] = t
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ms, ok := c.messages[key]
+	if !ok {
+		ms = make(map[string]*template.Template, len(parsed))
+		c.messages[key] = ms
+		c.tags = append(c.tags, tag)
+	}
+	for name, t := range parsed {
+		ms[name] = t
+	}
+	return nil
+}
+
+// Languages returns the language tags that have messages in the catalog in
+// registration order.
+func (c *MessageCatalog) Languages() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	langs := make([]string, len(c.tags))
+	for i, t := range c.tags {
+		langs[i] = t.String()
+	}
+	return langs
+}
+
+// Negotiate returns the catalog language that best matches the given
+// Accept-Language header value. It returns DefaultLanguage if no catalog
+// language matches.
+func (c *MessageCatalog) Negotiate(acceptLanguage string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.tags) == 0 || acceptLanguage == "" {
+		return DefaultLanguage
+	}
+	desired, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(desired) == 0 {
+		return DefaultLanguage
+	}
+	supported := append([]language.Tag{language.MustParse(DefaultLanguage)}, c.tags...)
+	_, idx, conf := language.NewMatcher(supported).Match(desired...)
+	if conf == language.No || idx == 0 {
+		return DefaultLanguage
+	}
+	return c.tags[idx-1].String()
+}
+
+// Localize returns a copy of err where the messages of the error and of its
+// violations are replaced with the messages registered for the given
+// language. The message of a merged error is rebuilt by joining the
+// messages of the errors it is made of. Messages that have no translation
+// are kept as is. If err wraps the ServiceError the result wraps both err
+// and the localized ServiceError so that errors.As returns the latter.
+// Localize returns err unchanged if it does not contain a ServiceError or if
+// no message is registered for the language.
+func (c *MessageCatalog) Localize(lang string, err error) error {
+	var e *ServiceError
+	if !errors.As(err, &e) {
+		return err
+	}
+	c.mu.RLock()
+	msgs := c.messages[lang]
+	c.mu.RUnlock()
+	if len(msgs) == 0 {
+		return err
+	}
+
+	var (
+		hist    = e.History()
+		loc     = make([]*ServiceError, len(hist))
+		parts   = make([]string, len(hist))
+		changed bool
+	)
+	for i, h := range hist {
+		cp := *h
+		if t, ok := msgs[h.Name]; ok {
+			var buf bytes.Buffer
+			if err := t.Execute(&buf, messageData(h)); err == nil {
+				cp.Message = buf.String()
+				if h.violation != nil {
+					v := *h.violation
+					v.Message = cp.Message
+					cp.violation = &v
+				}
+				changed = true
+			}
+		}
+		loc[i] = &cp
+		parts[i] = cp.Message
+	}
+	if !changed {
+		return err
+	}
+	res := loc[0]
+	if len(e.history) > 0 {
+		cp := *e
+		cp.Message = strings.Join(parts, "; ")
+		cp.history = loc
+		res = &cp
+	}
+	if err == error(e) {
+		return res
+	}
+	return &localizedError{err: err, loc: res}
+}
+
+// WithLocale returns a copy of ctx that holds the given catalog and language.
+// The transport middlewares call WithLocale with the language negotiated from
+// the request so that the errors returned to the client are localized.
+func WithLocale(ctx context.Context, catalog *MessageCatalog, lang string) context.Context {
+	return context.WithValue(ctx, localeKey, &locale{catalog: catalog, lang: lang})
+}
+
+// LanguageFromContext returns the language stored in ctx by WithLocale, empty
+// if there isn't one.
+func LanguageFromContext(ctx context.Context) string {
+	if l, ok := ctx.Value(localeKey).(*locale); ok {
+		return l.lang
+	}
+	return ""
+}
+
+// LocalizeError localizes err using the catalog and language stored in ctx
+// by WithLocale. It returns err unchanged if ctx does not hold a locale. The
+// transport error encoders call LocalizeError prior to encoding errors.
+func LocalizeError(ctx context.Context, err error) error {
+	l, ok := ctx.Value(localeKey).(*locale)
+	if !ok || l.catalog == nil {
+		return err
+	}
+	return l.catalog.Localize(l.lang, err)
+}
+
+// Error returns the message of the original error.
+func (l *localizedError) Error() string { return l.err.Error() }
+
+// Unwrap returns the localized ServiceError followed by the original error.
+func (l *localizedError) Unwrap() []error { return []error{l.loc, l.err} }
+
+// messageData returns the template data for the given original error.
+func messageData(e *ServiceError) *MessageData {
+	data := &MessageData{Name: e.Name, Message: e.Message}
+	if e.Field != nil {
+		data.Field = *e.Field
+	}
+	if v := e.violation; v != nil {
+		data.Path = v.Path
+		data.Context = v.context
+		data.Value = v.value
+		data.Params = v.Params
+	}
+	return data
+}
@@ -0,0 +1,145 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMessageCatalogRegister(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Register("not a tag!", Messages{}); err == nil {
+		t.Error("got no error for invalid language tag")
+	}
+	if err := c.Register("fr", Messages{MissingField: "{{ .Field"}); err == nil {
+		t.Error("got no error for invalid template")
+	}
+	if err := c.Register("pt-br", Messages{MissingField: "{{ .Field }} é obrigatório"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if langs := c.Languages(); len(langs) != 1 || langs[0] != "pt-BR" {
+		t.Errorf("got languages %v, want [pt-BR]", langs)
+	}
+}
+
+func TestMessageCatalogNegotiate(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Register("fr", Messages{MissingField: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register("de", Messages{MissingField: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		accept string
+		want   string
+	}{
+		{"", DefaultLanguage},
+		{"fr", "fr"},
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"ja, de;q=0.5", "de"},
+		{"en-US, de;q=0.5", DefaultLanguage},
+		{"ja", DefaultLanguage},
+		{";;;", DefaultLanguage},
+	}
+	for _, c2 := range cases {
+		if got := c.Negotiate(c2.accept); got != c2.want {
+			t.Errorf("Negotiate(%q): got %q, want %q", c2.accept, got, c2.want)
+		}
+	}
+}
+
+func TestMessageCatalogLocalize(t *testing.T) {
+	c := NewMessageCatalog()
+	err := c.Register("fr", Messages{
+		MissingField: `le champ {{ printf "%q" .Field }} est manquant dans {{ .Context }}`,
+		InvalidRange: `{{ .Field }} doit être au plus {{ .Params.max }} (valeur {{ .Value }})`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := MergeErrors(MissingFieldError("name", "body"), InvalidRangeError("age", 200, 150, false))
+	orig = MergeErrors(orig, InvalidPatternError("code", "x", "^[a-z]{2}$"))
+
+	loc := c.Localize("fr", orig)
+
+	var se *ServiceError
+	if !errors.As(loc, &se) {
+		t.Fatalf("got %T, want *ServiceError", loc)
+	}
+	want := `le champ "name" est manquant dans body; age doit être au plus 150 (valeur 200); code must match the regexp "^[a-z]{2}$" but got value "x"`
+	if se.Message != want {
+		t.Errorf("got message %q, want %q", se.Message, want)
+	}
+	vs := se.Violations()
+	if len(vs) != 3 || vs[0].Message != `le champ "name" est manquant dans body` || vs[1].Code != InvalidRange {
+		t.Errorf("got violations %+v", vs)
+	}
+	if orig.(*ServiceError).Violations()[0].Message != `"name" is missing from body` {
+		t.Error("original error was modified")
+	}
+	if got := c.Localize("de", orig); got != orig {
+		t.Error("got localized error for language without messages")
+	}
+	if got := c.Localize("fr", PermanentError("other", "other")).Error(); got != "other" {
+		t.Errorf("got message %q, want untranslated message", got)
+	}
+}
+
+func TestMessageCatalogLocalizeUntranslatedFirst(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Register("fr", Messages{MissingField: "champ {{ .Field }} requis"}); err != nil {
+		t.Fatal(err)
+	}
+	orig := MergeErrors(InvalidPatternError("a", "x", "^y$"), MissingFieldError("b", "body"))
+
+	var se *ServiceError
+	if !errors.As(c.Localize("fr", orig), &se) {
+		t.Fatal("got no ServiceError")
+	}
+	want := `a must match the regexp "^y$" but got value "x"; champ b requis`
+	if se.Message != want {
+		t.Errorf("got message %q, want %q", se.Message, want)
+	}
+}
+
+func TestMessageCatalogLocalizeWrapped(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Register("fr", Messages{MissingField: "champ {{ .Field }} requis"}); err != nil {
+		t.Fatal(err)
+	}
+	serr := MissingFieldError("b", "body")
+	orig := fmt.Errorf("decoding: %w", serr)
+
+	loc := c.Localize("fr", orig)
+
+	if !errors.Is(loc, serr) {
+		t.Error("got error that does not wrap the original error")
+	}
+	var se *ServiceError
+	if !errors.As(loc, &se) || se.Message != "champ b requis" {
+		t.Errorf("got %v, want localized ServiceError", se)
+	}
+	if loc.Error() != orig.Error() {
+		t.Errorf("got message %q, want %q", loc.Error(), orig.Error())
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	c := NewMessageCatalog()
+	if err := c.Register("fr", Messages{MissingField: "{{ .Field }} manquant"}); err != nil {
+		t.Fatal(err)
+	}
+	err := MissingFieldError("name", "body")
+	if got := LocalizeError(context.Background(), err); got != err {
+		t.Error("got localized error without locale")
+	}
+	ctx := WithLocale(context.Background(), c, "fr")
+	if lang := LanguageFromContext(ctx); lang != "fr" {
+		t.Errorf("got language %q, want fr", lang)
+	}
+	if got := LocalizeError(ctx, err).Error(); got != "name manquant" {
+		t.Errorf("got message %q, want %q", got, "name manquant")
+	}
+}
@@ -0,0 +1,160 @@
+package goa
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// Redacted is the value printed in place of sensitive values.
+const Redacted = "[REDACTED]"
+
+// RedactString returns a representation of v similar to the one produced by
+// the fmt package %+v verb where the values of the given struct fields are
+// replaced with Redacted. v must be a struct or a pointer to a struct,
+// RedactString falls back to the fmt representation otherwise. The code
+// generated for types that define sensitive attributes uses RedactString to
+// implement fmt.Stringer.
+func RedactString(v any, fields ...string) string {
+	rv, ptr, ok := structValue(v)
+	if !ok {
+		return fmt.Sprintf("%+v", v)
+	}
+	if !rv.IsValid() {
+		return "<nil>"
+	}
+	var b strings.Builder
+	if ptr {
+		b.WriteByte('&')
+	}
+	b.WriteByte('{')
+	first := true
+	eachField(rv, func(name string, fv reflect.Value) {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(name)
+		b.WriteByte(':')
+		if contains(fields, name) {
+			b.WriteString(Redacted)
+			return
+		}
+		fmt.Fprintf(&b, "%+v", printable(fv))
+	})
+	b.WriteByte('}')
+	return b.String()
+}
+
+// RedactGoString returns a representation of v similar to the one produced by
+// the fmt package %#v verb where the values of the given struct fields are
+// replaced with Redacted. v must be a struct or a pointer to a struct,
+// RedactGoString falls back to the fmt representation otherwise. The code
+// generated for types that define sensitive attributes uses RedactGoString to
+// implement fmt.GoStringer.
+func RedactGoString(v any, fields ...string) string {
+	rv, ptr, ok := structValue(v)
+	if !ok {
+		return fmt.Sprintf("%#v", v)
+	}
+	if !rv.IsValid() {
+		return fmt.Sprintf("(%T)(nil)", v)
+	}
+	var b strings.Builder
+	if ptr {
+		b.WriteByte('&')
+	}
+	b.WriteString(rv.Type().String())
+	b.WriteByte('{')
+	first := true
+	eachField(rv, func(name string, fv reflect.Value) {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		b.WriteString(name)
+		b.WriteByte(':')
+		if contains(fields, name) {
+			fmt.Fprintf(&b, "%q", Redacted)
+			return
+		}
+		fmt.Fprintf(&b, "%#v", fv.Interface())
+	})
+	b.WriteByte('}')
+	return b.String()
+}
+
+// RedactLogValue returns a slog group value made of the fields of v where the
+// values of the given fields are replaced with Redacted. v must be a struct or
+// a pointer to a struct, RedactLogValue falls back to slog.AnyValue otherwise.
+// The code generated for types that define sensitive attributes uses
+// RedactLogValue to implement slog.LogValuer.
+func RedactLogValue(v any, fields ...string) slog.Value {
+	rv, _, ok := structValue(v)
+	if !ok {
+		return slog.AnyValue(v)
+	}
+	if !rv.IsValid() {
+		return slog.AnyValue(nil)
+	}
+	var attrs []slog.Attr
+	eachField(rv, func(name string, fv reflect.Value) {
+		if contains(fields, name) {
+			attrs = append(attrs, slog.String(name, Redacted))
+			return
+		}
+		attrs = append(attrs, slog.Any(name, printable(fv)))
+	})
+	return slog.GroupValue(attrs...)
+}
+
+// structValue returns the struct value v holds or points to. The returned
+// value is the zero reflect.Value if v is a nil pointer to a struct. ptr is
+// true if v is a pointer and ok is false if v is not a struct or a pointer to
+// a struct.
+func structValue(v any) (rv reflect.Value, ptr bool, ok bool) {
+	rv = reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.Type().Elem().Kind() != reflect.Struct {
+			return rv, false, false
+		}
+		if rv.IsNil() {
+			return reflect.Value{}, true, true
+		}
+		return rv.Elem(), true, true
+	}
+	return rv, false, rv.Kind() == reflect.Struct
+}
+
+// eachField calls fn for each exported field of the struct value rv.
+func eachField(rv reflect.Value, fn func(name string, fv reflect.Value)) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fn(f.Name, rv.Field(i))
+	}
+}
+
+// printable returns the value to print for the given field value: pointers to
+// non struct values are dereferenced so that the value is printed rather than
+// its address.
+func printable(fv reflect.Value) any {
+	if fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Type().Elem().Kind() != reflect.Struct {
+		return fv.Elem().Interface()
+	}
+	return fv.Interface()
+}
+
+// contains returns true if names contains name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
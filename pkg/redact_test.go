@@ -0,0 +1,67 @@
+package goa
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type redactTest struct {
+	Login    string
+	Password *string
+	Age      *int
+}
+
+func TestRedactString(t *testing.T) {
+	var (
+		pass = "secret"
+		age  = 42
+		v    = &redactTest{Login: "joe", Password: &pass, Age: &age}
+	)
+	cases := map[string]struct {
+		v      any
+		fields []string
+		want   string
+	}{
+		"pointer":      {v, []string{"Password"}, "&{Login:joe Password:[REDACTED] Age:42}"},
+		"value":        {*v, []string{"Password"}, "{Login:joe Password:[REDACTED] Age:42}"},
+		"no-redaction": {v, nil, "&{Login:joe Password:secret Age:42}"},
+		"nil":          {(*redactTest)(nil), []string{"Password"}, "<nil>"},
+		"not-a-struct": {"foo", []string{"Password"}, "foo"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := RedactString(tc.v, tc.fields...)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactGoString(t *testing.T) {
+	pass := "secret"
+	got := RedactGoString(&redactTest{Login: "joe", Password: &pass}, "Password")
+	if strings.Contains(got, pass) {
+		t.Errorf("got %q, sensitive value not redacted", got)
+	}
+	want := `&goa.redactTest{Login:"joe", Password:"[REDACTED]", Age:(*int)(nil)}`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRedactLogValue(t *testing.T) {
+	pass := "secret"
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "payload", RedactLogValue(&redactTest{Login: "joe", Password: &pass}, "Password"))
+	got := buf.String()
+	if strings.Contains(got, pass) {
+		t.Errorf("got %q, sensitive value not redacted", got)
+	}
+	if !strings.Contains(got, "payload.Login=joe payload.Password=[REDACTED]") {
+		t.Errorf("got %q, missing redacted attributes", got)
+	}
+}
@@ -0,0 +1,127 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// AuthError is the error returned by the generated endpoints when a
+	// request does not satisfy any of the security requirements of the
+	// method. The requirements are evaluated in the order they are defined
+	// in the design and the first requirement whose schemes all succeed
+	// authorizes the request. The schemes of a requirement are evaluated in
+	// the order they are listed in the Security expression and the
+	// evaluation of a requirement stops at the first scheme that fails.
+	// AuthError records the failure of each requirement so that the
+	// transports may describe all the acceptable schemes to the client and
+	// the servers may log why each scheme failed, see HandleAuthErrors. The
+	// error message does not include the failures so that they are not
+	// disclosed to clients.
+	//
+	// errors.Is and errors.As match the errors returned by the scheme
+	// functions, in evaluation order.
+	AuthError struct {
+		// Schemes lists the security schemes accepted by the method in
+		// evaluation order.
+		Schemes []*SchemeRef
+		// Failures lists the scheme failures in evaluation order.
+		Failures []*SchemeError
+	}
+
+	// SchemeRef identifies a security scheme.
+	SchemeRef struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Type is the scheme type, one of "Basic", "APIKey", "JWT",
+		// "OAuth2", "OpenIDConnect", "MutualTLS", "Signature" or
+		// "Session".
+		Type string
+	}
+
+	// SchemeError describes the failure of a security scheme.
+	SchemeError struct {
+		// Requirement is the index of the security requirement that
+		// lists the scheme.
+		Requirement int
+		// Scheme is the scheme name defined in the design.
+		Scheme string
+		// Type is the scheme type, see SchemeRef.
+		Type string
+		// Err is the error returned by the scheme function.
+		Err error
+	}
+)
+
+// Error returns a generic error message, use Details or LogValue to retrieve
+// the failure of each scheme.
+func (e *AuthError) Error() string {
+	return "unauthorized"
+}
+
+// Details returns a message which lists the failure of each scheme. The
+// message may include sensitive information and should only be logged.
+func (e *AuthError) Details() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// HandleAuthErrors returns an endpoint middleware that calls fn with the
+// AuthError returned by the endpoint if any, e.g. to log why each security
+// scheme failed. The error is returned unchanged.
+func HandleAuthErrors(fn func(ctx context.Context, err *AuthError)) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			res, err := e(ctx, req)
+			var aerr *AuthError
+			if errors.As(err, &aerr) {
+				fn(ctx, aerr)
+			}
+			return res, err
+		}
+	}
+}
+
+// Unwrap returns the errors returned by the scheme functions.
+func (e *AuthError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// LogValue implements slog.LogValuer. The value lists the accepted schemes
+// and the failure of each scheme in evaluation order.
+func (e *AuthError) LogValue() slog.Value {
+	names := make([]string, len(e.Schemes))
+	for i, s := range e.Schemes {
+		names[i] = s.Name
+	}
+	attrs := []slog.Attr{slog.String("schemes", strings.Join(names, ","))}
+	for i, f := range e.Failures {
+		attrs = append(attrs, slog.Group("failure"+strconv.Itoa(i),
+			slog.Int("requirement", f.Requirement),
+			slog.String("scheme", f.Scheme),
+			slog.String("type", f.Type),
+			slog.String("error", f.Err.Error()),
+		))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Error returns the scheme name followed by the error message.
+func (e *SchemeError) Error() string {
+	return e.Scheme + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the scheme function.
+func (e *SchemeError) Unwrap() error { return e.Err }
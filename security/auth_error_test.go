@@ -0,0 +1,65 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestAuthError(t *testing.T) {
+	errExpired := errors.New("token expired")
+	err := &AuthError{
+		Schemes: []*SchemeRef{{Name: "jwt", Type: "JWT"}, {Name: "api_key", Type: "APIKey"}},
+		Failures: []*SchemeError{
+			{Requirement: 0, Scheme: "jwt", Type: "JWT", Err: errExpired},
+			{Requirement: 1, Scheme: "api_key", Type: "APIKey", Err: errors.New("invalid key")},
+		},
+	}
+	if got, expected := err.Error(), "unauthorized"; got != expected {
+		t.Errorf("got message %q, expected %q", got, expected)
+	}
+	if got, expected := err.Details(), "jwt: token expired; api_key: invalid key"; got != expected {
+		t.Errorf("got details %q, expected %q", got, expected)
+	}
+	if !errors.Is(err, errExpired) {
+		t.Error("got errors.Is false, expected the scheme error to be wrapped")
+	}
+	var serr *SchemeError
+	if !errors.As(err, &serr) || serr.Scheme != "jwt" {
+		t.Errorf("got scheme error %v, expected the first failure", serr)
+	}
+	v := err.LogValue()
+	if v.Kind() != slog.KindGroup {
+		t.Fatalf("got log value kind %s, expected group", v.Kind())
+	}
+	attrs := v.Group()
+	if len(attrs) != 3 {
+		t.Fatalf("got %d attributes, expected 3", len(attrs))
+	}
+	if got := attrs[0].Value.String(); got != "jwt,api_key" {
+		t.Errorf("got schemes %q, expected %q", got, "jwt,api_key")
+	}
+	if got := attrs[2].Value.Group()[1].Value.String(); got != "api_key" {
+		t.Errorf("got scheme %q, expected %q", got, "api_key")
+	}
+}
+
+func TestHandleAuthErrors(t *testing.T) {
+	aerr := &AuthError{Failures: []*SchemeError{{Scheme: "jwt", Err: errors.New("token expired")}}}
+	var got *AuthError
+	fn := func(_ context.Context, err *AuthError) { got = err }
+	for _, err := range []error{aerr, errors.New("boom"), nil} {
+		got = nil
+		endpoint := HandleAuthErrors(fn)(func(context.Context, any) (any, error) { return nil, err })
+		if _, e := endpoint(context.Background(), nil); e != err {
+			t.Errorf("got error %v, expected %v", e, err)
+		}
+		if err == aerr && got != aerr {
+			t.Errorf("got %v, expected fn to be called with the auth error", got)
+		}
+		if err != aerr && got != nil {
+			t.Errorf("got %v, expected fn not to be called", got)
+		}
+	}
+}
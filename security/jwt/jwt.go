@@ -0,0 +1,449 @@
+/*
+Package jwt implements the validation of JSON Web Tokens (RFC 7519) for the
+services that use the JWTSecurity scheme. A Validator verifies the token
+signature against static keys or the keys of a JSON Web Key Set (RFC 7517)
+file, checks the registered claims and the scopes required by the scheme, and
+stores the verified claims in the request context:
+
+	v, err := jwt.New(
+	    jwt.WithJWKSFile("keys.json"),
+	    jwt.WithIssuer("https://auth.example.com"),
+	    jwt.WithAudience("calc"),
+	)
+	if err != nil {
+	    return err
+	}
+
+	// JWTAuth implements the service Auther interface.
+	func (s *calcsrvc) JWTAuth(ctx context.Context, token string, scheme *security.JWTScheme) (context.Context, error) {
+	    return s.validator.Auth(ctx, token, scheme)
+	}
+
+The supported algorithms are HS256, HS384, HS512, RS256, RS384, RS512,
+PS256, PS384, PS512, ES256, ES384, ES512 and EdDSA (Ed25519).
+*/
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"goa.design/goa/v3/security"
+)
+
+type (
+	// Validator validates JSON Web Tokens.
+	Validator struct {
+		keys     []*Key
+		issuer   string
+		audience []string
+		leeway   time.Duration
+		now      func() time.Time
+		jwks     string
+	}
+
+	// Key is a key used to verify token signatures.
+	Key struct {
+		// ID is the key identifier matched against the token "kid"
+		// header, empty if the key may be used to verify any token.
+		ID string
+		// Value is the key value, one of []byte (HMAC), *rsa.PublicKey,
+		// *ecdsa.PublicKey or ed25519.PublicKey.
+		Value any
+	}
+
+	// Option configures a Validator.
+	Option func(*Validator)
+
+	// Claims contains the verified claims of a token.
+	Claims map[string]any
+
+	// header is the JOSE header of a token.
+	header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	// private type used to define context keys.
+	contextKey int
+)
+
+const (
+	// claimsKey is the context key used to store the verified claims.
+	claimsKey contextKey = iota + 1
+)
+
+var (
+	// ecdsaCurves maps the ECDSA algorithms to the name of the curve of
+	// their keys.
+	ecdsaCurves = map[string]string{"ES256": "P-256", "ES384": "P-384", "ES512": "P-521"}
+
+	// ErrInvalidToken is the error returned when the token is malformed or
+	// its signature cannot be verified.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is the error returned when the token "exp" claim is
+	// in the past.
+	ErrTokenExpired = errors.New("token is expired")
+	// ErrTokenNotValidYet is the error returned when the token "nbf" claim
+	// is in the future.
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+	// ErrInvalidIssuer is the error returned when the token "iss" claim
+	// does not match the expected issuer.
+	ErrInvalidIssuer = errors.New("invalid token issuer")
+	// ErrInvalidAudience is the error returned when the token "aud" claim
+	// does not contain any of the expected audiences.
+	ErrInvalidAudience = errors.New("invalid token audience")
+	// ErrInsufficientScope is the error returned when the token does not
+	// grant the scopes required by the security scheme.
+	ErrInsufficientScope = errors.New("insufficient token scope")
+)
+
+// New returns a validator configured with the given options. It returns an
+// error if no key is configured or if the JWKS file cannot be loaded.
+func New(opts ...Option) (*Validator, error) {
+	v := &Validator{now: time.Now}
+	for _, o := range opts {
+		o(v)
+	}
+	if v.jwks != "" {
+		keys, err := LoadJWKS(v.jwks)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = append(v.keys, keys...)
+	}
+	if len(v.keys) == 0 {
+		return nil, errors.New("jwt: no verification key")
+	}
+	for _, k := range v.keys {
+		switch k.Value.(type) {
+		case []byte, *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("jwt: unsupported key type %T", k.Value)
+		}
+	}
+	return v, nil
+}
+
+// Lazy returns a function that creates the validator configured with the given
+// options on first use and returns it on subsequent calls. Contrary to
+// sync.OnceValues errors are not cached: the validator creation is retried on
+// the next call after a failure, e.g. if the JWKS file cannot be loaded yet.
+func Lazy(opts ...Option) func() (*Validator, error) {
+	var (
+		mu sync.Mutex
+		v  atomic.Pointer[Validator]
+	)
+	return func() (*Validator, error) {
+		if cur := v.Load(); cur != nil {
+			return cur, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if cur := v.Load(); cur != nil {
+			return cur, nil
+		}
+		nv, err := New(opts...)
+		if err != nil {
+			return nil, err
+		}
+		v.Store(nv)
+		return nv, nil
+	}
+}
+
+// WithKey adds a static verification key. The key value must be a []byte for
+// HMAC algorithms, a *rsa.PublicKey, a *ecdsa.PublicKey or a
+// ed25519.PublicKey. id is matched against the token "kid" header if not
+// empty.
+func WithKey(id string, key any) Option {
+	return func(v *Validator) {
+		v.keys = append(v.keys, &Key{ID: id, Value: key})
+	}
+}
+
+// WithJWKSFile loads the verification keys from the JSON Web Key Set file at
+// the given path.
+func WithJWKSFile(path string) Option {
+	return func(v *Validator) {
+		v.jwks = path
+	}
+}
+
+// WithIssuer sets the expected value of the "iss" claim.
+func WithIssuer(iss string) Option {
+	return func(v *Validator) {
+		v.issuer = iss
+	}
+}
+
+// WithAudience sets the accepted values of the "aud" claim. Tokens must list
+// at least one of the given audiences.
+func WithAudience(aud ...string) Option {
+	return func(v *Validator) {
+		v.audience = append(v.audience, aud...)
+	}
+}
+
+// WithLeeway sets the clock skew tolerated when checking the "exp" and "nbf"
+// claims.
+func WithLeeway(d time.Duration) Option {
+	return func(v *Validator) {
+		v.leeway = d
+	}
+}
+
+// WithClock sets the function used to get the current time, time.Now by
+// default.
+func WithClock(now func() time.Time) Option {
+	return func(v *Validator) {
+		v.now = now
+	}
+}
+
+// Auth validates the token and checks that it grants the scopes required by
+// the scheme. It returns a context that holds the verified claims. Auth can be
+// used to implement the service JWTAuth method.
+func (v *Validator) Auth(ctx context.Context, token string, s *security.JWTScheme) (context.Context, error) {
+	claims, err := v.Parse(strings.TrimPrefix(token, "Bearer "))
+	if err != nil {
+		return ctx, err
+	}
+	if err := s.Validate(claims.Scopes()); err != nil {
+		return ctx, fmt.Errorf("%w: %s", ErrInsufficientScope, err)
+	}
+	return WithClaims(ctx, claims), nil
+}
+
+// Parse verifies the signature of the token and validates the "exp", "nbf",
+// "iss" and "aud" claims. It returns the token claims.
+func (v *Validator) Parse(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %s", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature encoding", ErrInvalidToken)
+	}
+	if err := v.verify(h, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: invalid claims: %s", ErrInvalidToken, err)
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// WithClaims returns a copy of ctx that holds the given claims. The claims are
+// also stored with security.WithClaims so that authorization policies may refer
+// to them.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	ctx = security.WithClaims(ctx, claims)
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// ContextClaims returns the claims stored in ctx by Auth, nil if there
+// isn't any.
+func ContextClaims(ctx context.Context) Claims {
+	c, _ := ctx.Value(claimsKey).(Claims)
+	return c
+}
+
+// Subject returns the value of the "sub" claim.
+func (c Claims) Subject() string {
+	s, _ := c["sub"].(string)
+	return s
+}
+
+// Scopes returns the scopes granted by the token. Scopes are read from the
+// "scope" claim (space separated list as defined by RFC 8693) or from the
+// "scopes" or "scp" claims (list of strings or space separated list).
+func (c Claims) Scopes() []string {
+	for _, n := range []string{"scope", "scopes", "scp"} {
+		if v, ok := c[n]; ok {
+			return stringList(v, true)
+		}
+	}
+	return nil
+}
+
+// verify checks the token signature using the keys that match the token
+// algorithm and key ID.
+func (v *Validator) verify(h header, signed string, sig []byte) error {
+	for _, k := range v.keys {
+		if h.Kid != "" && k.ID != "" && k.ID != h.Kid {
+			continue
+		}
+		ok, err := verifySignature(h.Alg, k.Value, []byte(signed), sig)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signature verification failed", ErrInvalidToken)
+}
+
+// validate checks the registered claims.
+func (v *Validator) validate(c Claims) error {
+	now := v.now()
+	if exp, ok, err := numericDate(c, "exp"); err != nil {
+		return err
+	} else if ok && !now.Before(exp.Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok, err := numericDate(c, "nbf"); err != nil {
+		return err
+	} else if ok && now.Add(v.leeway).Before(nbf) {
+		return ErrTokenNotValidYet
+	}
+	if v.issuer != "" {
+		if iss, _ := c["iss"].(string); iss != v.issuer {
+			return fmt.Errorf("%w: %q", ErrInvalidIssuer, iss)
+		}
+	}
+	if len(v.audience) > 0 {
+		found := false
+		for _, aud := range stringList(c["aud"], false) {
+			for _, a := range v.audience {
+				if aud == a {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return ErrInvalidAudience
+		}
+	}
+	return nil
+}
+
+// verifySignature verifies sig using the given algorithm and key. It returns
+// false if the key cannot be used with the algorithm.
+func verifySignature(alg string, key any, signed, sig []byte) (bool, error) {
+	if alg == "EdDSA" {
+		k, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(k, signed, sig), nil
+	}
+	var hash crypto.Hash
+	if len(alg) == 5 {
+		switch alg[2:] {
+		case "256":
+			hash = crypto.SHA256
+		case "384":
+			hash = crypto.SHA384
+		case "512":
+			hash = crypto.SHA512
+		}
+	}
+	if hash == 0 {
+		return false, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+	h := hash.New()
+	h.Write(signed) // nolint: errcheck
+	digest := h.Sum(nil)
+	switch alg[:2] {
+	case "HS":
+		k, ok := key.([]byte)
+		if !ok {
+			return false, nil
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write(signed) // nolint: errcheck
+		return hmac.Equal(sig, mac.Sum(nil)), nil
+	case "RS":
+		k, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil, nil
+	case "PS":
+		k, ok := key.(*rsa.PublicKey)
+		opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+		return ok && rsa.VerifyPSS(k, hash, digest, sig, opts) == nil, nil
+	case "ES":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok || k.Curve.Params().Name != ecdsaCurves[alg] {
+			// RFC 7518 section 3.4 ties each algorithm to a curve.
+			return false, nil
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false, nil
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s), nil
+	}
+	return false, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+}
+
+// decodeSegment decodes a base64url encoded JSON token segment into v.
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// numericDate returns the time corresponding to the given NumericDate claim.
+func numericDate(c Claims, name string) (time.Time, bool, error) {
+	v, ok := c[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%w: %q claim must be a number", ErrInvalidToken, name)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: invalid %q claim", ErrInvalidToken, name)
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*float64(time.Second))), true, nil
+}
+
+// stringList returns the strings contained in v which may be a string or a
+// list of strings. Strings are split on spaces if split is true.
+func stringList(v any, split bool) []string {
+	switch actual := v.(type) {
+	case string:
+		if split {
+			return strings.Fields(actual)
+		}
+		return []string{actual}
+	case []any:
+		res := make([]string, 0, len(actual))
+		for _, e := range actual {
+			if s, ok := e.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/security"
+)
+
+var now = time.Unix(1700000000, 0)
+
+func TestParse(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	secret := []byte("secret")
+	v, err := New(
+		WithKey("hs", secret),
+		WithKey("rs", &rsaKey.PublicKey),
+		WithKey("es", &ecKey.PublicKey),
+		WithKey("ed", edPub),
+		WithIssuer("issuer"),
+		WithAudience("api"),
+		WithLeeway(time.Minute),
+		WithClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := map[string]any{"iss": "issuer", "aud": []string{"other", "api"}, "exp": now.Add(time.Hour).Unix(), "sub": "alice"}
+	with := func(k string, val any) map[string]any {
+		c := make(map[string]any, len(valid))
+		for n, v := range valid {
+			c[n] = v
+		}
+		c[k] = val
+		return c
+	}
+	cases := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"HS256", sign(t, "HS256", "hs", secret, valid), nil},
+		{"HS512", sign(t, "HS512", "", secret, valid), nil},
+		{"RS256", sign(t, "RS256", "rs", rsaKey, valid), nil},
+		{"PS384", sign(t, "PS384", "rs", rsaKey, valid), nil},
+		{"ES256", sign(t, "ES256", "es", ecKey, valid), nil},
+		{"ES384 with P-256 key", sign(t, "ES384", "es", ecKey, valid), ErrInvalidToken},
+		{"EdDSA", sign(t, "EdDSA", "ed", edKey, valid), nil},
+		{"wrong key", sign(t, "HS256", "hs", []byte("other"), valid), ErrInvalidToken},
+		{"kid mismatch", sign(t, "RS256", "es", rsaKey, valid), ErrInvalidToken},
+		{"none", unsigned(valid), ErrInvalidToken},
+		{"malformed", "abc", ErrInvalidToken},
+		{"expired", sign(t, "HS256", "hs", secret, with("exp", now.Add(-2*time.Minute).Unix())), ErrTokenExpired},
+		{"expired within leeway", sign(t, "HS256", "hs", secret, with("exp", now.Add(-30*time.Second).Unix())), nil},
+		{"not valid yet", sign(t, "HS256", "hs", secret, with("nbf", now.Add(2*time.Minute).Unix())), ErrTokenNotValidYet},
+		{"issuer", sign(t, "HS256", "hs", secret, with("iss", "other")), ErrInvalidIssuer},
+		{"audience", sign(t, "HS256", "hs", secret, with("aud", "other")), ErrInvalidAudience},
+		{"string audience", sign(t, "HS256", "hs", secret, with("aud", "api")), nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			claims, err := v.Parse(c.token)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got error %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims.Subject() != "alice" {
+				t.Errorf("got subject %q, want alice", claims.Subject())
+			}
+		})
+	}
+}
+
+func TestAuth(t *testing.T) {
+	secret := []byte("secret")
+	v, err := New(WithKey("", secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme := &security.JWTScheme{Name: "jwt", RequiredScopes: []string{"api:read", "api:write"}}
+
+	token := sign(t, "HS256", "", secret, map[string]any{"sub": "bob", "scope": "api:read api:write"})
+	ctx, err := v.Auth(context.Background(), token, scheme)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if claims := ContextClaims(ctx); claims.Subject() != "bob" {
+		t.Errorf("got claims %v, want subject bob", claims)
+	}
+
+	token = sign(t, "HS256", "", secret, map[string]any{"scopes": []string{"api:read"}})
+	if _, err := v.Auth(context.Background(), token, scheme); !errors.Is(err, ErrInsufficientScope) {
+		t.Errorf("got error %v, want %v", err, ErrInsufficientScope)
+	}
+}
+
+func TestJWKSFile(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	b64 := base64.RawURLEncoding.EncodeToString
+	set := map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rs", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64([]byte{1, 0, 1})},
+		{"kty": "EC", "kid": "es", "crv": "P-384", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(edPub)},
+		{"kty": "oct", "kid": "hs", "k": b64([]byte("secret"))},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "", "e": ""},
+	}}
+	b, _ := json.Marshal(set)
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := New(WithJWKSFile(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(v.keys) != 4 {
+		t.Fatalf("got %d keys, want 4", len(v.keys))
+	}
+	for _, token := range []string{
+		sign(t, "RS512", "rs", rsaKey, nil),
+		sign(t, "ES384", "es", ecKey, nil),
+		sign(t, "EdDSA", "ed", edKey, nil),
+		sign(t, "HS384", "hs", []byte("secret"), nil),
+	} {
+		if _, err := v.Parse(token); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if _, err := New(WithJWKSFile(filepath.Join(t.TempDir(), "missing.json"))); err == nil {
+		t.Error("got no error for missing JWKS file")
+	}
+}
+
+func TestLazy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	get := Lazy(WithJWKSFile(path))
+	if _, err := get(); err == nil {
+		t.Fatal("got no error for missing JWKS file")
+	}
+	b, _ := json.Marshal(map[string]any{"keys": []map[string]any{
+		{"kty": "oct", "kid": "hs", "k": base64.RawURLEncoding.EncodeToString([]byte("secret"))},
+	}})
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := get()
+	if err != nil {
+		t.Fatalf("got error %v after JWKS file was created", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if v2, err := get(); err != nil || v2 != v {
+		t.Errorf("got %v, %v, want cached validator", v2, err)
+	}
+}
+
+// sign creates a token signed with the given algorithm and private key.
+func sign(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	t.Helper()
+	h := map[string]any{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		h["kid"] = kid
+	}
+	signed := segment(h) + "." + segment(claims)
+	var hash crypto.Hash
+	switch alg[len(alg)-3:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	}
+	var (
+		sig []byte
+		err error
+	)
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(signed))
+		if alg[:2] == "PS" {
+			sig, err = rsa.SignPSS(rand.Reader, k, hash, h.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, h.Sum(nil))
+		}
+	case *ecdsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(signed))
+		r, s, serr := ecdsa.Sign(rand.Reader, k, h.Sum(nil))
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		err = serr
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(signed))
+	default:
+		err = fmt.Errorf("unsupported key %T", key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// unsigned creates an unsecured token (alg "none").
+func unsigned(claims map[string]any) string {
+	return segment(map[string]any{"alg": "none"}) + "." + segment(claims) + "."
+}
+
+func segment(v any) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
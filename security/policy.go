@@ -0,0 +1,58 @@
+package security
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security/policy"
+)
+
+type (
+	// Policy is a compiled authorization policy, see policy.Policy.
+	Policy = policy.Policy
+
+	// AuthorizeFunc is the function type that evaluates the authorization
+	// policy of a method. payload holds the values of the payload
+	// attributes referenced by the policy indexed by attribute name.
+	AuthorizeFunc func(ctx context.Context, policy *Policy, payload map[string]any) error
+
+	// claimsKey is the context key used to store the authenticated
+	// principal claims.
+	claimsKey struct{}
+)
+
+// ParsePolicy compiles the given authorization policy expression, see
+// policy.Parse.
+func ParsePolicy(expr string) (*Policy, error) {
+	return policy.Parse(expr)
+}
+
+// MustParsePolicy is like ParsePolicy but panics if the expression cannot be
+// parsed. The generated code uses MustParsePolicy to compile the policies
+// validated at design time.
+func MustParsePolicy(expr string) *Policy {
+	return policy.MustParse(expr)
+}
+
+// Authorize is the default AuthorizeFunc. It evaluates the policy against the
+// claims stored in ctx with WithClaims and returns a goa permission denied
+// error if the policy is not satisfied.
+func Authorize(ctx context.Context, policy *Policy, payload map[string]any) error {
+	if !policy.Eval(ContextClaims(ctx), payload) {
+		return goa.PermissionDeniedError()
+	}
+	return nil
+}
+
+// WithClaims returns a copy of ctx that holds the claims of the authenticated
+// principal. Auth functions should call WithClaims so that the authorization
+// policies may refer to the claims.
+func WithClaims(ctx context.Context, claims map[string]any) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ContextClaims returns the claims stored in ctx, nil if there aren't any.
+func ContextClaims(ctx context.Context) map[string]any {
+	claims, _ := ctx.Value(claimsKey{}).(map[string]any)
+	return claims
+}
@@ -0,0 +1,478 @@
+/*
+Package policy implements the authorization policies attached to methods with
+the Policy DSL. Policies are parsed and validated by the design packages and
+evaluated by the generated endpoints at runtime, see security.Authorize.
+*/
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Policy is a compiled authorization policy. Policies are boolean
+	// expressions evaluated against the claims of the authenticated
+	// principal and the request payload. The grammar is:
+	//
+	//	expr       = term { ("or" | "||") term }
+	//	term       = factor { ("and" | "&&") factor }
+	//	factor     = ("not" | "!") factor | "(" expr ")" | comparison
+	//	comparison = operand [ ("==" | "!=") operand ]
+	//	operand    = "payload." name | "auth." path | name ":" value
+	//	           | string | number | "true" | "false" | "null"
+	//
+	// "payload.name" is the value of the top-level payload attribute
+	// "name", "auth.path" is the value of the claim at the given
+	// dot-separated path and "name:value" is true if the "name" claim is
+	// "value" or contains "value" when it is a list or a space separated
+	// string (e.g. "role:admin" or "scope:api:write"). Operands that are
+	// not compared are true unless they are false, null, zero or empty.
+	// Missing values are not equal to any value, including other missing
+	// values, compare them with null to test for their absence. Numbers
+	// are compared exactly regardless of their type.
+	//
+	// Example:
+	//
+	//	role:admin or (role:owner and payload.account_id == auth.account_id)
+	Policy struct {
+		expr string
+		root policyNode
+	}
+
+	// policyNode is a node of a policy expression tree.
+	policyNode interface {
+		eval(claims, payload map[string]any) any
+	}
+
+	// policyOp is a boolean operation node.
+	policyOp struct {
+		op          string
+		left, right policyNode
+	}
+
+	// policyNot negates its operand.
+	policyNot struct {
+		x policyNode
+	}
+
+	// policyCompare compares two operands.
+	policyCompare struct {
+		equal       bool
+		left, right policyNode
+	}
+
+	// policyClaim checks that a claim contains a value.
+	policyClaim struct {
+		name, value string
+	}
+
+	// policyRef refers to a payload attribute or a claim.
+	policyRef struct {
+		payload bool
+		path    []string
+	}
+
+	// policyLiteral is a literal value.
+	policyLiteral struct {
+		value any
+	}
+
+	// policyParser parses policy expressions.
+	policyParser struct {
+		tokens []string
+		pos    int
+	}
+)
+
+// Parse compiles the given authorization policy expression.
+func Parse(expr string) (*Policy, error) {
+	tokens, err := tokenizePolicy(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty policy")
+	}
+	p := &policyParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in policy %q", p.tokens[p.pos], expr)
+	}
+	return &Policy{expr: expr, root: root}, nil
+}
+
+// MustParse is like Parse but panics if the expression cannot be parsed.
+func MustParse(expr string) *Policy {
+	p, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the policy expression.
+func (p *Policy) String() string {
+	return p.expr
+}
+
+// Eval returns true if the given claims and payload attribute values satisfy
+// the policy.
+func (p *Policy) Eval(claims, payload map[string]any) bool {
+	return truthy(p.root.eval(claims, payload))
+}
+
+// PayloadAttributes returns the sorted names of the payload attributes
+// referenced by the policy.
+func (p *Policy) PayloadAttributes() []string {
+	seen := make(map[string]struct{})
+	var walk func(n policyNode)
+	walk = func(n policyNode) {
+		switch n := n.(type) {
+		case *policyOp:
+			walk(n.left)
+			walk(n.right)
+		case *policyNot:
+			walk(n.x)
+		case *policyCompare:
+			walk(n.left)
+			walk(n.right)
+		case *policyRef:
+			if n.payload {
+				seen[strings.Join(n.path, ".")] = struct{}{}
+			}
+		}
+	}
+	walk(p.root)
+	attrs := make([]string, 0, len(seen))
+	for a := range seen {
+		attrs = append(attrs, a)
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+func (n *policyOp) eval(claims, payload map[string]any) any {
+	if n.op == "and" {
+		return truthy(n.left.eval(claims, payload)) && truthy(n.right.eval(claims, payload))
+	}
+	return truthy(n.left.eval(claims, payload)) || truthy(n.right.eval(claims, payload))
+}
+
+func (n *policyNot) eval(claims, payload map[string]any) any {
+	return !truthy(n.x.eval(claims, payload))
+}
+
+func (n *policyCompare) eval(claims, payload map[string]any) any {
+	left, right := deref(n.left.eval(claims, payload)), deref(n.right.eval(claims, payload))
+	if isNull(n.left) || isNull(n.right) {
+		// Explicit comparison with null tests for missing values.
+		return (left == nil && right == nil) == n.equal
+	}
+	return equal(left, right) == n.equal
+}
+
+func (n *policyClaim) eval(claims, _ map[string]any) any {
+	switch v := deref(claims[n.name]).(type) {
+	case string:
+		for _, s := range strings.Fields(v) {
+			if s == n.value {
+				return true
+			}
+		}
+		return false
+	case nil:
+		return false
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return equal(v, n.value)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if equal(rv.Index(i).Interface(), n.value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (n *policyRef) eval(claims, payload map[string]any) any {
+	var v any = claims
+	if n.payload {
+		v = payload
+	}
+	for _, key := range n.path {
+		m, ok := deref(v).(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return deref(v)
+}
+
+func (n *policyLiteral) eval(_, _ map[string]any) any {
+	return n.value
+}
+
+func (p *policyParser) parseOr() (policyNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or", "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &policyOp{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *policyParser) parseAnd() (policyNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and", "&&") {
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &policyOp{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *policyParser) parseFactor() (policyNode, error) {
+	if p.accept("not", "!") {
+		x, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &policyNot{x: x}, nil
+	}
+	if p.accept("(") {
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return x, nil
+	}
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.accept("=="):
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &policyCompare{equal: true, left: left, right: right}, nil
+	case p.accept("!="):
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &policyCompare{left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *policyParser) parseOperand() (policyNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of policy")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case tok == "true":
+		return &policyLiteral{value: true}, nil
+	case tok == "false":
+		return &policyLiteral{value: false}, nil
+	case tok == "null":
+		return &policyLiteral{value: nil}, nil
+	case tok[0] == '"' || tok[0] == '\'':
+		return &policyLiteral{value: tok[1 : len(tok)-1]}, nil
+	case strings.HasPrefix(tok, "payload."):
+		path := strings.Split(strings.TrimPrefix(tok, "payload."), ".")
+		if len(path) != 1 || path[0] == "" {
+			return nil, fmt.Errorf("invalid payload reference %q, policies may only refer to top-level payload attributes", tok)
+		}
+		return &policyRef{payload: true, path: path}, nil
+	case strings.HasPrefix(tok, "auth."):
+		path := strings.Split(strings.TrimPrefix(tok, "auth."), ".")
+		for _, k := range path {
+			if k == "" {
+				return nil, fmt.Errorf("invalid claim reference %q", tok)
+			}
+		}
+		return &policyRef{path: path}, nil
+	case strings.Contains(tok, ":"):
+		name, value, _ := strings.Cut(tok, ":")
+		if name == "" || value == "" {
+			return nil, fmt.Errorf("invalid claim check %q, must be of the form name:value", tok)
+		}
+		return &policyClaim{name: name, value: value}, nil
+	}
+	if i, err := strconv.ParseInt(tok, 10, 64); err == nil {
+		return &policyLiteral{value: i}, nil
+	}
+	if f, err := strconv.ParseFloat(tok, 64); err == nil {
+		return &policyLiteral{value: f}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q, operands must be payload or auth references, claim checks or literals", tok)
+}
+
+// accept consumes the next token if it is one of the given tokens.
+func (p *policyParser) accept(toks ...string) bool {
+	if p.pos >= len(p.tokens) {
+		return false
+	}
+	for _, t := range toks {
+		if p.tokens[p.pos] == t {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizePolicy splits the policy expression into tokens.
+func tokenizePolicy(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case c == '!':
+			tokens = append(tokens, "!")
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in policy %q", expr)
+			}
+			tokens = append(tokens, expr[i:i+end+2])
+			i += end + 2
+		default:
+			start := i
+			for i < len(expr) && strings.IndexByte(" \t\r\n()=!&|\"'", expr[i]) < 0 {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected %q in policy %q", expr[i], expr)
+			}
+			tokens = append(tokens, expr[start:i])
+		}
+	}
+	return tokens, nil
+}
+
+// truthy returns false if v is false, nil, a zero number or an empty string,
+// slice or map.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if r, ok := number(v); ok {
+		return r.Sign() != 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() > 0
+	}
+	return true
+}
+
+// equal compares two operand values, numbers are compared exactly by value
+// regardless of their type. nil values are never equal so that missing
+// operands cannot satisfy a comparison.
+func equal(a, b any) bool {
+	a, b = deref(a), deref(b)
+	if a == nil || b == nil {
+		return false
+	}
+	if ra, ok := number(a); ok {
+		rb, ok := number(b)
+		return ok && ra.Cmp(rb) == 0
+	}
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if !ta.Comparable() || !tb.Comparable() {
+		return false
+	}
+	if ta.Kind() == reflect.String && tb.Kind() == reflect.String {
+		return reflect.ValueOf(a).String() == reflect.ValueOf(b).String()
+	}
+	return a == b
+}
+
+// number returns the exact value of v if it is a number. v may be of any
+// integer or float type or a json.Number as produced by decoders configured
+// with UseNumber.
+func number(v any) (*big.Rat, bool) {
+	if n, ok := v.(json.Number); ok {
+		return new(big.Rat).SetString(n.String())
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		r, ok := new(big.Rat).SetString(strconv.FormatFloat(rv.Float(), 'g', -1, 64))
+		return r, ok
+	}
+	return nil, false
+}
+
+// isNull returns true if n is the null literal.
+func isNull(n policyNode) bool {
+	l, ok := n.(*policyLiteral)
+	return ok && l.value == nil
+}
+
+// deref returns the value pointed to by v if v is a pointer, nil if it is a
+// nil pointer.
+func deref(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyEval(t *testing.T) {
+	accountID := "acme"
+	var (
+		admin   = map[string]any{"role": "admin"}
+		owner   = map[string]any{"role": []any{"owner", "viewer"}, "account_id": "acme"}
+		scoped  = map[string]any{"scope": "api:read api:write", "org": map[string]any{"tier": "gold"}}
+		payload = map[string]any{"account_id": &accountID, "count": 3, "big": int64(1<<53 + 1)}
+		numbers = map[string]any{"count": json.Number("3"), "big": json.Number("9007199254740992"), "ratio": json.Number("0.5")}
+	)
+	cases := []struct {
+		Name     string
+		Policy   string
+		Claims   map[string]any
+		Expected bool
+	}{
+		{"claim", "role:admin", admin, true},
+		{"claim-mismatch", "role:admin", owner, false},
+		{"claim-list", "role:viewer", owner, true},
+		{"claim-space-separated", "scope:api:write", scoped, true},
+		{"claim-missing", "role:admin", nil, false},
+		{"or", "role:admin or role:owner", owner, true},
+		{"and", "role:owner && role:admin", owner, false},
+		{"not", "not role:admin", owner, true},
+		{"bang", "!role:owner", owner, false},
+		{"parens", "role:admin or (role:owner and payload.account_id == auth.account_id)", owner, true},
+		{"parens-mismatch", "role:admin or (role:owner and payload.account_id == auth.account_id)", map[string]any{"role": "owner", "account_id": "other"}, false},
+		{"not-equal", "payload.account_id != auth.account_id", owner, false},
+		{"nested-claim", `auth.org.tier == "gold"`, scoped, true},
+		{"number", "payload.count == 3", nil, true},
+		{"truthy", "auth.org", scoped, true},
+		{"falsy", "auth.org", admin, false},
+		{"null", "auth.account_id == null", admin, true},
+		{"not-null", "auth.role != null", admin, true},
+		{"missing-operands", "payload.missing == auth.missing", admin, false},
+		{"missing-operand", "payload.account_id == auth.account_id", admin, false},
+		{"json-number", "payload.count == auth.count", numbers, true},
+		{"json-number-float", "auth.ratio == 0.5", numbers, true},
+		{"json-number-truthy", "auth.count", numbers, true},
+		{"large-integer", "payload.big == 9007199254740993", nil, true},
+		{"large-integer-mismatch", "payload.big == auth.big", numbers, false},
+		{"large-integer-literal-mismatch", "payload.big == 9007199254740992", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p, err := Parse(c.Policy)
+			if err != nil {
+				t.Fatalf("failed to parse policy: %s", err)
+			}
+			if got := p.Eval(c.Claims, payload); got != c.Expected {
+				t.Errorf("got %t, expected %t", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Policy     string
+		Attributes []string
+		Error      bool
+	}{
+		{"valid", "role:admin or (payload.b == auth.id and payload.a != 1)", []string{"a", "b"}, false},
+		{"empty", "", nil, true},
+		{"unbalanced", "(role:admin", nil, true},
+		{"trailing", "role:admin role:owner", nil, true},
+		{"nested-payload", "payload.a.b == 1", nil, true},
+		{"invalid-claim", "role: == 1", nil, true},
+		{"unknown-operand", "admin", nil, true},
+		{"unterminated-string", `auth.id == "foo`, nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			p, err := Parse(c.Policy)
+			if c.Error {
+				if err == nil {
+					t.Error("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %q, expected none", err)
+			}
+			if got := p.PayloadAttributes(); !reflect.DeepEqual(got, c.Attributes) {
+				t.Errorf("got attributes %v, expected %v", got, c.Attributes)
+			}
+		})
+	}
+}
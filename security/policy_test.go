@@ -0,0 +1,22 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestAuthorize(t *testing.T) {
+	p := MustParsePolicy("role:admin")
+	ctx := WithClaims(context.Background(), map[string]any{"role": "admin"})
+	if err := Authorize(ctx, p, nil); err != nil {
+		t.Errorf("got error %q, expected none", err)
+	}
+	err := Authorize(context.Background(), p, nil)
+	var gerr *goa.ServiceError
+	if !errors.As(err, &gerr) || gerr.Name != goa.PermissionDenied {
+		t.Errorf("got error %v, expected %q", err, goa.PermissionDenied)
+	}
+}
@@ -6,11 +6,16 @@ secure goa endpoint. It supports the following security schemes:
   - API key security using keys.
   - JWT security using JWT tokens.
   - OAuth2 security using OAuth2 tokens.
+  - OpenID Connect security using tokens issued by an OpenID Connect provider.
+  - Mutual TLS security using client certificates.
+  - Request signature security using HMAC signatures.
+  - Cookie session security with optional CSRF protection.
 */
 package security
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"strings"
 )
@@ -65,6 +70,33 @@ type (
 		Flows []*OAuthFlow
 	}
 
+	// OpenIDConnectScheme represents the OpenID Connect security scheme.
+	OpenIDConnectScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+		// DiscoveryURL is the URL of the OpenID Connect discovery
+		// document of the provider.
+		DiscoveryURL string
+	}
+
+	// MutualTLSScheme represents the mutual TLS security scheme.
+	// It consists of the client certificate chain verified during the
+	// TLS handshake.
+	MutualTLSScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+	}
+
 	// OAuthFlow represents the OAuth2 flow defined by the scheme.
 	OAuthFlow struct {
 		// Type is the type of grant.
@@ -92,8 +124,36 @@ type (
 	// AuthJWTFunc is the function type that implements the JWT
 	// scheme of using a JWT token.
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// AuthOpenIDConnectFunc is the function type that implements the
+	// OpenID Connect scheme of using a token issued by the provider.
+	AuthOpenIDConnectFunc func(ctx context.Context, token string, s *OpenIDConnectScheme) (context.Context, error)
+
+	// AuthMutualTLSFunc is the function type that implements the mutual
+	// TLS scheme of using a client certificate. chain is the verified
+	// client certificate chain starting with the client certificate, it is
+	// empty if the client did not present a valid certificate.
+	AuthMutualTLSFunc func(ctx context.Context, chain []*x509.Certificate, s *MutualTLSScheme) (context.Context, error)
 )
 
+// clientCertificatesKey is the context key used to store the verified client
+// certificate chain.
+type clientCertificatesKey struct{}
+
+// WithClientCertificates returns a copy of ctx that holds the given verified
+// client certificate chain. The transports call WithClientCertificates so that
+// the endpoints secured with mutual TLS may retrieve the chain.
+func WithClientCertificates(ctx context.Context, chain []*x509.Certificate) context.Context {
+	return context.WithValue(ctx, clientCertificatesKey{}, chain)
+}
+
+// ContextClientCertificates returns the verified client certificate chain
+// stored in ctx, nil if there isn't one.
+func ContextClientCertificates(ctx context.Context) []*x509.Certificate {
+	chain, _ := ctx.Value(clientCertificatesKey{}).([]*x509.Certificate)
+	return chain
+}
+
 // Validate returns a non-nil error if scopes does not contain all of
 // Basic scheme's required scopes.
 func (s *BasicScheme) Validate(scopes []string) error {
@@ -118,6 +178,18 @@ func (s *JWTScheme) Validate(scopes []string) error {
 	return validateScopes(s.RequiredScopes, scopes)
 }
 
+// Validate returns a non-nil error if scopes does not contain all of
+// OpenID Connect scheme's required scopes.
+func (s *OpenIDConnectScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes)
+}
+
+// Validate returns a non-nil error if scopes does not contain all of
+// mutual TLS scheme's required scopes.
+func (s *MutualTLSScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes)
+}
+
 func validateScopes(expected, actual []string) error {
 	var missing []string
 	for _, r := range expected {
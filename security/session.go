@@ -0,0 +1,161 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// CSRFDoubleSubmit is the CSRF protection mode where clients echo the
+	// value of a CSRF cookie set by the server in a request header.
+	CSRFDoubleSubmit = "double-submit"
+	// CSRFSynchronizerToken is the CSRF protection mode where clients send
+	// a token stored server side in the session in a request header.
+	CSRFSynchronizerToken = "synchronizer-token"
+
+	// DefaultCSRFHeader is the name of the header that holds the CSRF
+	// token when the scheme does not define one.
+	DefaultCSRFHeader = "X-CSRF-Token"
+	// DefaultCSRFCookie is the name of the cookie that holds the CSRF
+	// token in double-submit mode when the scheme does not define one.
+	DefaultCSRFCookie = "csrf_token"
+)
+
+var (
+	// ErrMissingCSRFToken is the error returned by VerifyCSRF when a
+	// request made with an unsafe method does not include a CSRF token.
+	ErrMissingCSRFToken = errors.New("missing CSRF token")
+	// ErrInvalidCSRFToken is the error returned by VerifyCSRF when the
+	// CSRF token does not match the expected value.
+	ErrInvalidCSRFToken = errors.New("invalid CSRF token")
+)
+
+type (
+	// SessionScheme represents the cookie session security scheme. It
+	// consists of a session ID sent by browsers in a cookie optionally
+	// combined with CSRF protection for requests made with unsafe methods.
+	SessionScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+		// Cookie is the name of the session cookie.
+		Cookie string
+		// CSRF is the CSRF protection mode, one of CSRFDoubleSubmit or
+		// CSRFSynchronizerToken. CSRF protection is disabled if empty.
+		CSRF string
+	}
+
+	// CSRF holds the CSRF data read by the transport from the request.
+	CSRF struct {
+		// Token is the CSRF token sent by the client in the request
+		// header.
+		Token string
+		// Cookie is the value of the CSRF cookie in double-submit mode.
+		Cookie string
+		// Safe is true if the request method is safe (GET, HEAD,
+		// OPTIONS or TRACE) in which case the request is not checked.
+		Safe bool
+	}
+
+	// AuthSessionFunc is the function type that implements the cookie
+	// session scheme. sessionID is the value of the session cookie.
+	// Implementations of schemes that use the synchronizer token CSRF
+	// protection mode must store the token associated with the session
+	// in the returned context with WithCSRFToken.
+	AuthSessionFunc func(ctx context.Context, sessionID string, s *SessionScheme) (context.Context, error)
+
+	// csrfKey is the context key used to store the request CSRF data.
+	csrfKey struct{}
+
+	// csrfTokenKey is the context key used to store the session CSRF
+	// token.
+	csrfTokenKey struct{}
+)
+
+// Validate returns a non-nil error if scopes does not contain all of
+// session scheme's required scopes.
+func (s *SessionScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes)
+}
+
+// VerifyCSRF checks the CSRF token of the request if the scheme enables CSRF
+// protection and the request method is unsafe. In double-submit mode the token
+// sent in the request header must match the value of the CSRF cookie. In
+// synchronizer token mode it must match the token stored in ctx with
+// WithCSRFToken. VerifyCSRF returns a "permission_denied" error that wraps
+// ErrMissingCSRFToken or ErrInvalidCSRFToken if the check fails.
+//
+// The generated endpoints call VerifyCSRF once the session is authenticated.
+func VerifyCSRF(ctx context.Context, s *SessionScheme) error {
+	if s.CSRF == "" {
+		return nil
+	}
+	csrf := ContextCSRF(ctx)
+	if csrf != nil && csrf.Safe {
+		return nil
+	}
+	if csrf == nil || csrf.Token == "" {
+		return csrfError(ErrMissingCSRFToken)
+	}
+	expected := csrf.Cookie
+	if s.CSRF == CSRFSynchronizerToken {
+		expected = ContextCSRFToken(ctx)
+	}
+	if expected == "" || subtle.ConstantTimeCompare([]byte(csrf.Token), []byte(expected)) != 1 {
+		return csrfError(ErrInvalidCSRFToken)
+	}
+	return nil
+}
+
+// NewCSRFToken returns a random token suitable for CSRF protection.
+func NewCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// WithCSRF returns a copy of ctx that holds the given request CSRF data. The
+// transports call WithCSRF so that the endpoints secured with a cookie session
+// scheme may verify the CSRF token.
+func WithCSRF(ctx context.Context, csrf *CSRF) context.Context {
+	return context.WithValue(ctx, csrfKey{}, csrf)
+}
+
+// ContextCSRF returns the request CSRF data stored in ctx, nil if there isn't
+// one.
+func ContextCSRF(ctx context.Context) *CSRF {
+	csrf, _ := ctx.Value(csrfKey{}).(*CSRF)
+	return csrf
+}
+
+// WithCSRFToken returns a copy of ctx that holds the CSRF token of the
+// session. Server side the AuthSessionFunc of schemes that use the
+// synchronizer token mode stores the token associated with the session so
+// that VerifyCSRF may check the request. Client side the generated clients
+// send the token in the CSRF header.
+func WithCSRFToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, csrfTokenKey{}, token)
+}
+
+// ContextCSRFToken returns the CSRF token stored in ctx, empty if there isn't
+// one.
+func ContextCSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfTokenKey{}).(string)
+	return token
+}
+
+// csrfError wraps err into a permission denied error.
+func csrfError(err error) error {
+	return goa.NewServiceError(err, goa.PermissionDenied, false, false, false)
+}
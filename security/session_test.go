@@ -0,0 +1,60 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestVerifyCSRF(t *testing.T) {
+	var (
+		doubleSubmit = &SessionScheme{Name: "session", CSRF: CSRFDoubleSubmit}
+		synchronizer = &SessionScheme{Name: "session", CSRF: CSRFSynchronizerToken}
+		disabled     = &SessionScheme{Name: "session"}
+	)
+	cases := []struct {
+		Name    string
+		Scheme  *SessionScheme
+		CSRF    *CSRF
+		Session string
+		Error   error
+	}{
+		{"disabled", disabled, nil, "", nil},
+		{"safe-method", doubleSubmit, &CSRF{Safe: true}, "", nil},
+		{"double-submit", doubleSubmit, &CSRF{Token: "tok", Cookie: "tok"}, "", nil},
+		{"double-submit-mismatch", doubleSubmit, &CSRF{Token: "tok", Cookie: "other"}, "", ErrInvalidCSRFToken},
+		{"double-submit-no-cookie", doubleSubmit, &CSRF{Token: "tok"}, "", ErrInvalidCSRFToken},
+		{"missing-token", doubleSubmit, &CSRF{Cookie: "tok"}, "", ErrMissingCSRFToken},
+		{"missing-csrf", doubleSubmit, nil, "", ErrMissingCSRFToken},
+		{"synchronizer", synchronizer, &CSRF{Token: "tok"}, "tok", nil},
+		{"synchronizer-mismatch", synchronizer, &CSRF{Token: "tok", Cookie: "tok"}, "other", ErrInvalidCSRFToken},
+		{"synchronizer-no-session-token", synchronizer, &CSRF{Token: "tok"}, "", ErrInvalidCSRFToken},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.CSRF != nil {
+				ctx = WithCSRF(ctx, c.CSRF)
+			}
+			if c.Session != "" {
+				ctx = WithCSRFToken(ctx, c.Session)
+			}
+			err := VerifyCSRF(ctx, c.Scheme)
+			if c.Error == nil {
+				if err != nil {
+					t.Errorf("got error %q, expected none", err)
+				}
+				return
+			}
+			if !errors.Is(err, c.Error) {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+			var gerr *goa.ServiceError
+			if !errors.As(err, &gerr) || gerr.Name != goa.PermissionDenied {
+				t.Errorf("got error %v, expected a %q error", err, goa.PermissionDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package security
+
+import (
+	"container/heap"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureAlgorithm is the algorithm used to sign requests secured
+	// with a signature scheme.
+	SignatureAlgorithm = "hmac-sha256"
+
+	// DefaultMaxClockSkew is the maximum difference between the signature
+	// creation time and the server clock used when the scheme does not
+	// define one.
+	DefaultMaxClockSkew = 5 * time.Minute
+)
+
+var (
+	// ErrMissingSignature is the error returned by VerifySignature when
+	// the request is not signed.
+	ErrMissingSignature = errors.New("missing request signature")
+	// ErrInvalidSignature is the error returned by VerifySignature when
+	// the signature is malformed, does not cover the components required
+	// by the scheme or does not match the request.
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrSignatureExpired is the error returned by VerifySignature when
+	// the signature creation time is outside of the allowed clock skew.
+	ErrSignatureExpired = errors.New("request signature expired")
+	// ErrReplayedNonce is the error returned by NonceStore implementations
+	// when a nonce is used more than once.
+	ErrReplayedNonce = errors.New("request signature nonce already used")
+)
+
+type (
+	// SignatureScheme represents the request signature security scheme.
+	// It consists of an HMAC signature computed over a set of request
+	// components with a secret key shared by the client and the server.
+	SignatureScheme struct {
+		// Name is the scheme name defined in the design.
+		Name string
+		// Scopes holds a list of scopes for the scheme.
+		Scopes []string
+		// RequiredScopes holds a list of scopes which are required
+		// by the scheme. It is a subset of Scopes field.
+		RequiredScopes []string
+		// Components lists the request components that the signature
+		// must cover, e.g. "@method", "@path" or "content-digest".
+		Components []string
+		// MaxClockSkew is the maximum difference between the signature
+		// creation time and the server clock.
+		MaxClockSkew time.Duration
+	}
+
+	// Signature is a request signature read by the transport.
+	Signature struct {
+		// KeyID identifies the key used to sign the request.
+		KeyID string
+		// Algorithm is the signature algorithm.
+		Algorithm string
+		// Created is the signature creation time.
+		Created time.Time
+		// Nonce is the unique value used to prevent replays.
+		Nonce string
+		// Components lists the request components covered by the
+		// signature.
+		Components []string
+		// Base is the signature base computed by the transport from the
+		// request.
+		Base []byte
+		// Value is the signature sent by the client.
+		Value []byte
+		// Err is the error that occurred while reading the signature if
+		// any.
+		Err error
+	}
+
+	// SigningKey is the key used by clients to sign requests.
+	SigningKey struct {
+		// ID identifies the key, it is sent along with the signature.
+		ID string
+		// Secret is the secret shared with the server.
+		Secret []byte
+	}
+
+	// SignatureKeyFunc is the function type that looks up the secret key
+	// identified by keyID.
+	SignatureKeyFunc func(ctx context.Context, keyID string) ([]byte, error)
+
+	// NonceStore records the nonces of verified signatures so that
+	// requests cannot be replayed.
+	NonceStore interface {
+		// Use records the nonce for the given key. It returns
+		// ErrReplayedNonce if the nonce was already recorded and has not
+		// expired yet.
+		Use(ctx context.Context, keyID, nonce string, expires time.Time) error
+	}
+
+	// memoryNonceStore is a NonceStore that keeps the nonces in memory.
+	memoryNonceStore struct {
+		mu     sync.Mutex
+		nonces map[string]struct{}
+		// expiries orders the recorded nonces by expiration time so
+		// that expired nonces are removed without scanning all nonces.
+		expiries nonceHeap
+	}
+
+	// nonceHeap is a min-heap of nonces ordered by expiration time.
+	nonceHeap []nonceExpiry
+
+	// nonceExpiry is a recorded nonce and its expiration time.
+	nonceExpiry struct {
+		key     string
+		expires time.Time
+	}
+
+	// signatureKey is the context key used to store the request signature.
+	signatureKey struct{}
+
+	// signingKeyKey is the context key used to store the signing key.
+	signingKeyKey struct{}
+)
+
+// Validate returns a non-nil error if scopes does not contain all of
+// signature scheme's required scopes.
+func (s *SignatureScheme) Validate(scopes []string) error {
+	return validateScopes(s.RequiredScopes, scopes)
+}
+
+// Sign returns the HMAC-SHA256 signature of base.
+func (k *SigningKey) Sign(base []byte) []byte {
+	return sign(k.Secret, base)
+}
+
+// VerifySignature verifies that sig satisfies the scheme s. It checks that
+// the signature covers the components required by the scheme, that it was
+// created within the allowed clock skew and that it matches the secret key
+// returned by keyFn. Finally it records the signature nonce in nonces if not
+// nil so that the request cannot be replayed.
+func VerifySignature(ctx context.Context, sig *Signature, s *SignatureScheme, keyFn SignatureKeyFunc, nonces NonceStore) error {
+	if sig == nil {
+		return ErrMissingSignature
+	}
+	if sig.Err != nil {
+		return sig.Err
+	}
+	if sig.Algorithm != "" && sig.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, sig.Algorithm)
+	}
+	for _, c := range s.Components {
+		if !slices.Contains(sig.Components, c) {
+			return fmt.Errorf("%w: component %q is not signed", ErrInvalidSignature, c)
+		}
+	}
+	if sig.Created.IsZero() {
+		return fmt.Errorf("%w: missing creation time", ErrInvalidSignature)
+	}
+	skew := s.MaxClockSkew
+	if skew <= 0 {
+		skew = DefaultMaxClockSkew
+	}
+	if d := time.Since(sig.Created); d > skew || d < -skew {
+		return ErrSignatureExpired
+	}
+	key, err := keyFn(ctx, sig.KeyID)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(sign(key, sig.Base), sig.Value) {
+		return ErrInvalidSignature
+	}
+	if nonces == nil {
+		return nil
+	}
+	if sig.Nonce == "" {
+		return fmt.Errorf("%w: missing nonce", ErrInvalidSignature)
+	}
+	return nonces.Use(ctx, sig.KeyID, sig.Nonce, sig.Created.Add(skew))
+}
+
+// NewMemoryNonceStore returns a NonceStore that keeps the nonces in memory.
+// It is suitable for services that run a single instance, services running
+// multiple instances should use a shared store instead.
+func NewMemoryNonceStore() NonceStore {
+	return &memoryNonceStore{nonces: make(map[string]struct{})}
+}
+
+// Use implements NonceStore.
+func (s *memoryNonceStore) Use(_ context.Context, keyID, nonce string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for len(s.expiries) > 0 && now.After(s.expiries[0].expires) {
+		delete(s.nonces, heap.Pop(&s.expiries).(nonceExpiry).key)
+	}
+	k := keyID + "\x00" + nonce
+	if _, ok := s.nonces[k]; ok {
+		return ErrReplayedNonce
+	}
+	s.nonces[k] = struct{}{}
+	heap.Push(&s.expiries, nonceExpiry{key: k, expires: expires})
+	return nil
+}
+
+// Len implements heap.Interface.
+func (h nonceHeap) Len() int { return len(h) }
+
+// Less implements heap.Interface.
+func (h nonceHeap) Less(i, j int) bool { return h[i].expires.Before(h[j].expires) }
+
+// Swap implements heap.Interface.
+func (h nonceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push implements heap.Interface.
+func (h *nonceHeap) Push(x any) { *h = append(*h, x.(nonceExpiry)) }
+
+// Pop implements heap.Interface.
+func (h *nonceHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// WithSignature returns a copy of ctx that holds the given request signature.
+// The transports call WithSignature so that the endpoints secured with a
+// signature scheme may retrieve it.
+func WithSignature(ctx context.Context, sig *Signature) context.Context {
+	return context.WithValue(ctx, signatureKey{}, sig)
+}
+
+// ContextSignature returns the request signature stored in ctx, nil if there
+// isn't one.
+func ContextSignature(ctx context.Context) *Signature {
+	sig, _ := ctx.Value(signatureKey{}).(*Signature)
+	return sig
+}
+
+// WithSigningKey returns a copy of ctx that holds the key used by the
+// generated clients to sign the requests made to endpoints secured with a
+// signature scheme.
+func WithSigningKey(ctx context.Context, key *SigningKey) context.Context {
+	return context.WithValue(ctx, signingKeyKey{}, key)
+}
+
+// ContextSigningKey returns the signing key stored in ctx, nil if there isn't
+// one.
+func ContextSigningKey(ctx context.Context) *SigningKey {
+	key, _ := ctx.Value(signingKeyKey{}).(*SigningKey)
+	return key
+}
+
+// sign computes the HMAC-SHA256 of base using key.
+func sign(key, base []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(base)
+	return h.Sum(nil)
+}